@@ -29,6 +29,19 @@ To configure the client, pass a Config object to the NewClient function:
 
 		elasticsearch.NewClient(cfg)
 
+To trust a custom certificate authority, pass the PEM-encoded certificate in the CACert option;
+use the ClientCert and ClientKey options for client certificate authentication,
+and the CertificateFingerprint option to pin the SHA256 fingerprint of a certificate in the server chain,
+instead of verifying it with the certificate authorities; it cannot be used together with CACert.
+The TLS options require the Transport to be either nil or a *http.Transport, which is copied, not modified.
+
+		cert, _ := ioutil.ReadFile("ca.crt")
+
+		cfg := elasticsearch.Config{
+		  Addresses: []string{"https://localhost:9200"},
+		  CACert:    cert,
+		}
+
 When using the Elastic Service (https://elastic.co/cloud), you can use CloudID instead of Addresses.
 When either Addresses or CloudID is set, the ELASTICSEARCH_URL environment variable is ignored.
 
@@ -45,6 +58,14 @@ Call the Elasticsearch APIs by invoking the corresponding methods on the client:
 
 See the github.com/elastic/go-elasticsearch/esapi package for more information about using the API.
 
+Call the Close method to stop the background goroutines, such as the periodic node discovery,
+and to wait for the in-flight requests to finish; any request performed afterwards returns an error:
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		es.Close(ctx)
+
 See the github.com/elastic/go-elasticsearch/estransport package for more information about configuring the transport.
 */
 package elasticsearch
@@ -5,7 +5,13 @@
 package elasticsearch
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
@@ -37,23 +43,61 @@ type Config struct {
 	CloudID string // Endpoint for the Elastic Service (https://elastic.co/cloud).
 	APIKey  string // Base64-encoded token for authorization; if set, overrides username and password.
 
+	CACert                 []byte // PEM-encoded certificate authorities; if set, the transport is configured to trust them.
+	ClientCert             []byte // PEM-encoded client certificate for TLS client authentication; requires ClientKey.
+	ClientKey              []byte // PEM-encoded client private key for TLS client authentication; requires ClientCert.
+	CertificateFingerprint string // SHA256 hex fingerprint of a certificate in the server chain; if set, only that chain is trusted. Cannot be used with CACert.
+
+	CompressRequestBody bool // Compress the request body with gzip. Default: false.
+
 	RetryOnStatus        []int // List of status codes for retry. Default: 502, 503, 504.
 	DisableRetry         bool  // Default: false.
 	EnableRetryOnTimeout bool  // Default: false.
 	MaxRetries           int   // Default: 3.
 
+	RetryTimeout   time.Duration // The time limit for the request, including retries. Default: disabled.
+	AttemptTimeout time.Duration // The time limit for a single attempt. Default: disabled.
+
+	RetryOnTooManyRequests bool // Retry requests rejected with 429, respecting Retry-After. Default: false.
+
 	DiscoverNodesOnStart  bool          // Discover nodes when initializing the client. Default: false.
 	DiscoverNodesInterval time.Duration // Discover nodes periodically. Default: disabled.
 
+	// Optional filter for the discovered nodes. Default: nodes with the data and ingest roles.
+	DiscoveryFilter func(estransport.NodeInfo) bool
+
+	HealthCheckInterval    time.Duration // Probe the dead connections periodically. Default: disabled.
+	HealthCheckTimeout     time.Duration // The time limit for a single probe. Default: 5s.
+	HealthCheckConcurrency int           // The maximum number of concurrent probes. Default: 4.
+
+	// Enable the circuit breakers per node and per cluster. Default: disabled.
+	CircuitBreaker *estransport.CircuitBreakerConfig
+
+	HedgeDelay time.Duration // The delay before hedging the requests enabled with estransport.WithHedging. Default: 100ms.
+
+	// Limit the rate and concurrency of requests, globally or per path. Default: disabled.
+	RateLimits []estransport.RateLimit
+
+	// Headers added to every request; the headers set on the request are not overridden.
+	Header http.Header
+
+	// Optional function called before every attempt, which can change any header of the request.
+	HeaderFunc func(*http.Request)
+
 	EnableMetrics     bool // Enable the metrics collection.
 	EnableDebugLogger bool // Enable the debug logging.
 
+	Instrumentation estransport.Instrumentation // The instrumentation for tracing the requests. Default: no-op.
+
 	RetryBackoff func(attempt int) time.Duration // Optional backoff duration. Default: nil.
 
-	Transport http.RoundTripper    // The HTTP transport object.
+	Transport http.RoundTripper    // The HTTP transport object; must be *http.Transport when the TLS options are set.
 	Logger    estransport.Logger   // The logger object.
 	Selector  estransport.Selector // The selector object.
 
+	// Optional function returning the weight of a connection for estransport.NewWeightedSelector.
+	ConnectionWeight func(*estransport.Connection) int
+
 	// Optional constructor function for a custom ConnectionPool. Default: nil.
 	ConnectionPoolFunc func([]*estransport.Connection, estransport.Selector) estransport.ConnectionPool
 }
@@ -128,26 +172,60 @@ func NewClient(cfg Config) (*Client, error) {
 		cfg.Password = pw
 	}
 
+	if len(cfg.CACert) > 0 && cfg.CertificateFingerprint != "" {
+		return nil, errors.New("cannot create client: both CACert and CertificateFingerprint are set")
+	}
+
+	if len(cfg.CACert) > 0 || len(cfg.ClientCert) > 0 || len(cfg.ClientKey) > 0 || cfg.CertificateFingerprint != "" {
+		tr, err := transportWithTLS(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create client: %s", err)
+		}
+		cfg.Transport = tr
+	}
+
 	tp := estransport.New(estransport.Config{
 		URLs:     urls,
 		Username: cfg.Username,
 		Password: cfg.Password,
 		APIKey:   cfg.APIKey,
 
+		CompressRequestBody: cfg.CompressRequestBody,
+
 		RetryOnStatus:        cfg.RetryOnStatus,
 		DisableRetry:         cfg.DisableRetry,
 		EnableRetryOnTimeout: cfg.EnableRetryOnTimeout,
 		MaxRetries:           cfg.MaxRetries,
 		RetryBackoff:         cfg.RetryBackoff,
+		RetryTimeout:         cfg.RetryTimeout,
+		AttemptTimeout:       cfg.AttemptTimeout,
+
+		RetryOnTooManyRequests: cfg.RetryOnTooManyRequests,
 
 		EnableMetrics:     cfg.EnableMetrics,
 		EnableDebugLogger: cfg.EnableDebugLogger,
 
+		Instrumentation: cfg.Instrumentation,
+
 		DiscoverNodesInterval: cfg.DiscoverNodesInterval,
+		DiscoveryFilter:       cfg.DiscoveryFilter,
+
+		HealthCheckInterval:    cfg.HealthCheckInterval,
+		HealthCheckTimeout:     cfg.HealthCheckTimeout,
+		HealthCheckConcurrency: cfg.HealthCheckConcurrency,
+
+		CircuitBreaker: cfg.CircuitBreaker,
+
+		HedgeDelay: cfg.HedgeDelay,
+		RateLimits: cfg.RateLimits,
+
+		Header:     cfg.Header,
+		HeaderFunc: cfg.HeaderFunc,
 
 		Transport:          cfg.Transport,
 		Logger:             cfg.Logger,
 		Selector:           cfg.Selector,
+		ConnectionWeight:   cfg.ConnectionWeight,
 		ConnectionPoolFunc: cfg.ConnectionPoolFunc,
 	})
 
@@ -184,6 +262,111 @@ func (c *Client) DiscoverNodes() error {
 	return errors.New("transport is missing method DiscoverNodes()")
 }
 
+// Close stops the background goroutines of the transport, and waits until the in-flight requests
+// are finished, or until ctx is done. Any request performed after Close returns estransport.ErrClosed.
+//
+func (c *Client) Close(ctx context.Context) error {
+	if ct, ok := c.Transport.(estransport.Closeable); ok {
+		return ct.Close(ctx)
+	}
+	return errors.New("transport is missing method Close()")
+}
+
+// transportWithTLS returns a copy of the configured transport, or of http.DefaultTransport,
+// with the TLS client configuration set from the CACert, ClientCert, ClientKey
+// and CertificateFingerprint options.
+//
+func transportWithTLS(cfg Config) (*http.Transport, error) {
+	var tr *http.Transport
+
+	switch t := cfg.Transport.(type) {
+	case nil:
+		tr = cloneTransport(http.DefaultTransport.(*http.Transport))
+	case *http.Transport:
+		tr = cloneTransport(t)
+	default:
+		return nil, fmt.Errorf("unable to configure TLS: transport is not *http.Transport, but %T", cfg.Transport)
+	}
+
+	if tr.TLSClientConfig == nil {
+		tr.TLSClientConfig = &tls.Config{}
+	}
+
+	if len(cfg.CACert) > 0 {
+		pool := x509.NewCertPool()
+		if ok := pool.AppendCertsFromPEM(cfg.CACert); !ok {
+			return nil, errors.New("unable to add CA certificate: no valid PEM certificate found")
+		}
+		tr.TLSClientConfig.RootCAs = pool
+	}
+
+	if len(cfg.ClientCert) > 0 || len(cfg.ClientKey) > 0 {
+		if len(cfg.ClientCert) == 0 || len(cfg.ClientKey) == 0 {
+			return nil, errors.New("unable to add client certificate: both ClientCert and ClientKey must be set")
+		}
+		cert, err := tls.X509KeyPair(cfg.ClientCert, cfg.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to add client certificate: %s", err)
+		}
+		tr.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if cfg.CertificateFingerprint != "" {
+		fingerprint, err := hex.DecodeString(strings.Replace(cfg.CertificateFingerprint, ":", "", -1))
+		if err != nil || len(fingerprint) != sha256.Size {
+			return nil, fmt.Errorf("unable to parse certificate fingerprint: %q", cfg.CertificateFingerprint)
+		}
+
+		// The chain is verified by the fingerprint only, instead of the certificate authorities
+		tr.TLSClientConfig.InsecureSkipVerify = true
+		tr.TLSClientConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			for _, raw := range rawCerts {
+				digest := sha256.Sum256(raw)
+				if bytes.Equal(digest[:], fingerprint) {
+					return nil
+				}
+			}
+			return fmt.Errorf("certificate fingerprint mismatch: no certificate in chain matches %q", cfg.CertificateFingerprint)
+		}
+	}
+
+	return tr, nil
+}
+
+// cloneTransport returns a copy of the transport, without its idle connections.
+//
+// TODO: Use http.Transport.Clone() when the minimum supported version is Go 1.13
+//
+func cloneTransport(t *http.Transport) *http.Transport {
+	tr := &http.Transport{
+		Proxy:                  t.Proxy,
+		DialContext:            t.DialContext,
+		Dial:                   t.Dial,
+		DialTLS:                t.DialTLS,
+		TLSHandshakeTimeout:    t.TLSHandshakeTimeout,
+		DisableKeepAlives:      t.DisableKeepAlives,
+		DisableCompression:     t.DisableCompression,
+		MaxIdleConns:           t.MaxIdleConns,
+		MaxIdleConnsPerHost:    t.MaxIdleConnsPerHost,
+		MaxConnsPerHost:        t.MaxConnsPerHost,
+		IdleConnTimeout:        t.IdleConnTimeout,
+		ResponseHeaderTimeout:  t.ResponseHeaderTimeout,
+		ExpectContinueTimeout:  t.ExpectContinueTimeout,
+		ProxyConnectHeader:     t.ProxyConnectHeader,
+		MaxResponseHeaderBytes: t.MaxResponseHeaderBytes,
+	}
+	if t.TLSClientConfig != nil {
+		tr.TLSClientConfig = t.TLSClientConfig.Clone()
+	}
+	if t.TLSNextProto != nil {
+		tr.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper, len(t.TLSNextProto))
+		for k, v := range t.TLSNextProto {
+			tr.TLSNextProto[k] = v
+		}
+	}
+	return tr
+}
+
 // addrsFromEnvironment returns a list of addresses by splitting
 // the ELASTICSEARCH_URL environment variable with comma, or an empty list.
 //
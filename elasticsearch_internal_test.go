@@ -7,12 +7,20 @@
 package elasticsearch
 
 import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
 	"errors"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/elastic/go-elasticsearch/v8/estransport"
@@ -336,3 +344,227 @@ func TestClientMetrics(t *testing.T) {
 		t.Errorf("Unexpected output: %s", m)
 	}
 }
+
+func TestClientClose(t *testing.T) {
+	t.Run("Close", func(t *testing.T) {
+		c, _ := NewClient(Config{Transport: &mockTransp{}})
+
+		if err := c.Close(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if _, err := c.Info(); err != estransport.ErrClosed {
+			t.Errorf("Expected ErrClosed, got: %v", err)
+		}
+	})
+
+	t.Run("Transport without Close()", func(t *testing.T) {
+		c, _ := NewClient(Config{})
+		c.Transport = &mockTransport{}
+
+		if err := c.Close(context.Background()); err == nil {
+			t.Errorf("Expected error, got: %v", err)
+		}
+	})
+}
+
+type mockTransport struct{}
+
+func (t *mockTransport) Perform(req *http.Request) (*http.Response, error) {
+	return &http.Response{}, nil
+}
+
+func TestClientTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+			w.Header().Set("X-Client-Cert", r.TLS.PeerCertificates[0].Subject.String())
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	digest := sha256.Sum256(srv.Certificate().Raw)
+
+	t.Run("Without CACert", func(t *testing.T) {
+		c, _ := NewClient(Config{Addresses: []string{srv.URL}, DisableRetry: true})
+
+		if _, err := c.Info(); err == nil {
+			t.Errorf("Expected error for unknown certificate authority")
+		}
+	})
+
+	t.Run("With CACert", func(t *testing.T) {
+		c, err := NewClient(Config{Addresses: []string{srv.URL}, CACert: caCert})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		res, err := c.Info()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		defer res.Body.Close()
+	})
+
+	t.Run("With invalid CACert", func(t *testing.T) {
+		_, err := NewClient(Config{CACert: []byte("invalid")})
+		if err == nil {
+			t.Fatalf("Expected error, got: %v", err)
+		}
+		if !strings.Contains(err.Error(), "unable to add CA certificate") {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("With CACert and custom transport", func(t *testing.T) {
+		tr := &http.Transport{}
+
+		c, err := NewClient(Config{Addresses: []string{srv.URL}, CACert: caCert, Transport: tr})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		res, err := c.Info()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		defer res.Body.Close()
+
+		if tr.TLSClientConfig != nil && tr.TLSClientConfig.RootCAs != nil {
+			t.Errorf("Expected the custom transport to not be modified")
+		}
+	})
+
+	t.Run("With CACert and non-HTTP transport", func(t *testing.T) {
+		_, err := NewClient(Config{CACert: caCert, Transport: &mockTransp{}})
+		if err == nil {
+			t.Fatalf("Expected error, got: %v", err)
+		}
+		if !strings.Contains(err.Error(), "transport is not *http.Transport") {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("With client certificate", func(t *testing.T) {
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS == nil || len(r.TLS.PeerCertificates) < 1 {
+				http.Error(w, "missing client certificate", 401)
+				return
+			}
+			w.Write([]byte(`{}`))
+		}))
+		srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+		srv.StartTLS()
+		defer srv.Close()
+
+		cert, _ := ioutil.ReadFile("estransport/testdata/cert.pem")
+		key, _ := ioutil.ReadFile("estransport/testdata/key.pem")
+		caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+		c, err := NewClient(Config{
+			Addresses:  []string{srv.URL},
+			CACert:     caCert,
+			ClientCert: cert,
+			ClientKey:  key,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		res, err := c.Info()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode != 200 {
+			t.Errorf("Unexpected response: %s", res)
+		}
+	})
+
+	t.Run("With client certificate without key", func(t *testing.T) {
+		cert, _ := ioutil.ReadFile("estransport/testdata/cert.pem")
+
+		_, err := NewClient(Config{ClientCert: cert})
+		if err == nil {
+			t.Fatalf("Expected error, got: %v", err)
+		}
+		if !strings.Contains(err.Error(), "both ClientCert and ClientKey must be set") {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("With CertificateFingerprint", func(t *testing.T) {
+		c, err := NewClient(Config{
+			Addresses:              []string{srv.URL},
+			CertificateFingerprint: hex.EncodeToString(digest[:]),
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		res, err := c.Info()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		defer res.Body.Close()
+	})
+
+	t.Run("With CertificateFingerprint with colons", func(t *testing.T) {
+		var parts []string
+		for _, b := range digest {
+			parts = append(parts, strings.ToUpper(hex.EncodeToString([]byte{b})))
+		}
+
+		c, err := NewClient(Config{
+			Addresses:              []string{srv.URL},
+			CertificateFingerprint: strings.Join(parts, ":"),
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		res, err := c.Info()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		defer res.Body.Close()
+	})
+
+	t.Run("With mismatched CertificateFingerprint", func(t *testing.T) {
+		c, err := NewClient(Config{
+			Addresses:              []string{srv.URL},
+			CertificateFingerprint: strings.Repeat("ab", sha256.Size),
+			DisableRetry:           true,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		_, err = c.Info()
+		if err == nil {
+			t.Fatalf("Expected error, got: %v", err)
+		}
+		if !strings.Contains(err.Error(), "fingerprint mismatch") {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("With invalid CertificateFingerprint", func(t *testing.T) {
+		_, err := NewClient(Config{CertificateFingerprint: "foobar"})
+		if err == nil {
+			t.Fatalf("Expected error, got: %v", err)
+		}
+	})
+
+	t.Run("With CACert and CertificateFingerprint", func(t *testing.T) {
+		_, err := NewClient(Config{CACert: caCert, CertificateFingerprint: strings.Repeat("ab", sha256.Size)})
+		if err == nil {
+			t.Fatalf("Expected error, got: %v", err)
+		}
+		if !strings.Contains(err.Error(), "both CACert and CertificateFingerprint") {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	})
+}
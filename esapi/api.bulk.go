@@ -56,6 +56,8 @@ type BulkRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -165,9 +167,7 @@ func (r BulkRequest) Do(ctx context.Context, transport Transport) (*Response, er
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "bulk", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -326,3 +326,11 @@ func (f Bulk) WithOpaqueID(s string) func(*BulkRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Bulk) WithRequestOptions(o RequestOptions) func(*BulkRequest) {
+	return func(r *BulkRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
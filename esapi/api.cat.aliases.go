@@ -50,6 +50,8 @@ type CatAliasesRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -141,9 +143,7 @@ func (r CatAliasesRequest) Do(ctx context.Context, transport Transport) (*Respon
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.aliases"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -278,3 +278,11 @@ func (f CatAliases) WithOpaqueID(s string) func(*CatAliasesRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatAliases) WithRequestOptions(o RequestOptions) func(*CatAliasesRequest) {
+	return func(r *CatAliasesRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -53,6 +53,8 @@ type CatAllocationRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -152,9 +154,7 @@ func (r CatAllocationRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.allocation"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -305,3 +305,11 @@ func (f CatAllocation) WithOpaqueID(s string) func(*CatAllocationRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatAllocation) WithRequestOptions(o RequestOptions) func(*CatAllocationRequest) {
+	return func(r *CatAllocationRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
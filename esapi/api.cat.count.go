@@ -49,6 +49,8 @@ type CatCountRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -136,9 +138,7 @@ func (r CatCountRequest) Do(ctx context.Context, transport Transport) (*Response
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.count", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -265,3 +265,11 @@ func (f CatCount) WithOpaqueID(s string) func(*CatCountRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatCount) WithRequestOptions(o RequestOptions) func(*CatCountRequest) {
+	return func(r *CatCountRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
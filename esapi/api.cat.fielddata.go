@@ -50,6 +50,8 @@ type CatFielddataRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -145,9 +147,7 @@ func (r CatFielddataRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.fielddata"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -282,3 +282,11 @@ func (f CatFielddata) WithOpaqueID(s string) func(*CatFielddataRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatFielddata) WithRequestOptions(o RequestOptions) func(*CatFielddataRequest) {
+	return func(r *CatFielddataRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
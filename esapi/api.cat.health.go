@@ -49,6 +49,8 @@ type CatHealthRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -137,9 +139,7 @@ func (r CatHealthRequest) Do(ctx context.Context, transport Transport) (*Respons
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.health"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -274,3 +274,11 @@ func (f CatHealth) WithOpaqueID(s string) func(*CatHealthRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatHealth) WithRequestOptions(o RequestOptions) func(*CatHealthRequest) {
+	return func(r *CatHealthRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -44,6 +44,8 @@ type CatHelpRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -112,9 +114,7 @@ func (r CatHelpRequest) Do(ctx context.Context, transport Transport) (*Response,
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.help"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -209,3 +209,11 @@ func (f CatHelp) WithOpaqueID(s string) func(*CatHelpRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatHelp) WithRequestOptions(o RequestOptions) func(*CatHelpRequest) {
+	return func(r *CatHelpRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
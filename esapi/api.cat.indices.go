@@ -57,6 +57,8 @@ type CatIndicesRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -172,9 +174,7 @@ func (r CatIndicesRequest) Do(ctx context.Context, transport Transport) (*Respon
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.indices", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -357,3 +357,11 @@ func (f CatIndices) WithOpaqueID(s string) func(*CatIndicesRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatIndices) WithRequestOptions(o RequestOptions) func(*CatIndicesRequest) {
+	return func(r *CatIndicesRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -50,6 +50,8 @@ type CatMasterRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -138,9 +140,7 @@ func (r CatMasterRequest) Do(ctx context.Context, transport Transport) (*Respons
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.master"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -275,3 +275,11 @@ func (f CatMaster) WithOpaqueID(s string) func(*CatMasterRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatMaster) WithRequestOptions(o RequestOptions) func(*CatMasterRequest) {
+	return func(r *CatMasterRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -50,6 +50,8 @@ type CatNodeattrsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -138,9 +140,7 @@ func (r CatNodeattrsRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.nodeattrs"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -275,3 +275,11 @@ func (f CatNodeattrs) WithOpaqueID(s string) func(*CatNodeattrsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatNodeattrs) WithRequestOptions(o RequestOptions) func(*CatNodeattrsRequest) {
+	return func(r *CatNodeattrsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -53,6 +53,8 @@ type CatNodesRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -153,9 +155,7 @@ func (r CatNodesRequest) Do(ctx context.Context, transport Transport) (*Response
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.nodes"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -314,3 +314,11 @@ func (f CatNodes) WithOpaqueID(s string) func(*CatNodesRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatNodes) WithRequestOptions(o RequestOptions) func(*CatNodesRequest) {
+	return func(r *CatNodesRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
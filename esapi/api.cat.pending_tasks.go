@@ -51,6 +51,8 @@ type CatPendingTasksRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -143,9 +145,7 @@ func (r CatPendingTasksRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.pending_tasks"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -288,3 +288,11 @@ func (f CatPendingTasks) WithOpaqueID(s string) func(*CatPendingTasksRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatPendingTasks) WithRequestOptions(o RequestOptions) func(*CatPendingTasksRequest) {
+	return func(r *CatPendingTasksRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -50,6 +50,8 @@ type CatPluginsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -138,9 +140,7 @@ func (r CatPluginsRequest) Do(ctx context.Context, transport Transport) (*Respon
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.plugins"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -275,3 +275,11 @@ func (f CatPlugins) WithOpaqueID(s string) func(*CatPluginsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatPlugins) WithRequestOptions(o RequestOptions) func(*CatPluginsRequest) {
+	return func(r *CatPluginsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -53,6 +53,8 @@ type CatRecoveryRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -160,9 +162,7 @@ func (r CatRecoveryRequest) Do(ctx context.Context, transport Transport) (*Respo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.recovery", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -321,3 +321,11 @@ func (f CatRecovery) WithOpaqueID(s string) func(*CatRecoveryRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatRecovery) WithRequestOptions(o RequestOptions) func(*CatRecoveryRequest) {
+	return func(r *CatRecoveryRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -50,6 +50,8 @@ type CatRepositoriesRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -138,9 +140,7 @@ func (r CatRepositoriesRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.repositories"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -275,3 +275,11 @@ func (f CatRepositories) WithOpaqueID(s string) func(*CatRepositoriesRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatRepositories) WithRequestOptions(o RequestOptions) func(*CatRepositoriesRequest) {
+	return func(r *CatRepositoriesRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -50,6 +50,8 @@ type CatSegmentsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -141,9 +143,7 @@ func (r CatSegmentsRequest) Do(ctx context.Context, transport Transport) (*Respo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.segments", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -278,3 +278,11 @@ func (f CatSegments) WithOpaqueID(s string) func(*CatSegmentsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatSegments) WithRequestOptions(o RequestOptions) func(*CatSegmentsRequest) {
+	return func(r *CatSegmentsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
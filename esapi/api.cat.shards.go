@@ -54,6 +54,8 @@ type CatShardsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -157,9 +159,7 @@ func (r CatShardsRequest) Do(ctx context.Context, transport Transport) (*Respons
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.shards", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -318,3 +318,11 @@ func (f CatShards) WithOpaqueID(s string) func(*CatShardsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatShards) WithRequestOptions(o RequestOptions) func(*CatShardsRequest) {
+	return func(r *CatShardsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
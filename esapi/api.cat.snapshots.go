@@ -53,6 +53,8 @@ type CatSnapshotsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -152,9 +154,7 @@ func (r CatSnapshotsRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.snapshots"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -305,3 +305,11 @@ func (f CatSnapshots) WithOpaqueID(s string) func(*CatSnapshotsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatSnapshots) WithRequestOptions(o RequestOptions) func(*CatSnapshotsRequest) {
+	return func(r *CatSnapshotsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -52,6 +52,8 @@ type CatTasksRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -152,9 +154,7 @@ func (r CatTasksRequest) Do(ctx context.Context, transport Transport) (*Response
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.tasks"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -313,3 +313,11 @@ func (f CatTasks) WithOpaqueID(s string) func(*CatTasksRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatTasks) WithRequestOptions(o RequestOptions) func(*CatTasksRequest) {
+	return func(r *CatTasksRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -52,6 +52,8 @@ type CatTemplatesRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -147,9 +149,7 @@ func (r CatTemplatesRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.templates"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -292,3 +292,11 @@ func (f CatTemplates) WithOpaqueID(s string) func(*CatTemplatesRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatTemplates) WithRequestOptions(o RequestOptions) func(*CatTemplatesRequest) {
+	return func(r *CatTemplatesRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
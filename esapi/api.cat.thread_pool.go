@@ -54,6 +54,8 @@ type CatThreadPoolRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -153,9 +155,7 @@ func (r CatThreadPoolRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cat.thread_pool"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -306,3 +306,11 @@ func (f CatThreadPool) WithOpaqueID(s string) func(*CatThreadPoolRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CatThreadPool) WithRequestOptions(o RequestOptions) func(*CatThreadPoolRequest) {
+	return func(r *CatThreadPoolRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
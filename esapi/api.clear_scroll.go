@@ -45,6 +45,8 @@ type ClearScrollRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -116,9 +118,7 @@ func (r ClearScrollRequest) Do(ctx context.Context, transport Transport) (*Respo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "clear_scroll"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -213,3 +213,11 @@ func (f ClearScroll) WithOpaqueID(s string) func(*ClearScrollRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ClearScroll) WithRequestOptions(o RequestOptions) func(*ClearScrollRequest) {
+	return func(r *ClearScrollRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -47,6 +47,8 @@ type ClusterAllocationExplainRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -119,9 +121,7 @@ func (r ClusterAllocationExplainRequest) Do(ctx context.Context, transport Trans
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cluster.allocation_explain"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -224,3 +224,11 @@ func (f ClusterAllocationExplain) WithOpaqueID(s string) func(*ClusterAllocation
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ClusterAllocationExplain) WithRequestOptions(o RequestOptions) func(*ClusterAllocationExplainRequest) {
+	return func(r *ClusterAllocationExplainRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
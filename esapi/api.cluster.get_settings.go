@@ -47,6 +47,8 @@ type ClusterGetSettingsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -123,9 +125,7 @@ func (r ClusterGetSettingsRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cluster.get_settings"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -236,3 +236,11 @@ func (f ClusterGetSettings) WithOpaqueID(s string) func(*ClusterGetSettingsReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ClusterGetSettings) WithRequestOptions(o RequestOptions) func(*ClusterGetSettingsRequest) {
+	return func(r *ClusterGetSettingsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -56,6 +56,8 @@ type ClusterHealthRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -167,9 +169,7 @@ func (r ClusterHealthRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cluster.health", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -344,3 +344,11 @@ func (f ClusterHealth) WithOpaqueID(s string) func(*ClusterHealthRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ClusterHealth) WithRequestOptions(o RequestOptions) func(*ClusterHealthRequest) {
+	return func(r *ClusterHealthRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
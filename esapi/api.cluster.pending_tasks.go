@@ -46,6 +46,8 @@ type ClusterPendingTasksRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r ClusterPendingTasksRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cluster.pending_tasks"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -211,3 +211,11 @@ func (f ClusterPendingTasks) WithOpaqueID(s string) func(*ClusterPendingTasksReq
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ClusterPendingTasks) WithRequestOptions(o RequestOptions) func(*ClusterPendingTasksRequest) {
+	return func(r *ClusterPendingTasksRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
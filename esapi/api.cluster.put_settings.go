@@ -49,6 +49,8 @@ type ClusterPutSettingsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -125,9 +127,7 @@ func (r ClusterPutSettingsRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cluster.put_settings"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -230,3 +230,11 @@ func (f ClusterPutSettings) WithOpaqueID(s string) func(*ClusterPutSettingsReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ClusterPutSettings) WithRequestOptions(o RequestOptions) func(*ClusterPutSettingsRequest) {
+	return func(r *ClusterPutSettingsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -40,6 +40,8 @@ type ClusterRemoteInfoRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r ClusterRemoteInfoRequest) Do(ctx context.Context, transport Transport) (
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cluster.remote_info"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f ClusterRemoteInfo) WithOpaqueID(s string) func(*ClusterRemoteInfoRequest
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ClusterRemoteInfo) WithRequestOptions(o RequestOptions) func(*ClusterRemoteInfoRequest) {
+	return func(r *ClusterRemoteInfoRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -52,9 +52,54 @@ type ClusterRerouteRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
+// ClusterRerouteBody represents the body of the Cluster Reroute API request.
+//
+type ClusterRerouteBody struct {
+	Commands []ClusterRerouteCommand `json:"commands,omitempty"`
+}
+
+// ClusterRerouteCommand represents a single reroute command; set exactly one of the fields.
+//
+type ClusterRerouteCommand struct {
+	Move                 *ClusterRerouteMove     `json:"move,omitempty"`
+	Cancel               *ClusterRerouteCancel   `json:"cancel,omitempty"`
+	AllocateReplica      *ClusterRerouteAllocate `json:"allocate_replica,omitempty"`
+	AllocateStalePrimary *ClusterRerouteAllocate `json:"allocate_stale_primary,omitempty"`
+	AllocateEmptyPrimary *ClusterRerouteAllocate `json:"allocate_empty_primary,omitempty"`
+}
+
+// ClusterRerouteMove moves a started shard from one node to another node.
+//
+type ClusterRerouteMove struct {
+	Index    string `json:"index"`
+	Shard    int    `json:"shard"`
+	FromNode string `json:"from_node"`
+	ToNode   string `json:"to_node"`
+}
+
+// ClusterRerouteCancel cancels the allocation of a shard, or its recovery.
+//
+type ClusterRerouteCancel struct {
+	Index        string `json:"index"`
+	Shard        int    `json:"shard"`
+	Node         string `json:"node"`
+	AllowPrimary bool   `json:"allow_primary,omitempty"`
+}
+
+// ClusterRerouteAllocate allocates an unassigned shard to a node.
+//
+type ClusterRerouteAllocate struct {
+	Index          string `json:"index"`
+	Shard          int    `json:"shard"`
+	Node           string `json:"node"`
+	AcceptDataLoss bool   `json:"accept_data_loss,omitempty"`
+}
+
 // Do executes the request and returns response or error.
 //
 func (r ClusterRerouteRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
@@ -140,9 +185,7 @@ func (r ClusterRerouteRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cluster.reroute"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -174,6 +217,14 @@ func (f ClusterReroute) WithBody(v io.Reader) func(*ClusterRerouteRequest) {
 	}
 }
 
+// WithBodyStruct sets the request body to v encoded into JSON.
+//
+func (f ClusterReroute) WithBodyStruct(v *ClusterRerouteBody) func(*ClusterRerouteRequest) {
+	return func(r *ClusterRerouteRequest) {
+		r.Body = newJSONReader(v)
+	}
+}
+
 // WithDryRun - simulate the operation only and return the resulting state.
 //
 func (f ClusterReroute) WithDryRun(v bool) func(*ClusterRerouteRequest) {
@@ -277,3 +328,11 @@ func (f ClusterReroute) WithOpaqueID(s string) func(*ClusterRerouteRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ClusterReroute) WithRequestOptions(o RequestOptions) func(*ClusterRerouteRequest) {
+	return func(r *ClusterRerouteRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -55,6 +55,8 @@ type ClusterStateRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -158,9 +160,7 @@ func (r ClusterStateRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cluster.state", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -319,3 +319,11 @@ func (f ClusterState) WithOpaqueID(s string) func(*ClusterStateRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ClusterState) WithRequestOptions(o RequestOptions) func(*ClusterStateRequest) {
+	return func(r *ClusterStateRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -47,6 +47,8 @@ type ClusterStatsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -124,9 +126,7 @@ func (r ClusterStatsRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "cluster.stats"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -229,3 +229,11 @@ func (f ClusterStats) WithOpaqueID(s string) func(*ClusterStatsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ClusterStats) WithRequestOptions(o RequestOptions) func(*ClusterStatsRequest) {
+	return func(r *ClusterStatsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
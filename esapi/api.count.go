@@ -61,6 +61,8 @@ type CountRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -186,9 +188,7 @@ func (r CountRequest) Do(ctx context.Context, transport Transport) (*Response, e
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "count", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -395,3 +395,11 @@ func (f Count) WithOpaqueID(s string) func(*CountRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Count) WithRequestOptions(o RequestOptions) func(*CountRequest) {
+	return func(r *CountRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -58,6 +58,8 @@ type CreateRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -155,9 +157,7 @@ func (r CreateRequest) Do(ctx context.Context, transport Transport) (*Response,
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "create", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -292,3 +292,11 @@ func (f Create) WithOpaqueID(s string) func(*CreateRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Create) WithRequestOptions(o RequestOptions) func(*CreateRequest) {
+	return func(r *CreateRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
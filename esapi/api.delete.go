@@ -54,6 +54,8 @@ type DeleteRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -151,9 +153,7 @@ func (r DeleteRequest) Do(ctx context.Context, transport Transport) (*Response,
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "delete", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -296,3 +296,11 @@ func (f Delete) WithOpaqueID(s string) func(*DeleteRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Delete) WithRequestOptions(o RequestOptions) func(*DeleteRequest) {
+	return func(r *DeleteRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
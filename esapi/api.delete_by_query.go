@@ -80,6 +80,8 @@ type DeleteByQueryRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -275,9 +277,7 @@ func (r DeleteByQueryRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "delete_by_query", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -612,3 +612,11 @@ func (f DeleteByQuery) WithOpaqueID(s string) func(*DeleteByQueryRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DeleteByQuery) WithRequestOptions(o RequestOptions) func(*DeleteByQueryRequest) {
+	return func(r *DeleteByQueryRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -45,6 +45,8 @@ type DeleteByQueryRethrottleRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r DeleteByQueryRethrottleRequest) Do(ctx context.Context, transport Transp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "delete_by_query_rethrottle"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -203,3 +203,11 @@ func (f DeleteByQueryRethrottle) WithOpaqueID(s string) func(*DeleteByQueryRethr
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DeleteByQueryRethrottle) WithRequestOptions(o RequestOptions) func(*DeleteByQueryRethrottleRequest) {
+	return func(r *DeleteByQueryRethrottleRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
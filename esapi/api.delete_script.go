@@ -46,6 +46,8 @@ type DeleteScriptRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -117,9 +119,7 @@ func (r DeleteScriptRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "delete_script"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -214,3 +214,11 @@ func (f DeleteScript) WithOpaqueID(s string) func(*DeleteScriptRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DeleteScript) WithRequestOptions(o RequestOptions) func(*DeleteScriptRequest) {
+	return func(r *DeleteScriptRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
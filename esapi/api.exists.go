@@ -55,6 +55,8 @@ type ExistsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -160,9 +162,7 @@ func (r ExistsRequest) Do(ctx context.Context, transport Transport) (*Response,
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "exists", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -321,3 +321,11 @@ func (f Exists) WithOpaqueID(s string) func(*ExistsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Exists) WithRequestOptions(o RequestOptions) func(*ExistsRequest) {
+	return func(r *ExistsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -55,6 +55,8 @@ type ExistsSourceRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -160,9 +162,7 @@ func (r ExistsSourceRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "exists_source", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -321,3 +321,11 @@ func (f ExistsSource) WithOpaqueID(s string) func(*ExistsSourceRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ExistsSource) WithRequestOptions(o RequestOptions) func(*ExistsSourceRequest) {
+	return func(r *ExistsSourceRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
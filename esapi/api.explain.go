@@ -60,6 +60,8 @@ type ExplainRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -177,9 +179,7 @@ func (r ExplainRequest) Do(ctx context.Context, transport Transport) (*Response,
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "explain", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -362,3 +362,11 @@ func (f Explain) WithOpaqueID(s string) func(*ExplainRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Explain) WithRequestOptions(o RequestOptions) func(*ExplainRequest) {
+	return func(r *ExplainRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -49,6 +49,8 @@ type FieldCapsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -134,9 +136,7 @@ func (r FieldCapsRequest) Do(ctx context.Context, transport Transport) (*Respons
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "field_caps", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -263,3 +263,11 @@ func (f FieldCaps) WithOpaqueID(s string) func(*FieldCapsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f FieldCaps) WithRequestOptions(o RequestOptions) func(*FieldCapsRequest) {
+	return func(r *FieldCapsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -55,6 +55,8 @@ type GetRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -160,9 +162,7 @@ func (r GetRequest) Do(ctx context.Context, transport Transport) (*Response, err
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "get", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -321,3 +321,11 @@ func (f Get) WithOpaqueID(s string) func(*GetRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Get) WithRequestOptions(o RequestOptions) func(*GetRequest) {
+	return func(r *GetRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -45,6 +45,8 @@ type GetScriptRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -112,9 +114,7 @@ func (r GetScriptRequest) Do(ctx context.Context, transport Transport) (*Respons
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "get_script"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -201,3 +201,11 @@ func (f GetScript) WithOpaqueID(s string) func(*GetScriptRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f GetScript) WithRequestOptions(o RequestOptions) func(*GetScriptRequest) {
+	return func(r *GetScriptRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -38,6 +38,8 @@ type GetScriptContextRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -98,9 +100,7 @@ func (r GetScriptContextRequest) Do(ctx context.Context, transport Transport) (*
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "get_script_context"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -179,3 +179,11 @@ func (f GetScriptContext) WithOpaqueID(s string) func(*GetScriptContextRequest)
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f GetScriptContext) WithRequestOptions(o RequestOptions) func(*GetScriptContextRequest) {
+	return func(r *GetScriptContextRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -38,6 +38,8 @@ type GetScriptLanguagesRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -98,9 +100,7 @@ func (r GetScriptLanguagesRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "get_script_languages"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -179,3 +179,11 @@ func (f GetScriptLanguages) WithOpaqueID(s string) func(*GetScriptLanguagesReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f GetScriptLanguages) WithRequestOptions(o RequestOptions) func(*GetScriptLanguagesRequest) {
+	return func(r *GetScriptLanguagesRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -54,6 +54,8 @@ type GetSourceRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -155,9 +157,7 @@ func (r GetSourceRequest) Do(ctx context.Context, transport Transport) (*Respons
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "get_source", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -308,3 +308,11 @@ func (f GetSource) WithOpaqueID(s string) func(*GetSourceRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f GetSource) WithRequestOptions(o RequestOptions) func(*GetSourceRequest) {
+	return func(r *GetSourceRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
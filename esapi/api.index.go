@@ -59,6 +59,8 @@ type IndexRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -174,9 +176,7 @@ func (r IndexRequest) Do(ctx context.Context, transport Transport) (*Response, e
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "index", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -343,3 +343,11 @@ func (f Index) WithOpaqueID(s string) func(*IndexRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Index) WithRequestOptions(o RequestOptions) func(*IndexRequest) {
+	return func(r *IndexRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -45,6 +45,8 @@ type IndicesAnalyzeRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -118,9 +120,7 @@ func (r IndicesAnalyzeRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.analyze", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -215,3 +215,11 @@ func (f IndicesAnalyze) WithOpaqueID(s string) func(*IndicesAnalyzeRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesAnalyze) WithRequestOptions(o RequestOptions) func(*IndicesAnalyzeRequest) {
+	return func(r *IndicesAnalyzeRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
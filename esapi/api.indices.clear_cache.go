@@ -51,6 +51,8 @@ type IndicesClearCacheRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -150,9 +152,7 @@ func (r IndicesClearCacheRequest) Do(ctx context.Context, transport Transport) (
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.clear_cache", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -295,3 +295,11 @@ func (f IndicesClearCache) WithOpaqueID(s string) func(*IndicesClearCacheRequest
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesClearCache) WithRequestOptions(o RequestOptions) func(*IndicesClearCacheRequest) {
+	return func(r *IndicesClearCacheRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
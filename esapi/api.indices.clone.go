@@ -52,6 +52,8 @@ type IndicesCloneRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -133,9 +135,7 @@ func (r IndicesCloneRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.clone", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -246,3 +246,11 @@ func (f IndicesClone) WithOpaqueID(s string) func(*IndicesCloneRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesClone) WithRequestOptions(o RequestOptions) func(*IndicesCloneRequest) {
+	return func(r *IndicesCloneRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -51,6 +51,8 @@ type IndicesCloseRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -138,9 +140,7 @@ func (r IndicesCloseRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.close", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -267,3 +267,11 @@ func (f IndicesClose) WithOpaqueID(s string) func(*IndicesCloseRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesClose) WithRequestOptions(o RequestOptions) func(*IndicesCloseRequest) {
+	return func(r *IndicesCloseRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
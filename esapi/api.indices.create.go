@@ -50,9 +50,19 @@ type IndicesCreateRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
+// IndicesCreateBody represents the body of the Indices Create API request.
+//
+type IndicesCreateBody struct {
+	Settings *IndexSettings        `json:"settings,omitempty"`
+	Mappings *TypeMapping          `json:"mappings,omitempty"`
+	Aliases  map[string]IndexAlias `json:"aliases,omitempty"`
+}
+
 // Do executes the request and returns response or error.
 //
 func (r IndicesCreateRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
@@ -127,9 +137,7 @@ func (r IndicesCreateRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.create", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -161,6 +169,14 @@ func (f IndicesCreate) WithBody(v io.Reader) func(*IndicesCreateRequest) {
 	}
 }
 
+// WithBodyStruct sets the request body to v encoded into JSON.
+//
+func (f IndicesCreate) WithBodyStruct(v *IndicesCreateBody) func(*IndicesCreateRequest) {
+	return func(r *IndicesCreateRequest) {
+		r.Body = newJSONReader(v)
+	}
+}
+
 // WithMasterTimeout - specify timeout for connection to master.
 //
 func (f IndicesCreate) WithMasterTimeout(v time.Duration) func(*IndicesCreateRequest) {
@@ -240,3 +256,11 @@ func (f IndicesCreate) WithOpaqueID(s string) func(*IndicesCreateRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesCreate) WithRequestOptions(o RequestOptions) func(*IndicesCreateRequest) {
+	return func(r *IndicesCreateRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -50,6 +50,8 @@ type IndicesDeleteRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -131,9 +133,7 @@ func (r IndicesDeleteRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.delete", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -252,3 +252,11 @@ func (f IndicesDelete) WithOpaqueID(s string) func(*IndicesDeleteRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesDelete) WithRequestOptions(o RequestOptions) func(*IndicesDeleteRequest) {
+	return func(r *IndicesDeleteRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -48,6 +48,8 @@ type IndicesDeleteAliasRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -121,9 +123,7 @@ func (r IndicesDeleteAliasRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.delete_alias", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -218,3 +218,11 @@ func (f IndicesDeleteAlias) WithOpaqueID(s string) func(*IndicesDeleteAliasReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesDeleteAlias) WithRequestOptions(o RequestOptions) func(*IndicesDeleteAliasRequest) {
+	return func(r *IndicesDeleteAliasRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
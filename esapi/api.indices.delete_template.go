@@ -46,6 +46,8 @@ type IndicesDeleteTemplateRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -117,9 +119,7 @@ func (r IndicesDeleteTemplateRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.delete_template"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -214,3 +214,11 @@ func (f IndicesDeleteTemplate) WithOpaqueID(s string) func(*IndicesDeleteTemplat
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesDeleteTemplate) WithRequestOptions(o RequestOptions) func(*IndicesDeleteTemplateRequest) {
+	return func(r *IndicesDeleteTemplateRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -50,6 +50,8 @@ type IndicesExistsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -135,9 +137,7 @@ func (r IndicesExistsRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.exists", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -264,3 +264,11 @@ func (f IndicesExists) WithOpaqueID(s string) func(*IndicesExistsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesExists) WithRequestOptions(o RequestOptions) func(*IndicesExistsRequest) {
+	return func(r *IndicesExistsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
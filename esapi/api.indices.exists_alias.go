@@ -50,6 +50,8 @@ type IndicesExistsAliasRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -133,9 +135,7 @@ func (r IndicesExistsAliasRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.exists_alias", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -254,3 +254,11 @@ func (f IndicesExistsAlias) WithOpaqueID(s string) func(*IndicesExistsAliasReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesExistsAlias) WithRequestOptions(o RequestOptions) func(*IndicesExistsAliasRequest) {
+	return func(r *IndicesExistsAliasRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -48,6 +48,8 @@ type IndicesExistsTemplateRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -123,9 +125,7 @@ func (r IndicesExistsTemplateRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.exists_template"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -228,3 +228,11 @@ func (f IndicesExistsTemplate) WithOpaqueID(s string) func(*IndicesExistsTemplat
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesExistsTemplate) WithRequestOptions(o RequestOptions) func(*IndicesExistsTemplateRequest) {
+	return func(r *IndicesExistsTemplateRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -49,6 +49,8 @@ type IndicesExistsDocumentTypeRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -130,9 +132,7 @@ func (r IndicesExistsDocumentTypeRequest) Do(ctx context.Context, transport Tran
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.exists_type", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -251,3 +251,11 @@ func (f IndicesExistsDocumentType) WithOpaqueID(s string) func(*IndicesExistsDoc
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesExistsDocumentType) WithRequestOptions(o RequestOptions) func(*IndicesExistsDocumentTypeRequest) {
+	return func(r *IndicesExistsDocumentTypeRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
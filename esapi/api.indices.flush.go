@@ -49,6 +49,8 @@ type IndicesFlushRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -134,9 +136,7 @@ func (r IndicesFlushRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.flush", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -263,3 +263,11 @@ func (f IndicesFlush) WithOpaqueID(s string) func(*IndicesFlushRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesFlush) WithRequestOptions(o RequestOptions) func(*IndicesFlushRequest) {
+	return func(r *IndicesFlushRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -47,6 +47,8 @@ type IndicesFlushSyncedRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -126,9 +128,7 @@ func (r IndicesFlushSyncedRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.flush_synced", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -239,3 +239,11 @@ func (f IndicesFlushSynced) WithOpaqueID(s string) func(*IndicesFlushSyncedReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesFlushSynced) WithRequestOptions(o RequestOptions) func(*IndicesFlushSyncedRequest) {
+	return func(r *IndicesFlushSyncedRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -50,6 +50,8 @@ type IndicesForcemergeRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -139,9 +141,7 @@ func (r IndicesForcemergeRequest) Do(ctx context.Context, transport Transport) (
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.forcemerge", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -276,3 +276,11 @@ func (f IndicesForcemerge) WithOpaqueID(s string) func(*IndicesForcemergeRequest
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesForcemerge) WithRequestOptions(o RequestOptions) func(*IndicesForcemergeRequest) {
+	return func(r *IndicesForcemergeRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
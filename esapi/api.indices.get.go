@@ -52,6 +52,8 @@ type IndicesGetRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -141,9 +143,7 @@ func (r IndicesGetRequest) Do(ctx context.Context, transport Transport) (*Respon
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.get", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -278,3 +278,11 @@ func (f IndicesGet) WithOpaqueID(s string) func(*IndicesGetRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesGet) WithRequestOptions(o RequestOptions) func(*IndicesGetRequest) {
+	return func(r *IndicesGetRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
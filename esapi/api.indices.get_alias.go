@@ -50,6 +50,8 @@ type IndicesGetAliasRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -135,9 +137,7 @@ func (r IndicesGetAliasRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.get_alias", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -264,3 +264,11 @@ func (f IndicesGetAlias) WithOpaqueID(s string) func(*IndicesGetAliasRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesGetAlias) WithRequestOptions(o RequestOptions) func(*IndicesGetAliasRequest) {
+	return func(r *IndicesGetAliasRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
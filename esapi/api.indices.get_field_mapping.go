@@ -51,6 +51,8 @@ type IndicesGetFieldMappingRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -140,9 +142,7 @@ func (r IndicesGetFieldMappingRequest) Do(ctx context.Context, transport Transpo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.get_field_mapping", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -269,3 +269,11 @@ func (f IndicesGetFieldMapping) WithOpaqueID(s string) func(*IndicesGetFieldMapp
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesGetFieldMapping) WithRequestOptions(o RequestOptions) func(*IndicesGetFieldMappingRequest) {
+	return func(r *IndicesGetFieldMappingRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -50,6 +50,8 @@ type IndicesGetMappingRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -135,9 +137,7 @@ func (r IndicesGetMappingRequest) Do(ctx context.Context, transport Transport) (
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.get_mapping", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -264,3 +264,11 @@ func (f IndicesGetMapping) WithOpaqueID(s string) func(*IndicesGetMappingRequest
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesGetMapping) WithRequestOptions(o RequestOptions) func(*IndicesGetMappingRequest) {
+	return func(r *IndicesGetMappingRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -54,6 +54,8 @@ type IndicesGetSettingsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -151,9 +153,7 @@ func (r IndicesGetSettingsRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.get_settings", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -304,3 +304,11 @@ func (f IndicesGetSettings) WithOpaqueID(s string) func(*IndicesGetSettingsReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesGetSettings) WithRequestOptions(o RequestOptions) func(*IndicesGetSettingsRequest) {
+	return func(r *IndicesGetSettingsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
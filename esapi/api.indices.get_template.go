@@ -48,6 +48,8 @@ type IndicesGetTemplateRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -125,9 +127,7 @@ func (r IndicesGetTemplateRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.get_template"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -238,3 +238,11 @@ func (f IndicesGetTemplate) WithOpaqueID(s string) func(*IndicesGetTemplateReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesGetTemplate) WithRequestOptions(o RequestOptions) func(*IndicesGetTemplateRequest) {
+	return func(r *IndicesGetTemplateRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
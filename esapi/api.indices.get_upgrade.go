@@ -47,6 +47,8 @@ type IndicesGetUpgradeRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -124,9 +126,7 @@ func (r IndicesGetUpgradeRequest) Do(ctx context.Context, transport Transport) (
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.get_upgrade", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -237,3 +237,11 @@ func (f IndicesGetUpgrade) WithOpaqueID(s string) func(*IndicesGetUpgradeRequest
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesGetUpgrade) WithRequestOptions(o RequestOptions) func(*IndicesGetUpgradeRequest) {
+	return func(r *IndicesGetUpgradeRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -51,6 +51,8 @@ type IndicesOpenRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -138,9 +140,7 @@ func (r IndicesOpenRequest) Do(ctx context.Context, transport Transport) (*Respo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.open", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -267,3 +267,11 @@ func (f IndicesOpen) WithOpaqueID(s string) func(*IndicesOpenRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesOpen) WithRequestOptions(o RequestOptions) func(*IndicesOpenRequest) {
+	return func(r *IndicesOpenRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
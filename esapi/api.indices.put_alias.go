@@ -51,6 +51,8 @@ type IndicesPutAliasRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -128,9 +130,7 @@ func (r IndicesPutAliasRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.put_alias", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -233,3 +233,11 @@ func (f IndicesPutAlias) WithOpaqueID(s string) func(*IndicesPutAliasRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesPutAlias) WithRequestOptions(o RequestOptions) func(*IndicesPutAliasRequest) {
+	return func(r *IndicesPutAliasRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -53,9 +53,18 @@ type IndicesPutMappingRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
+// IndicesPutMappingBody represents the body of the Indices Put Mapping API request.
+//
+type IndicesPutMappingBody struct {
+	Dynamic    interface{}                `json:"dynamic,omitempty"`
+	Properties map[string]MappingProperty `json:"properties,omitempty"`
+}
+
 // Do executes the request and returns response or error.
 //
 func (r IndicesPutMappingRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
@@ -142,9 +151,7 @@ func (r IndicesPutMappingRequest) Do(ctx context.Context, transport Transport) (
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.put_mapping", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -168,6 +175,14 @@ func (f IndicesPutMapping) WithContext(v context.Context) func(*IndicesPutMappin
 	}
 }
 
+// WithBodyStruct sets the request body to v encoded into JSON.
+//
+func (f IndicesPutMapping) WithBodyStruct(v *IndicesPutMappingBody) func(*IndicesPutMappingRequest) {
+	return func(r *IndicesPutMappingRequest) {
+		r.Body = newJSONReader(v)
+	}
+}
+
 // WithAllowNoIndices - whether to ignore if a wildcard indices expression resolves into no concrete indices. (this includes `_all` string or when no indices have been specified).
 //
 func (f IndicesPutMapping) WithAllowNoIndices(v bool) func(*IndicesPutMappingRequest) {
@@ -263,3 +278,11 @@ func (f IndicesPutMapping) WithOpaqueID(s string) func(*IndicesPutMappingRequest
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesPutMapping) WithRequestOptions(o RequestOptions) func(*IndicesPutMappingRequest) {
+	return func(r *IndicesPutMappingRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
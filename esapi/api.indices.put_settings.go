@@ -55,9 +55,17 @@ type IndicesPutSettingsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
+// IndicesPutSettingsBody represents the body of the Indices Put Settings API request.
+//
+type IndicesPutSettingsBody struct {
+	Index IndexSettings `json:"index"`
+}
+
 // Do executes the request and returns response or error.
 //
 func (r IndicesPutSettingsRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
@@ -152,9 +160,7 @@ func (r IndicesPutSettingsRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.put_settings", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -178,6 +184,14 @@ func (f IndicesPutSettings) WithContext(v context.Context) func(*IndicesPutSetti
 	}
 }
 
+// WithBodyStruct sets the request body to v encoded into JSON.
+//
+func (f IndicesPutSettings) WithBodyStruct(v *IndicesPutSettingsBody) func(*IndicesPutSettingsRequest) {
+	return func(r *IndicesPutSettingsRequest) {
+		r.Body = newJSONReader(v)
+	}
+}
+
 // WithIndex - a list of index names; use _all to perform the operation on all indices.
 //
 func (f IndicesPutSettings) WithIndex(v ...string) func(*IndicesPutSettingsRequest) {
@@ -297,3 +311,11 @@ func (f IndicesPutSettings) WithOpaqueID(s string) func(*IndicesPutSettingsReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesPutSettings) WithRequestOptions(o RequestOptions) func(*IndicesPutSettingsRequest) {
+	return func(r *IndicesPutSettingsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -53,6 +53,8 @@ type IndicesPutTemplateRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -140,9 +142,7 @@ func (r IndicesPutTemplateRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.put_template"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -261,3 +261,11 @@ func (f IndicesPutTemplate) WithOpaqueID(s string) func(*IndicesPutTemplateReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesPutTemplate) WithRequestOptions(o RequestOptions) func(*IndicesPutTemplateRequest) {
+	return func(r *IndicesPutTemplateRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -46,6 +46,8 @@ type IndicesRecoveryRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -119,9 +121,7 @@ func (r IndicesRecoveryRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.recovery", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -224,3 +224,11 @@ func (f IndicesRecovery) WithOpaqueID(s string) func(*IndicesRecoveryRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesRecovery) WithRequestOptions(o RequestOptions) func(*IndicesRecoveryRequest) {
+	return func(r *IndicesRecoveryRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
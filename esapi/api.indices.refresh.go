@@ -47,6 +47,8 @@ type IndicesRefreshRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -124,9 +126,7 @@ func (r IndicesRefreshRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.refresh", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -237,3 +237,11 @@ func (f IndicesRefresh) WithOpaqueID(s string) func(*IndicesRefreshRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesRefresh) WithRequestOptions(o RequestOptions) func(*IndicesRefreshRequest) {
+	return func(r *IndicesRefreshRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
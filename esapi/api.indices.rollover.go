@@ -54,6 +54,8 @@ type IndicesRolloverRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -141,9 +143,7 @@ func (r IndicesRolloverRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.rollover"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -270,3 +270,11 @@ func (f IndicesRollover) WithOpaqueID(s string) func(*IndicesRolloverRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesRollover) WithRequestOptions(o RequestOptions) func(*IndicesRolloverRequest) {
+	return func(r *IndicesRolloverRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
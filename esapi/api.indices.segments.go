@@ -48,6 +48,8 @@ type IndicesSegmentsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -129,9 +131,7 @@ func (r IndicesSegmentsRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.segments", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -250,3 +250,11 @@ func (f IndicesSegments) WithOpaqueID(s string) func(*IndicesSegmentsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesSegments) WithRequestOptions(o RequestOptions) func(*IndicesSegmentsRequest) {
+	return func(r *IndicesSegmentsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
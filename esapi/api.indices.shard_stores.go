@@ -48,6 +48,8 @@ type IndicesShardStoresRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -129,9 +131,7 @@ func (r IndicesShardStoresRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.shard_stores", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -250,3 +250,11 @@ func (f IndicesShardStores) WithOpaqueID(s string) func(*IndicesShardStoresReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesShardStores) WithRequestOptions(o RequestOptions) func(*IndicesShardStoresRequest) {
+	return func(r *IndicesShardStoresRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
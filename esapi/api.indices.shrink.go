@@ -52,6 +52,8 @@ type IndicesShrinkRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -133,9 +135,7 @@ func (r IndicesShrinkRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.shrink", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -246,3 +246,11 @@ func (f IndicesShrink) WithOpaqueID(s string) func(*IndicesShrinkRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesShrink) WithRequestOptions(o RequestOptions) func(*IndicesShrinkRequest) {
+	return func(r *IndicesShrinkRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -52,6 +52,8 @@ type IndicesSplitRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -133,9 +135,7 @@ func (r IndicesSplitRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.split", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -246,3 +246,11 @@ func (f IndicesSplit) WithOpaqueID(s string) func(*IndicesSplitRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesSplit) WithRequestOptions(o RequestOptions) func(*IndicesSplitRequest) {
+	return func(r *IndicesSplitRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
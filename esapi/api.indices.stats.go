@@ -56,6 +56,8 @@ type IndicesStatsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -165,9 +167,7 @@ func (r IndicesStatsRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.stats", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -342,3 +342,11 @@ func (f IndicesStats) WithOpaqueID(s string) func(*IndicesStatsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesStats) WithRequestOptions(o RequestOptions) func(*IndicesStatsRequest) {
+	return func(r *IndicesStatsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -47,9 +47,40 @@ type IndicesUpdateAliasesRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
+// IndicesUpdateAliasesBody represents the body of the Indices Update Aliases API request.
+//
+type IndicesUpdateAliasesBody struct {
+	Actions []IndicesAliasAction `json:"actions"`
+}
+
+// IndicesAliasAction represents a single alias action; set exactly one of the fields.
+//
+type IndicesAliasAction struct {
+	Add         *IndicesAliasActionParams `json:"add,omitempty"`
+	Remove      *IndicesAliasActionParams `json:"remove,omitempty"`
+	RemoveIndex *IndicesAliasActionParams `json:"remove_index,omitempty"`
+}
+
+// IndicesAliasActionParams represents the parameters of an alias action.
+//
+type IndicesAliasActionParams struct {
+	Index         string      `json:"index,omitempty"`
+	Indices       []string    `json:"indices,omitempty"`
+	Alias         string      `json:"alias,omitempty"`
+	Aliases       []string    `json:"aliases,omitempty"`
+	Filter        interface{} `json:"filter,omitempty"`
+	Routing       string      `json:"routing,omitempty"`
+	IndexRouting  string      `json:"index_routing,omitempty"`
+	SearchRouting string      `json:"search_routing,omitempty"`
+	IsWriteIndex  *bool       `json:"is_write_index,omitempty"`
+	MustExist     *bool       `json:"must_exist,omitempty"`
+}
+
 // Do executes the request and returns response or error.
 //
 func (r IndicesUpdateAliasesRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
@@ -119,9 +150,7 @@ func (r IndicesUpdateAliasesRequest) Do(ctx context.Context, transport Transport
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.update_aliases"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -145,6 +174,14 @@ func (f IndicesUpdateAliases) WithContext(v context.Context) func(*IndicesUpdate
 	}
 }
 
+// WithBodyStruct sets the request body to v encoded into JSON.
+//
+func (f IndicesUpdateAliases) WithBodyStruct(v *IndicesUpdateAliasesBody) func(*IndicesUpdateAliasesRequest) {
+	return func(r *IndicesUpdateAliasesRequest) {
+		r.Body = newJSONReader(v)
+	}
+}
+
 // WithMasterTimeout - specify timeout for connection to master.
 //
 func (f IndicesUpdateAliases) WithMasterTimeout(v time.Duration) func(*IndicesUpdateAliasesRequest) {
@@ -216,3 +253,11 @@ func (f IndicesUpdateAliases) WithOpaqueID(s string) func(*IndicesUpdateAliasesR
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesUpdateAliases) WithRequestOptions(o RequestOptions) func(*IndicesUpdateAliasesRequest) {
+	return func(r *IndicesUpdateAliasesRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
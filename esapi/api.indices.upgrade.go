@@ -49,6 +49,8 @@ type IndicesUpgradeRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -134,9 +136,7 @@ func (r IndicesUpgradeRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.upgrade", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -263,3 +263,11 @@ func (f IndicesUpgrade) WithOpaqueID(s string) func(*IndicesUpgradeRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesUpgrade) WithRequestOptions(o RequestOptions) func(*IndicesUpgradeRequest) {
+	return func(r *IndicesUpgradeRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -60,6 +60,8 @@ type IndicesValidateQueryRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -183,9 +185,7 @@ func (r IndicesValidateQueryRequest) Do(ctx context.Context, transport Transport
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.validate_query", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -384,3 +384,11 @@ func (f IndicesValidateQuery) WithOpaqueID(s string) func(*IndicesValidateQueryR
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesValidateQuery) WithRequestOptions(o RequestOptions) func(*IndicesValidateQueryRequest) {
+	return func(r *IndicesValidateQueryRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
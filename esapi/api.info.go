@@ -40,6 +40,8 @@ type InfoRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r InfoRequest) Do(ctx context.Context, transport Transport) (*Response, er
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "info"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -173,3 +173,11 @@ func (f Info) WithOpaqueID(s string) func(*InfoRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Info) WithRequestOptions(o RequestOptions) func(*InfoRequest) {
+	return func(r *InfoRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
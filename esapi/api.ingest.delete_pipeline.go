@@ -46,6 +46,8 @@ type IngestDeletePipelineRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -119,9 +121,7 @@ func (r IngestDeletePipelineRequest) Do(ctx context.Context, transport Transport
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ingest.delete_pipeline"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -216,3 +216,11 @@ func (f IngestDeletePipeline) WithOpaqueID(s string) func(*IngestDeletePipelineR
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IngestDeletePipeline) WithRequestOptions(o RequestOptions) func(*IngestDeletePipelineRequest) {
+	return func(r *IngestDeletePipelineRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -45,6 +45,8 @@ type IngestGetPipelineRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -116,9 +118,7 @@ func (r IngestGetPipelineRequest) Do(ctx context.Context, transport Transport) (
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ingest.get_pipeline"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -213,3 +213,11 @@ func (f IngestGetPipeline) WithOpaqueID(s string) func(*IngestGetPipelineRequest
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IngestGetPipeline) WithRequestOptions(o RequestOptions) func(*IngestGetPipelineRequest) {
+	return func(r *IngestGetPipelineRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
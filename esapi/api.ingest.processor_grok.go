@@ -40,6 +40,8 @@ type IngestProcessorGrokRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r IngestProcessorGrokRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ingest.processor_grok"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f IngestProcessorGrok) WithOpaqueID(s string) func(*IngestProcessorGrokReq
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IngestProcessorGrok) WithRequestOptions(o RequestOptions) func(*IngestProcessorGrokRequest) {
+	return func(r *IngestProcessorGrokRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -49,6 +49,8 @@ type IngestPutPipelineRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -126,9 +128,7 @@ func (r IngestPutPipelineRequest) Do(ctx context.Context, transport Transport) (
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ingest.put_pipeline"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -223,3 +223,11 @@ func (f IngestPutPipeline) WithOpaqueID(s string) func(*IngestPutPipelineRequest
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IngestPutPipeline) WithRequestOptions(o RequestOptions) func(*IngestPutPipelineRequest) {
+	return func(r *IngestPutPipelineRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -48,6 +48,8 @@ type IngestSimulateRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -125,9 +127,7 @@ func (r IngestSimulateRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ingest.simulate"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -222,3 +222,11 @@ func (f IngestSimulate) WithOpaqueID(s string) func(*IngestSimulateRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IngestSimulate) WithRequestOptions(o RequestOptions) func(*IngestSimulateRequest) {
+	return func(r *IngestSimulateRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -55,6 +55,8 @@ type MgetRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -156,9 +158,7 @@ func (r MgetRequest) Do(ctx context.Context, transport Transport) (*Response, er
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "mget", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -309,3 +309,11 @@ func (f Mget) WithOpaqueID(s string) func(*MgetRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Mget) WithRequestOptions(o RequestOptions) func(*MgetRequest) {
+	return func(r *MgetRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -54,6 +54,8 @@ type MsearchRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -151,9 +153,7 @@ func (r MsearchRequest) Do(ctx context.Context, transport Transport) (*Response,
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "msearch", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -296,3 +296,11 @@ func (f Msearch) WithOpaqueID(s string) func(*MsearchRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Msearch) WithRequestOptions(o RequestOptions) func(*MsearchRequest) {
+	return func(r *MsearchRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
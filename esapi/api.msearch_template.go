@@ -52,6 +52,8 @@ type MsearchTemplateRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -143,9 +145,7 @@ func (r MsearchTemplateRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "msearch_template", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -272,3 +272,11 @@ func (f MsearchTemplate) WithOpaqueID(s string) func(*MsearchTemplateRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MsearchTemplate) WithRequestOptions(o RequestOptions) func(*MsearchTemplateRequest) {
+	return func(r *MsearchTemplateRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -59,6 +59,8 @@ type MtermvectorsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -176,9 +178,7 @@ func (r MtermvectorsRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "mtermvectors", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -369,3 +369,11 @@ func (f Mtermvectors) WithOpaqueID(s string) func(*MtermvectorsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Mtermvectors) WithRequestOptions(o RequestOptions) func(*MtermvectorsRequest) {
+	return func(r *MtermvectorsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -51,6 +51,8 @@ type NodesHotThreadsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -144,9 +146,7 @@ func (r NodesHotThreadsRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "nodes.hot_threads"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -281,3 +281,11 @@ func (f NodesHotThreads) WithOpaqueID(s string) func(*NodesHotThreadsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f NodesHotThreads) WithRequestOptions(o RequestOptions) func(*NodesHotThreadsRequest) {
+	return func(r *NodesHotThreadsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
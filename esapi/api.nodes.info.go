@@ -48,6 +48,8 @@ type NodesInfoRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -125,9 +127,7 @@ func (r NodesInfoRequest) Do(ctx context.Context, transport Transport) (*Respons
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "nodes.info"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -238,3 +238,11 @@ func (f NodesInfo) WithOpaqueID(s string) func(*NodesInfoRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f NodesInfo) WithRequestOptions(o RequestOptions) func(*NodesInfoRequest) {
+	return func(r *NodesInfoRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -45,6 +45,8 @@ type NodesReloadSecureSettingsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -116,9 +118,7 @@ func (r NodesReloadSecureSettingsRequest) Do(ctx context.Context, transport Tran
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "nodes.reload_secure_settings"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -213,3 +213,11 @@ func (f NodesReloadSecureSettings) WithOpaqueID(s string) func(*NodesReloadSecur
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f NodesReloadSecureSettings) WithRequestOptions(o RequestOptions) func(*NodesReloadSecureSettingsRequest) {
+	return func(r *NodesReloadSecureSettingsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -55,6 +55,8 @@ type NodesStatsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -162,9 +164,7 @@ func (r NodesStatsRequest) Do(ctx context.Context, transport Transport) (*Respon
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "nodes.stats"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -331,3 +331,11 @@ func (f NodesStats) WithOpaqueID(s string) func(*NodesStatsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f NodesStats) WithRequestOptions(o RequestOptions) func(*NodesStatsRequest) {
+	return func(r *NodesStatsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
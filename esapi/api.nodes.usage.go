@@ -46,6 +46,8 @@ type NodesUsageRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -121,9 +123,7 @@ func (r NodesUsageRequest) Do(ctx context.Context, transport Transport) (*Respon
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "nodes.usage"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -226,3 +226,11 @@ func (f NodesUsage) WithOpaqueID(s string) func(*NodesUsageRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f NodesUsage) WithRequestOptions(o RequestOptions) func(*NodesUsageRequest) {
+	return func(r *NodesUsageRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
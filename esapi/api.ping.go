@@ -40,6 +40,8 @@ type PingRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r PingRequest) Do(ctx context.Context, transport Transport) (*Response, er
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ping"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f Ping) WithOpaqueID(s string) func(*PingRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Ping) WithRequestOptions(o RequestOptions) func(*PingRequest) {
+	return func(r *PingRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -51,6 +51,8 @@ type PutScriptRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -134,9 +136,7 @@ func (r PutScriptRequest) Do(ctx context.Context, transport Transport) (*Respons
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "put_script"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -239,3 +239,11 @@ func (f PutScript) WithOpaqueID(s string) func(*PutScriptRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f PutScript) WithRequestOptions(o RequestOptions) func(*PutScriptRequest) {
+	return func(r *PutScriptRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
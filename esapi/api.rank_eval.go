@@ -51,6 +51,8 @@ type RankEvalRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -136,9 +138,7 @@ func (r RankEvalRequest) Do(ctx context.Context, transport Transport) (*Response
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "rank_eval", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -257,3 +257,11 @@ func (f RankEval) WithOpaqueID(s string) func(*RankEvalRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f RankEval) WithRequestOptions(o RequestOptions) func(*RankEvalRequest) {
+	return func(r *RankEvalRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
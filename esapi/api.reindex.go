@@ -56,9 +56,53 @@ type ReindexRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
+// ReindexBody represents the body of the Reindex API request.
+//
+type ReindexBody struct {
+	Conflicts string        `json:"conflicts,omitempty"`
+	MaxDocs   int           `json:"max_docs,omitempty"`
+	Source    ReindexSource `json:"source"`
+	Dest      ReindexDest   `json:"dest"`
+	Script    *Script       `json:"script,omitempty"`
+}
+
+// ReindexSource represents the source of the documents to reindex.
+//
+type ReindexSource struct {
+	Index        []string       `json:"index"`
+	Query        interface{}    `json:"query,omitempty"`
+	Size         int            `json:"size,omitempty"`
+	Sort         interface{}    `json:"sort,omitempty"`
+	SourceFields []string       `json:"_source,omitempty"`
+	Remote       *ReindexRemote `json:"remote,omitempty"`
+}
+
+// ReindexRemote represents a remote cluster to reindex from.
+//
+type ReindexRemote struct {
+	Host           string            `json:"host"`
+	Username       string            `json:"username,omitempty"`
+	Password       string            `json:"password,omitempty"`
+	Headers        map[string]string `json:"headers,omitempty"`
+	SocketTimeout  string            `json:"socket_timeout,omitempty"`
+	ConnectTimeout string            `json:"connect_timeout,omitempty"`
+}
+
+// ReindexDest represents the destination of the reindexed documents.
+//
+type ReindexDest struct {
+	Index       string `json:"index"`
+	OpType      string `json:"op_type,omitempty"`
+	Pipeline    string `json:"pipeline,omitempty"`
+	VersionType string `json:"version_type,omitempty"`
+	Routing     string `json:"routing,omitempty"`
+}
+
 // Do executes the request and returns response or error.
 //
 func (r ReindexRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
@@ -152,9 +196,7 @@ func (r ReindexRequest) Do(ctx context.Context, transport Transport) (*Response,
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "reindex"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -178,6 +220,14 @@ func (f Reindex) WithContext(v context.Context) func(*ReindexRequest) {
 	}
 }
 
+// WithBodyStruct sets the request body to v encoded into JSON.
+//
+func (f Reindex) WithBodyStruct(v *ReindexBody) func(*ReindexRequest) {
+	return func(r *ReindexRequest) {
+		r.Body = newJSONReader(v)
+	}
+}
+
 // WithMaxDocs - maximum number of documents to process (default: all documents).
 //
 func (f Reindex) WithMaxDocs(v int) func(*ReindexRequest) {
@@ -297,3 +347,11 @@ func (f Reindex) WithOpaqueID(s string) func(*ReindexRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Reindex) WithRequestOptions(o RequestOptions) func(*ReindexRequest) {
+	return func(r *ReindexRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
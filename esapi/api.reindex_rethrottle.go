@@ -45,6 +45,8 @@ type ReindexRethrottleRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r ReindexRethrottleRequest) Do(ctx context.Context, transport Transport) (
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "reindex_rethrottle"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -203,3 +203,11 @@ func (f ReindexRethrottle) WithOpaqueID(s string) func(*ReindexRethrottleRequest
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ReindexRethrottle) WithRequestOptions(o RequestOptions) func(*ReindexRethrottleRequest) {
+	return func(r *ReindexRethrottleRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
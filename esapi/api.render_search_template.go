@@ -45,6 +45,8 @@ type RenderSearchTemplateRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -116,9 +118,7 @@ func (r RenderSearchTemplateRequest) Do(ctx context.Context, transport Transport
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "render_search_template"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -213,3 +213,11 @@ func (f RenderSearchTemplate) WithOpaqueID(s string) func(*RenderSearchTemplateR
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f RenderSearchTemplate) WithRequestOptions(o RequestOptions) func(*RenderSearchTemplateRequest) {
+	return func(r *RenderSearchTemplateRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -35,6 +35,8 @@ type ScriptsPainlessContextRequest struct {
 	ErrorTrace bool
 	FilterPath []string
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -84,9 +86,7 @@ func (r ScriptsPainlessContextRequest) Do(ctx context.Context, transport Transpo
 		req.URL.RawQuery = q.Encode()
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "scripts_painless_context"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -149,3 +149,11 @@ func (f ScriptsPainlessContext) WithFilterPath(v ...string) func(*ScriptsPainles
 		r.FilterPath = v
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ScriptsPainlessContext) WithRequestOptions(o RequestOptions) func(*ScriptsPainlessContextRequest) {
+	return func(r *ScriptsPainlessContextRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -43,6 +43,8 @@ type ScriptsPainlessExecuteRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r ScriptsPainlessExecuteRequest) Do(ctx context.Context, transport Transpo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "scripts_painless_execute"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -196,3 +196,11 @@ func (f ScriptsPainlessExecute) WithOpaqueID(s string) func(*ScriptsPainlessExec
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ScriptsPainlessExecute) WithRequestOptions(o RequestOptions) func(*ScriptsPainlessExecuteRequest) {
+	return func(r *ScriptsPainlessExecuteRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
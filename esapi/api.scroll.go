@@ -50,6 +50,8 @@ type ScrollRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -126,9 +128,7 @@ func (r ScrollRequest) Do(ctx context.Context, transport Transport) (*Response,
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "scroll"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -239,3 +239,11 @@ func (f Scroll) WithOpaqueID(s string) func(*ScrollRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Scroll) WithRequestOptions(o RequestOptions) func(*ScrollRequest) {
+	return func(r *ScrollRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -91,6 +91,8 @@ type SearchRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -328,9 +330,7 @@ func (r SearchRequest) Do(ctx context.Context, transport Transport) (*Response,
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "search", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -761,3 +761,11 @@ func (f Search) WithOpaqueID(s string) func(*SearchRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Search) WithRequestOptions(o RequestOptions) func(*SearchRequest) {
+	return func(r *SearchRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
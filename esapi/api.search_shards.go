@@ -50,6 +50,8 @@ type SearchShardsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -139,9 +141,7 @@ func (r SearchShardsRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "search_shards", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -276,3 +276,11 @@ func (f SearchShards) WithOpaqueID(s string) func(*SearchShardsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SearchShards) WithRequestOptions(o RequestOptions) func(*SearchShardsRequest) {
+	return func(r *SearchShardsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
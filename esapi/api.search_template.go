@@ -61,6 +61,8 @@ type SearchTemplateRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -184,9 +186,7 @@ func (r SearchTemplateRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "search_template", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -377,3 +377,11 @@ func (f SearchTemplate) WithOpaqueID(s string) func(*SearchTemplateRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SearchTemplate) WithRequestOptions(o RequestOptions) func(*SearchTemplateRequest) {
+	return func(r *SearchTemplateRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -49,6 +49,8 @@ type SnapshotCleanupRepositoryRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -126,9 +128,7 @@ func (r SnapshotCleanupRepositoryRequest) Do(ctx context.Context, transport Tran
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "snapshot.cleanup_repository"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -231,3 +231,11 @@ func (f SnapshotCleanupRepository) WithOpaqueID(s string) func(*SnapshotCleanupR
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SnapshotCleanupRepository) WithRequestOptions(o RequestOptions) func(*SnapshotCleanupRepositoryRequest) {
+	return func(r *SnapshotCleanupRepositoryRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
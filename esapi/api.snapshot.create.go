@@ -51,9 +51,22 @@ type SnapshotCreateRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
+// SnapshotCreateBody represents the body of the Snapshot Create API request.
+//
+type SnapshotCreateBody struct {
+	Indices            []string               `json:"indices,omitempty"`
+	IgnoreUnavailable  bool                   `json:"ignore_unavailable,omitempty"`
+	IncludeGlobalState *bool                  `json:"include_global_state,omitempty"`
+	Partial            bool                   `json:"partial,omitempty"`
+	FeatureStates      []string               `json:"feature_states,omitempty"`
+	Metadata           map[string]interface{} `json:"metadata,omitempty"`
+}
+
 // Do executes the request and returns response or error.
 //
 func (r SnapshotCreateRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
@@ -128,9 +141,7 @@ func (r SnapshotCreateRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "snapshot.create"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -162,6 +173,14 @@ func (f SnapshotCreate) WithBody(v io.Reader) func(*SnapshotCreateRequest) {
 	}
 }
 
+// WithBodyStruct sets the request body to v encoded into JSON.
+//
+func (f SnapshotCreate) WithBodyStruct(v *SnapshotCreateBody) func(*SnapshotCreateRequest) {
+	return func(r *SnapshotCreateRequest) {
+		r.Body = newJSONReader(v)
+	}
+}
+
 // WithMasterTimeout - explicit operation timeout for connection to master node.
 //
 func (f SnapshotCreate) WithMasterTimeout(v time.Duration) func(*SnapshotCreateRequest) {
@@ -233,3 +252,11 @@ func (f SnapshotCreate) WithOpaqueID(s string) func(*SnapshotCreateRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SnapshotCreate) WithRequestOptions(o RequestOptions) func(*SnapshotCreateRequest) {
+	return func(r *SnapshotCreateRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
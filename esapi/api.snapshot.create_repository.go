@@ -51,6 +51,8 @@ type SnapshotCreateRepositoryRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -130,9 +132,7 @@ func (r SnapshotCreateRepositoryRequest) Do(ctx context.Context, transport Trans
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "snapshot.create_repository"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -235,3 +235,11 @@ func (f SnapshotCreateRepository) WithOpaqueID(s string) func(*SnapshotCreateRep
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SnapshotCreateRepository) WithRequestOptions(o RequestOptions) func(*SnapshotCreateRepositoryRequest) {
+	return func(r *SnapshotCreateRepositoryRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
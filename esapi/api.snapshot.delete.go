@@ -46,6 +46,8 @@ type SnapshotDeleteRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -115,9 +117,7 @@ func (r SnapshotDeleteRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "snapshot.delete"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -204,3 +204,11 @@ func (f SnapshotDelete) WithOpaqueID(s string) func(*SnapshotDeleteRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SnapshotDelete) WithRequestOptions(o RequestOptions) func(*SnapshotDeleteRequest) {
+	return func(r *SnapshotDeleteRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -46,6 +46,8 @@ type SnapshotDeleteRepositoryRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -117,9 +119,7 @@ func (r SnapshotDeleteRepositoryRequest) Do(ctx context.Context, transport Trans
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "snapshot.delete_repository"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -214,3 +214,11 @@ func (f SnapshotDeleteRepository) WithOpaqueID(s string) func(*SnapshotDeleteRep
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SnapshotDeleteRepository) WithRequestOptions(o RequestOptions) func(*SnapshotDeleteRepositoryRequest) {
+	return func(r *SnapshotDeleteRepositoryRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
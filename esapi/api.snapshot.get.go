@@ -49,6 +49,8 @@ type SnapshotGetRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -126,9 +128,7 @@ func (r SnapshotGetRequest) Do(ctx context.Context, transport Transport) (*Respo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "snapshot.get"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -231,3 +231,11 @@ func (f SnapshotGet) WithOpaqueID(s string) func(*SnapshotGetRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SnapshotGet) WithRequestOptions(o RequestOptions) func(*SnapshotGetRequest) {
+	return func(r *SnapshotGetRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -47,6 +47,8 @@ type SnapshotGetRepositoryRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -120,9 +122,7 @@ func (r SnapshotGetRepositoryRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "snapshot.get_repository"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -225,3 +225,11 @@ func (f SnapshotGetRepository) WithOpaqueID(s string) func(*SnapshotGetRepositor
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SnapshotGetRepository) WithRequestOptions(o RequestOptions) func(*SnapshotGetRepositoryRequest) {
+	return func(r *SnapshotGetRepositoryRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -51,9 +51,26 @@ type SnapshotRestoreRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
+// SnapshotRestoreBody represents the body of the Snapshot Restore API request.
+//
+type SnapshotRestoreBody struct {
+	Indices             []string               `json:"indices,omitempty"`
+	IgnoreUnavailable   bool                   `json:"ignore_unavailable,omitempty"`
+	IncludeGlobalState  *bool                  `json:"include_global_state,omitempty"`
+	IncludeAliases      *bool                  `json:"include_aliases,omitempty"`
+	Partial             bool                   `json:"partial,omitempty"`
+	RenamePattern       string                 `json:"rename_pattern,omitempty"`
+	RenameReplacement   string                 `json:"rename_replacement,omitempty"`
+	IndexSettings       map[string]interface{} `json:"index_settings,omitempty"`
+	IgnoreIndexSettings []string               `json:"ignore_index_settings,omitempty"`
+	FeatureStates       []string               `json:"feature_states,omitempty"`
+}
+
 // Do executes the request and returns response or error.
 //
 func (r SnapshotRestoreRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
@@ -130,9 +147,7 @@ func (r SnapshotRestoreRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "snapshot.restore"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -164,6 +179,14 @@ func (f SnapshotRestore) WithBody(v io.Reader) func(*SnapshotRestoreRequest) {
 	}
 }
 
+// WithBodyStruct sets the request body to v encoded into JSON.
+//
+func (f SnapshotRestore) WithBodyStruct(v *SnapshotRestoreBody) func(*SnapshotRestoreRequest) {
+	return func(r *SnapshotRestoreRequest) {
+		r.Body = newJSONReader(v)
+	}
+}
+
 // WithMasterTimeout - explicit operation timeout for connection to master node.
 //
 func (f SnapshotRestore) WithMasterTimeout(v time.Duration) func(*SnapshotRestoreRequest) {
@@ -235,3 +258,11 @@ func (f SnapshotRestore) WithOpaqueID(s string) func(*SnapshotRestoreRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SnapshotRestore) WithRequestOptions(o RequestOptions) func(*SnapshotRestoreRequest) {
+	return func(r *SnapshotRestoreRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -48,6 +48,8 @@ type SnapshotStatusRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -127,9 +129,7 @@ func (r SnapshotStatusRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "snapshot.status"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -240,3 +240,11 @@ func (f SnapshotStatus) WithOpaqueID(s string) func(*SnapshotStatusRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SnapshotStatus) WithRequestOptions(o RequestOptions) func(*SnapshotStatusRequest) {
+	return func(r *SnapshotStatusRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
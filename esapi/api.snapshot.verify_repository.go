@@ -46,6 +46,8 @@ type SnapshotVerifyRepositoryRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -119,9 +121,7 @@ func (r SnapshotVerifyRepositoryRequest) Do(ctx context.Context, transport Trans
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "snapshot.verify_repository"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -216,3 +216,11 @@ func (f SnapshotVerifyRepository) WithOpaqueID(s string) func(*SnapshotVerifyRep
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SnapshotVerifyRepository) WithRequestOptions(o RequestOptions) func(*SnapshotVerifyRepositoryRequest) {
+	return func(r *SnapshotVerifyRepositoryRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
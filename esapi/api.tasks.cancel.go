@@ -46,6 +46,8 @@ type TasksCancelRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -125,9 +127,7 @@ func (r TasksCancelRequest) Do(ctx context.Context, transport Transport) (*Respo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "tasks.cancel"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -238,3 +238,11 @@ func (f TasksCancel) WithOpaqueID(s string) func(*TasksCancelRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f TasksCancel) WithRequestOptions(o RequestOptions) func(*TasksCancelRequest) {
+	return func(r *TasksCancelRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -47,6 +47,8 @@ type TasksGetRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -118,9 +120,7 @@ func (r TasksGetRequest) Do(ctx context.Context, transport Transport) (*Response
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "tasks.get"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -215,3 +215,11 @@ func (f TasksGet) WithOpaqueID(s string) func(*TasksGetRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f TasksGet) WithRequestOptions(o RequestOptions) func(*TasksGetRequest) {
+	return func(r *TasksGetRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
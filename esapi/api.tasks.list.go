@@ -50,6 +50,8 @@ type TasksListRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -138,9 +140,7 @@ func (r TasksListRequest) Do(ctx context.Context, transport Transport) (*Respons
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "tasks.list"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -275,3 +275,11 @@ func (f TasksList) WithOpaqueID(s string) func(*TasksListRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f TasksList) WithRequestOptions(o RequestOptions) func(*TasksListRequest) {
+	return func(r *TasksListRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
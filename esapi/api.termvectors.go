@@ -59,6 +59,8 @@ type TermvectorsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -174,9 +176,7 @@ func (r TermvectorsRequest) Do(ctx context.Context, transport Transport) (*Respo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "termvectors", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -359,3 +359,11 @@ func (f Termvectors) WithOpaqueID(s string) func(*TermvectorsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Termvectors) WithRequestOptions(o RequestOptions) func(*TermvectorsRequest) {
+	return func(r *TermvectorsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -60,6 +60,8 @@ type UpdateRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -173,9 +175,7 @@ func (r UpdateRequest) Do(ctx context.Context, transport Transport) (*Response,
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "update", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -342,3 +342,11 @@ func (f Update) WithOpaqueID(s string) func(*UpdateRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f Update) WithRequestOptions(o RequestOptions) func(*UpdateRequest) {
+	return func(r *UpdateRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -83,6 +83,8 @@ type UpdateByQueryRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -286,9 +288,7 @@ func (r UpdateByQueryRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "update_by_query", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -647,3 +647,11 @@ func (f UpdateByQuery) WithOpaqueID(s string) func(*UpdateByQueryRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f UpdateByQuery) WithRequestOptions(o RequestOptions) func(*UpdateByQueryRequest) {
+	return func(r *UpdateByQueryRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
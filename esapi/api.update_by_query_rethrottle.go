@@ -45,6 +45,8 @@ type UpdateByQueryRethrottleRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r UpdateByQueryRethrottleRequest) Do(ctx context.Context, transport Transp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "update_by_query_rethrottle"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -203,3 +203,11 @@ func (f UpdateByQueryRethrottle) WithOpaqueID(s string) func(*UpdateByQueryRethr
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f UpdateByQueryRethrottle) WithRequestOptions(o RequestOptions) func(*UpdateByQueryRethrottleRequest) {
+	return func(r *UpdateByQueryRethrottleRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -42,6 +42,8 @@ type CCRDeleteAutoFollowPatternRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r CCRDeleteAutoFollowPatternRequest) Do(ctx context.Context, transport Tra
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ccr.delete_auto_follow_pattern"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f CCRDeleteAutoFollowPattern) WithOpaqueID(s string) func(*CCRDeleteAutoFo
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CCRDeleteAutoFollowPattern) WithRequestOptions(o RequestOptions) func(*CCRDeleteAutoFollowPatternRequest) {
+	return func(r *CCRDeleteAutoFollowPatternRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -47,6 +47,8 @@ type CCRFollowRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -120,9 +122,7 @@ func (r CCRFollowRequest) Do(ctx context.Context, transport Transport) (*Respons
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ccr.follow", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -209,3 +209,11 @@ func (f CCRFollow) WithOpaqueID(s string) func(*CCRFollowRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CCRFollow) WithRequestOptions(o RequestOptions) func(*CCRFollowRequest) {
+	return func(r *CCRFollowRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -42,6 +42,8 @@ type CCRFollowInfoRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r CCRFollowInfoRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ccr.follow_info", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f CCRFollowInfo) WithOpaqueID(s string) func(*CCRFollowInfoRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CCRFollowInfo) WithRequestOptions(o RequestOptions) func(*CCRFollowInfoRequest) {
+	return func(r *CCRFollowInfoRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -42,6 +42,8 @@ type CCRFollowStatsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r CCRFollowStatsRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ccr.follow_stats", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f CCRFollowStats) WithOpaqueID(s string) func(*CCRFollowStatsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CCRFollowStats) WithRequestOptions(o RequestOptions) func(*CCRFollowStatsRequest) {
+	return func(r *CCRFollowStatsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
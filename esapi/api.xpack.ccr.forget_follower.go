@@ -45,6 +45,8 @@ type CCRForgetFollowerRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r CCRForgetFollowerRequest) Do(ctx context.Context, transport Transport) (
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ccr.forget_follower", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -195,3 +195,11 @@ func (f CCRForgetFollower) WithOpaqueID(s string) func(*CCRForgetFollowerRequest
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CCRForgetFollower) WithRequestOptions(o RequestOptions) func(*CCRForgetFollowerRequest) {
+	return func(r *CCRForgetFollowerRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -42,6 +42,8 @@ type CCRGetAutoFollowPatternRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -109,9 +111,7 @@ func (r CCRGetAutoFollowPatternRequest) Do(ctx context.Context, transport Transp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ccr.get_auto_follow_pattern"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -198,3 +198,11 @@ func (f CCRGetAutoFollowPattern) WithOpaqueID(s string) func(*CCRGetAutoFollowPa
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CCRGetAutoFollowPattern) WithRequestOptions(o RequestOptions) func(*CCRGetAutoFollowPatternRequest) {
+	return func(r *CCRGetAutoFollowPatternRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -42,6 +42,8 @@ type CCRPauseAutoFollowPatternRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -109,9 +111,7 @@ func (r CCRPauseAutoFollowPatternRequest) Do(ctx context.Context, transport Tran
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ccr.pause_auto_follow_pattern"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -190,3 +190,11 @@ func (f CCRPauseAutoFollowPattern) WithOpaqueID(s string) func(*CCRPauseAutoFoll
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CCRPauseAutoFollowPattern) WithRequestOptions(o RequestOptions) func(*CCRPauseAutoFollowPatternRequest) {
+	return func(r *CCRPauseAutoFollowPatternRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
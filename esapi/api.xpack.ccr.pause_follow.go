@@ -42,6 +42,8 @@ type CCRPauseFollowRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r CCRPauseFollowRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ccr.pause_follow", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f CCRPauseFollow) WithOpaqueID(s string) func(*CCRPauseFollowRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CCRPauseFollow) WithRequestOptions(o RequestOptions) func(*CCRPauseFollowRequest) {
+	return func(r *CCRPauseFollowRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
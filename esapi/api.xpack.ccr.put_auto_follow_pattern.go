@@ -45,6 +45,8 @@ type CCRPutAutoFollowPatternRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r CCRPutAutoFollowPatternRequest) Do(ctx context.Context, transport Transp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ccr.put_auto_follow_pattern"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -195,3 +195,11 @@ func (f CCRPutAutoFollowPattern) WithOpaqueID(s string) func(*CCRPutAutoFollowPa
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CCRPutAutoFollowPattern) WithRequestOptions(o RequestOptions) func(*CCRPutAutoFollowPatternRequest) {
+	return func(r *CCRPutAutoFollowPatternRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -42,6 +42,8 @@ type CCRResumeAutoFollowPatternRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -109,9 +111,7 @@ func (r CCRResumeAutoFollowPatternRequest) Do(ctx context.Context, transport Tra
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ccr.resume_auto_follow_pattern"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -190,3 +190,11 @@ func (f CCRResumeAutoFollowPattern) WithOpaqueID(s string) func(*CCRResumeAutoFo
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CCRResumeAutoFollowPattern) WithRequestOptions(o RequestOptions) func(*CCRResumeAutoFollowPatternRequest) {
+	return func(r *CCRResumeAutoFollowPatternRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
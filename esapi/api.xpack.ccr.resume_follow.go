@@ -45,6 +45,8 @@ type CCRResumeFollowRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r CCRResumeFollowRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ccr.resume_follow", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -203,3 +203,11 @@ func (f CCRResumeFollow) WithOpaqueID(s string) func(*CCRResumeFollowRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CCRResumeFollow) WithRequestOptions(o RequestOptions) func(*CCRResumeFollowRequest) {
+	return func(r *CCRResumeFollowRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -40,6 +40,8 @@ type CCRStatsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r CCRStatsRequest) Do(ctx context.Context, transport Transport) (*Response
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ccr.stats"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f CCRStats) WithOpaqueID(s string) func(*CCRStatsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CCRStats) WithRequestOptions(o RequestOptions) func(*CCRStatsRequest) {
+	return func(r *CCRStatsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -42,6 +42,8 @@ type CCRUnfollowRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r CCRUnfollowRequest) Do(ctx context.Context, transport Transport) (*Respo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ccr.unfollow", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f CCRUnfollow) WithOpaqueID(s string) func(*CCRUnfollowRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f CCRUnfollow) WithRequestOptions(o RequestOptions) func(*CCRUnfollowRequest) {
+	return func(r *CCRUnfollowRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -41,6 +41,8 @@ type DataFrameDeleteDataFrameTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r DataFrameDeleteDataFrameTransformRequest) Do(ctx context.Context, transp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "data_frame.delete_data_frame_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -185,3 +185,11 @@ func (f DataFrameDeleteDataFrameTransform) WithHeader(h map[string]string) func(
 		}
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DataFrameDeleteDataFrameTransform) WithRequestOptions(o RequestOptions) func(*DataFrameDeleteDataFrameTransformRequest) {
+	return func(r *DataFrameDeleteDataFrameTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -43,6 +43,8 @@ type DataFrameGetDataFrameTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -119,9 +121,7 @@ func (r DataFrameGetDataFrameTransformRequest) Do(ctx context.Context, transport
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "data_frame.get_data_frame_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -221,3 +221,11 @@ func (f DataFrameGetDataFrameTransform) WithHeader(h map[string]string) func(*Da
 		}
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DataFrameGetDataFrameTransform) WithRequestOptions(o RequestOptions) func(*DataFrameGetDataFrameTransformRequest) {
+	return func(r *DataFrameGetDataFrameTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
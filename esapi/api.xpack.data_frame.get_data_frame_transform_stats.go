@@ -43,6 +43,8 @@ type DataFrameGetDataFrameTransformStatsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -121,9 +123,7 @@ func (r DataFrameGetDataFrameTransformStatsRequest) Do(ctx context.Context, tran
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "data_frame.get_data_frame_transform_stats"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -223,3 +223,11 @@ func (f DataFrameGetDataFrameTransformStats) WithHeader(h map[string]string) fun
 		}
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DataFrameGetDataFrameTransformStats) WithRequestOptions(o RequestOptions) func(*DataFrameGetDataFrameTransformStatsRequest) {
+	return func(r *DataFrameGetDataFrameTransformStatsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -39,6 +39,8 @@ type DataFramePreviewDataFrameTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r DataFramePreviewDataFrameTransformRequest) Do(ctx context.Context, trans
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "data_frame.preview_data_frame_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -170,3 +170,11 @@ func (f DataFramePreviewDataFrameTransform) WithHeader(h map[string]string) func
 		}
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DataFramePreviewDataFrameTransform) WithRequestOptions(o RequestOptions) func(*DataFramePreviewDataFrameTransformRequest) {
+	return func(r *DataFramePreviewDataFrameTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
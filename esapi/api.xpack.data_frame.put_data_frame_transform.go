@@ -44,6 +44,8 @@ type DataFramePutDataFrameTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r DataFramePutDataFrameTransformRequest) Do(ctx context.Context, transport
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "data_frame.put_data_frame_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -192,3 +192,11 @@ func (f DataFramePutDataFrameTransform) WithHeader(h map[string]string) func(*Da
 		}
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DataFramePutDataFrameTransform) WithRequestOptions(o RequestOptions) func(*DataFramePutDataFrameTransformRequest) {
+	return func(r *DataFramePutDataFrameTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
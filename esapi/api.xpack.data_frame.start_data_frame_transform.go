@@ -41,6 +41,8 @@ type DataFrameStartDataFrameTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -109,9 +111,7 @@ func (r DataFrameStartDataFrameTransformRequest) Do(ctx context.Context, transpo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "data_frame.start_data_frame_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -187,3 +187,11 @@ func (f DataFrameStartDataFrameTransform) WithHeader(h map[string]string) func(*
 		}
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DataFrameStartDataFrameTransform) WithRequestOptions(o RequestOptions) func(*DataFrameStartDataFrameTransformRequest) {
+	return func(r *DataFrameStartDataFrameTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -44,6 +44,8 @@ type DataFrameStopDataFrameTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -120,9 +122,7 @@ func (r DataFrameStopDataFrameTransformRequest) Do(ctx context.Context, transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "data_frame.stop_data_frame_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -214,3 +214,11 @@ func (f DataFrameStopDataFrameTransform) WithHeader(h map[string]string) func(*D
 		}
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DataFrameStopDataFrameTransform) WithRequestOptions(o RequestOptions) func(*DataFrameStopDataFrameTransformRequest) {
+	return func(r *DataFrameStopDataFrameTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -44,6 +44,8 @@ type DataFrameUpdateDataFrameTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -116,9 +118,7 @@ func (r DataFrameUpdateDataFrameTransformRequest) Do(ctx context.Context, transp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "data_frame.update_data_frame_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -194,3 +194,11 @@ func (f DataFrameUpdateDataFrameTransform) WithHeader(h map[string]string) func(
 		}
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DataFrameUpdateDataFrameTransform) WithRequestOptions(o RequestOptions) func(*DataFrameUpdateDataFrameTransformRequest) {
+	return func(r *DataFrameUpdateDataFrameTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -45,6 +45,8 @@ type DataFrameTransformDeprecatedDeleteTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r DataFrameTransformDeprecatedDeleteTransformRequest) Do(ctx context.Conte
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "data_frame_transform_deprecated.delete_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -203,3 +203,11 @@ func (f DataFrameTransformDeprecatedDeleteTransform) WithOpaqueID(s string) func
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DataFrameTransformDeprecatedDeleteTransform) WithRequestOptions(o RequestOptions) func(*DataFrameTransformDeprecatedDeleteTransformRequest) {
+	return func(r *DataFrameTransformDeprecatedDeleteTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
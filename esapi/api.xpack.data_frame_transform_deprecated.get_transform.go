@@ -47,6 +47,8 @@ type DataFrameTransformDeprecatedGetTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -126,9 +128,7 @@ func (r DataFrameTransformDeprecatedGetTransformRequest) Do(ctx context.Context,
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "data_frame_transform_deprecated.get_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -239,3 +239,11 @@ func (f DataFrameTransformDeprecatedGetTransform) WithOpaqueID(s string) func(*D
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DataFrameTransformDeprecatedGetTransform) WithRequestOptions(o RequestOptions) func(*DataFrameTransformDeprecatedGetTransformRequest) {
+	return func(r *DataFrameTransformDeprecatedGetTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
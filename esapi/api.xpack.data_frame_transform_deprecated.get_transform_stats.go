@@ -47,6 +47,8 @@ type DataFrameTransformDeprecatedGetTransformStatsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -126,9 +128,7 @@ func (r DataFrameTransformDeprecatedGetTransformStatsRequest) Do(ctx context.Con
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "data_frame_transform_deprecated.get_transform_stats"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -231,3 +231,11 @@ func (f DataFrameTransformDeprecatedGetTransformStats) WithOpaqueID(s string) fu
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DataFrameTransformDeprecatedGetTransformStats) WithRequestOptions(o RequestOptions) func(*DataFrameTransformDeprecatedGetTransformStatsRequest) {
+	return func(r *DataFrameTransformDeprecatedGetTransformStatsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
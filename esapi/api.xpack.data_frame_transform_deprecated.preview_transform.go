@@ -43,6 +43,8 @@ type DataFrameTransformDeprecatedPreviewTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r DataFrameTransformDeprecatedPreviewTransformRequest) Do(ctx context.Cont
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "data_frame_transform_deprecated.preview_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f DataFrameTransformDeprecatedPreviewTransform) WithOpaqueID(s string) fun
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DataFrameTransformDeprecatedPreviewTransform) WithRequestOptions(o RequestOptions) func(*DataFrameTransformDeprecatedPreviewTransformRequest) {
+	return func(r *DataFrameTransformDeprecatedPreviewTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -48,6 +48,8 @@ type DataFrameTransformDeprecatedPutTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -121,9 +123,7 @@ func (r DataFrameTransformDeprecatedPutTransformRequest) Do(ctx context.Context,
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "data_frame_transform_deprecated.put_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -210,3 +210,11 @@ func (f DataFrameTransformDeprecatedPutTransform) WithOpaqueID(s string) func(*D
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DataFrameTransformDeprecatedPutTransform) WithRequestOptions(o RequestOptions) func(*DataFrameTransformDeprecatedPutTransformRequest) {
+	return func(r *DataFrameTransformDeprecatedPutTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -45,6 +45,8 @@ type DataFrameTransformDeprecatedStartTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -116,9 +118,7 @@ func (r DataFrameTransformDeprecatedStartTransformRequest) Do(ctx context.Contex
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "data_frame_transform_deprecated.start_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -205,3 +205,11 @@ func (f DataFrameTransformDeprecatedStartTransform) WithOpaqueID(s string) func(
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DataFrameTransformDeprecatedStartTransform) WithRequestOptions(o RequestOptions) func(*DataFrameTransformDeprecatedStartTransformRequest) {
+	return func(r *DataFrameTransformDeprecatedStartTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -48,6 +48,8 @@ type DataFrameTransformDeprecatedStopTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -127,9 +129,7 @@ func (r DataFrameTransformDeprecatedStopTransformRequest) Do(ctx context.Context
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "data_frame_transform_deprecated.stop_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -232,3 +232,11 @@ func (f DataFrameTransformDeprecatedStopTransform) WithOpaqueID(s string) func(*
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DataFrameTransformDeprecatedStopTransform) WithRequestOptions(o RequestOptions) func(*DataFrameTransformDeprecatedStopTransformRequest) {
+	return func(r *DataFrameTransformDeprecatedStopTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -48,6 +48,8 @@ type DataFrameTransformDeprecatedUpdateTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -123,9 +125,7 @@ func (r DataFrameTransformDeprecatedUpdateTransformRequest) Do(ctx context.Conte
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "data_frame_transform_deprecated.update_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -212,3 +212,11 @@ func (f DataFrameTransformDeprecatedUpdateTransform) WithOpaqueID(s string) func
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f DataFrameTransformDeprecatedUpdateTransform) WithRequestOptions(o RequestOptions) func(*DataFrameTransformDeprecatedUpdateTransformRequest) {
+	return func(r *DataFrameTransformDeprecatedUpdateTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
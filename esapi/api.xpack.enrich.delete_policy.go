@@ -42,6 +42,8 @@ type EnrichDeletePolicyRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r EnrichDeletePolicyRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "enrich.delete_policy"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f EnrichDeletePolicy) WithOpaqueID(s string) func(*EnrichDeletePolicyReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f EnrichDeletePolicy) WithRequestOptions(o RequestOptions) func(*EnrichDeletePolicyRequest) {
+	return func(r *EnrichDeletePolicyRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
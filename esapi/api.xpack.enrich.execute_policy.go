@@ -45,6 +45,8 @@ type EnrichExecutePolicyRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -116,9 +118,7 @@ func (r EnrichExecutePolicyRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "enrich.execute_policy"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -205,3 +205,11 @@ func (f EnrichExecutePolicy) WithOpaqueID(s string) func(*EnrichExecutePolicyReq
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f EnrichExecutePolicy) WithRequestOptions(o RequestOptions) func(*EnrichExecutePolicyRequest) {
+	return func(r *EnrichExecutePolicyRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -42,6 +42,8 @@ type EnrichGetPolicyRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -109,9 +111,7 @@ func (r EnrichGetPolicyRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "enrich.get_policy"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -198,3 +198,11 @@ func (f EnrichGetPolicy) WithOpaqueID(s string) func(*EnrichGetPolicyRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f EnrichGetPolicy) WithRequestOptions(o RequestOptions) func(*EnrichGetPolicyRequest) {
+	return func(r *EnrichGetPolicyRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -45,6 +45,8 @@ type EnrichPutPolicyRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r EnrichPutPolicyRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "enrich.put_policy"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -195,3 +195,11 @@ func (f EnrichPutPolicy) WithOpaqueID(s string) func(*EnrichPutPolicyRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f EnrichPutPolicy) WithRequestOptions(o RequestOptions) func(*EnrichPutPolicyRequest) {
+	return func(r *EnrichPutPolicyRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
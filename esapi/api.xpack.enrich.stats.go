@@ -40,6 +40,8 @@ type EnrichStatsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r EnrichStatsRequest) Do(ctx context.Context, transport Transport) (*Respo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "enrich.stats"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f EnrichStats) WithOpaqueID(s string) func(*EnrichStatsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f EnrichStats) WithRequestOptions(o RequestOptions) func(*EnrichStatsRequest) {
+	return func(r *EnrichStatsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
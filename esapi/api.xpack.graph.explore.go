@@ -49,6 +49,8 @@ type GraphExploreRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -126,9 +128,7 @@ func (r GraphExploreRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "graph.explore", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -231,3 +231,11 @@ func (f GraphExplore) WithOpaqueID(s string) func(*GraphExploreRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f GraphExplore) WithRequestOptions(o RequestOptions) func(*GraphExploreRequest) {
+	return func(r *GraphExploreRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
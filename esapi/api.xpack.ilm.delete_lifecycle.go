@@ -42,6 +42,8 @@ type ILMDeleteLifecycleRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r ILMDeleteLifecycleRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ilm.delete_lifecycle"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f ILMDeleteLifecycle) WithOpaqueID(s string) func(*ILMDeleteLifecycleReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ILMDeleteLifecycle) WithRequestOptions(o RequestOptions) func(*ILMDeleteLifecycleRequest) {
+	return func(r *ILMDeleteLifecycleRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -46,6 +46,8 @@ type ILMExplainLifecycleRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -119,9 +121,7 @@ func (r ILMExplainLifecycleRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ilm.explain_lifecycle", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -216,3 +216,11 @@ func (f ILMExplainLifecycle) WithOpaqueID(s string) func(*ILMExplainLifecycleReq
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ILMExplainLifecycle) WithRequestOptions(o RequestOptions) func(*ILMExplainLifecycleRequest) {
+	return func(r *ILMExplainLifecycleRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
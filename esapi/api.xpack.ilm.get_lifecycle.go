@@ -42,6 +42,8 @@ type ILMGetLifecycleRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -109,9 +111,7 @@ func (r ILMGetLifecycleRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ilm.get_lifecycle"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -198,3 +198,11 @@ func (f ILMGetLifecycle) WithOpaqueID(s string) func(*ILMGetLifecycleRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ILMGetLifecycle) WithRequestOptions(o RequestOptions) func(*ILMGetLifecycleRequest) {
+	return func(r *ILMGetLifecycleRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
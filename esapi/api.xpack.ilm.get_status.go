@@ -40,6 +40,8 @@ type ILMGetStatusRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r ILMGetStatusRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ilm.get_status"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f ILMGetStatus) WithOpaqueID(s string) func(*ILMGetStatusRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ILMGetStatus) WithRequestOptions(o RequestOptions) func(*ILMGetStatusRequest) {
+	return func(r *ILMGetStatusRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
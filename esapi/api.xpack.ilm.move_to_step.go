@@ -45,6 +45,8 @@ type ILMMoveToStepRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r ILMMoveToStepRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ilm.move_to_step", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -203,3 +203,11 @@ func (f ILMMoveToStep) WithOpaqueID(s string) func(*ILMMoveToStepRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ILMMoveToStep) WithRequestOptions(o RequestOptions) func(*ILMMoveToStepRequest) {
+	return func(r *ILMMoveToStepRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
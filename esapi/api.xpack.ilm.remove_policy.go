@@ -42,6 +42,8 @@ type ILMRemovePolicyRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r ILMRemovePolicyRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ilm.remove_policy", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f ILMRemovePolicy) WithOpaqueID(s string) func(*ILMRemovePolicyRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ILMRemovePolicy) WithRequestOptions(o RequestOptions) func(*ILMRemovePolicyRequest) {
+	return func(r *ILMRemovePolicyRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
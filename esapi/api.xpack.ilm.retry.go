@@ -42,6 +42,8 @@ type ILMRetryRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r ILMRetryRequest) Do(ctx context.Context, transport Transport) (*Response
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ilm.retry", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f ILMRetry) WithOpaqueID(s string) func(*ILMRetryRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ILMRetry) WithRequestOptions(o RequestOptions) func(*ILMRetryRequest) {
+	return func(r *ILMRetryRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -40,6 +40,8 @@ type ILMStartRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r ILMStartRequest) Do(ctx context.Context, transport Transport) (*Response
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ilm.start"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f ILMStart) WithOpaqueID(s string) func(*ILMStartRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ILMStart) WithRequestOptions(o RequestOptions) func(*ILMStartRequest) {
+	return func(r *ILMStartRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
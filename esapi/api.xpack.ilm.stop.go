@@ -40,6 +40,8 @@ type ILMStopRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r ILMStopRequest) Do(ctx context.Context, transport Transport) (*Response,
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ilm.stop"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f ILMStop) WithOpaqueID(s string) func(*ILMStopRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ILMStop) WithRequestOptions(o RequestOptions) func(*ILMStopRequest) {
+	return func(r *ILMStopRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
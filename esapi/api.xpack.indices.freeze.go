@@ -51,6 +51,8 @@ type IndicesFreezeRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -138,9 +140,7 @@ func (r IndicesFreezeRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.freeze", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -267,3 +267,11 @@ func (f IndicesFreeze) WithOpaqueID(s string) func(*IndicesFreezeRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesFreeze) WithRequestOptions(o RequestOptions) func(*IndicesFreezeRequest) {
+	return func(r *IndicesFreezeRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
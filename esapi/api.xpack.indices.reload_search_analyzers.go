@@ -47,6 +47,8 @@ type IndicesReloadSearchAnalyzersRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -122,9 +124,7 @@ func (r IndicesReloadSearchAnalyzersRequest) Do(ctx context.Context, transport T
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.reload_search_analyzers", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -227,3 +227,11 @@ func (f IndicesReloadSearchAnalyzers) WithOpaqueID(s string) func(*IndicesReload
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesReloadSearchAnalyzers) WithRequestOptions(o RequestOptions) func(*IndicesReloadSearchAnalyzersRequest) {
+	return func(r *IndicesReloadSearchAnalyzersRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
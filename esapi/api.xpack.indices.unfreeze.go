@@ -51,6 +51,8 @@ type IndicesUnfreezeRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -138,9 +140,7 @@ func (r IndicesUnfreezeRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "indices.unfreeze", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -267,3 +267,11 @@ func (f IndicesUnfreeze) WithOpaqueID(s string) func(*IndicesUnfreezeRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f IndicesUnfreeze) WithRequestOptions(o RequestOptions) func(*IndicesUnfreezeRequest) {
+	return func(r *IndicesUnfreezeRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -40,6 +40,8 @@ type LicenseDeleteRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r LicenseDeleteRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "license.delete"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f LicenseDelete) WithOpaqueID(s string) func(*LicenseDeleteRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f LicenseDelete) WithRequestOptions(o RequestOptions) func(*LicenseDeleteRequest) {
+	return func(r *LicenseDeleteRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
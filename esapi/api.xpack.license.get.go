@@ -44,6 +44,8 @@ type LicenseGetRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -112,9 +114,7 @@ func (r LicenseGetRequest) Do(ctx context.Context, transport Transport) (*Respon
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "license.get"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -209,3 +209,11 @@ func (f LicenseGet) WithOpaqueID(s string) func(*LicenseGetRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f LicenseGet) WithRequestOptions(o RequestOptions) func(*LicenseGetRequest) {
+	return func(r *LicenseGetRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -40,6 +40,8 @@ type LicenseGetBasicStatusRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r LicenseGetBasicStatusRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "license.get_basic_status"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f LicenseGetBasicStatus) WithOpaqueID(s string) func(*LicenseGetBasicStatu
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f LicenseGetBasicStatus) WithRequestOptions(o RequestOptions) func(*LicenseGetBasicStatusRequest) {
+	return func(r *LicenseGetBasicStatusRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
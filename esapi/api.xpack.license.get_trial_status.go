@@ -40,6 +40,8 @@ type LicenseGetTrialStatusRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r LicenseGetTrialStatusRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "license.get_trial_status"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f LicenseGetTrialStatus) WithOpaqueID(s string) func(*LicenseGetTrialStatu
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f LicenseGetTrialStatus) WithRequestOptions(o RequestOptions) func(*LicenseGetTrialStatusRequest) {
+	return func(r *LicenseGetTrialStatusRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
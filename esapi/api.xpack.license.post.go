@@ -46,6 +46,8 @@ type LicensePostRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r LicensePostRequest) Do(ctx context.Context, transport Transport) (*Respo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "license.post"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -211,3 +211,11 @@ func (f LicensePost) WithOpaqueID(s string) func(*LicensePostRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f LicensePost) WithRequestOptions(o RequestOptions) func(*LicensePostRequest) {
+	return func(r *LicensePostRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -43,6 +43,8 @@ type LicensePostStartBasicRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r LicensePostStartBasicRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "license.post_start_basic"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -196,3 +196,11 @@ func (f LicensePostStartBasic) WithOpaqueID(s string) func(*LicensePostStartBasi
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f LicensePostStartBasic) WithRequestOptions(o RequestOptions) func(*LicensePostStartBasicRequest) {
+	return func(r *LicensePostStartBasicRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -44,6 +44,8 @@ type LicensePostStartTrialRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -112,9 +114,7 @@ func (r LicensePostStartTrialRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "license.post_start_trial"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -209,3 +209,11 @@ func (f LicensePostStartTrial) WithOpaqueID(s string) func(*LicensePostStartTria
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f LicensePostStartTrial) WithRequestOptions(o RequestOptions) func(*LicensePostStartTrialRequest) {
+	return func(r *LicensePostStartTrialRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -42,6 +42,8 @@ type MigrationDeprecationsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -109,9 +111,7 @@ func (r MigrationDeprecationsRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "migration.deprecations", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -198,3 +198,11 @@ func (f MigrationDeprecations) WithOpaqueID(s string) func(*MigrationDeprecation
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MigrationDeprecations) WithRequestOptions(o RequestOptions) func(*MigrationDeprecationsRequest) {
+	return func(r *MigrationDeprecationsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
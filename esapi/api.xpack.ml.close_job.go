@@ -51,6 +51,8 @@ type MLCloseJobRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -134,9 +136,7 @@ func (r MLCloseJobRequest) Do(ctx context.Context, transport Transport) (*Respon
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.close_job"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -247,3 +247,11 @@ func (f MLCloseJob) WithOpaqueID(s string) func(*MLCloseJobRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLCloseJob) WithRequestOptions(o RequestOptions) func(*MLCloseJobRequest) {
+	return func(r *MLCloseJobRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -40,6 +40,8 @@ type MLDeleteCalendarRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -105,9 +107,7 @@ func (r MLDeleteCalendarRequest) Do(ctx context.Context, transport Transport) (*
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.delete_calendar"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -186,3 +186,11 @@ func (f MLDeleteCalendar) WithOpaqueID(s string) func(*MLDeleteCalendarRequest)
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLDeleteCalendar) WithRequestOptions(o RequestOptions) func(*MLDeleteCalendarRequest) {
+	return func(r *MLDeleteCalendarRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -41,6 +41,8 @@ type MLDeleteCalendarEventRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -110,9 +112,7 @@ func (r MLDeleteCalendarEventRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.delete_calendar_event"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -191,3 +191,11 @@ func (f MLDeleteCalendarEvent) WithOpaqueID(s string) func(*MLDeleteCalendarEven
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLDeleteCalendarEvent) WithRequestOptions(o RequestOptions) func(*MLDeleteCalendarEventRequest) {
+	return func(r *MLDeleteCalendarEventRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
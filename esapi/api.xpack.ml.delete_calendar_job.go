@@ -41,6 +41,8 @@ type MLDeleteCalendarJobRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -110,9 +112,7 @@ func (r MLDeleteCalendarJobRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.delete_calendar_job"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -191,3 +191,11 @@ func (f MLDeleteCalendarJob) WithOpaqueID(s string) func(*MLDeleteCalendarJobReq
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLDeleteCalendarJob) WithRequestOptions(o RequestOptions) func(*MLDeleteCalendarJobRequest) {
+	return func(r *MLDeleteCalendarJobRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -42,6 +42,8 @@ type MLDeleteDataFrameAnalyticsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -109,9 +111,7 @@ func (r MLDeleteDataFrameAnalyticsRequest) Do(ctx context.Context, transport Tra
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.delete_data_frame_analytics"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -190,3 +190,11 @@ func (f MLDeleteDataFrameAnalytics) WithOpaqueID(s string) func(*MLDeleteDataFra
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLDeleteDataFrameAnalytics) WithRequestOptions(o RequestOptions) func(*MLDeleteDataFrameAnalyticsRequest) {
+	return func(r *MLDeleteDataFrameAnalyticsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -45,6 +45,8 @@ type MLDeleteDatafeedRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r MLDeleteDatafeedRequest) Do(ctx context.Context, transport Transport) (*
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.delete_datafeed"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -203,3 +203,11 @@ func (f MLDeleteDatafeed) WithOpaqueID(s string) func(*MLDeleteDatafeedRequest)
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLDeleteDatafeed) WithRequestOptions(o RequestOptions) func(*MLDeleteDatafeedRequest) {
+	return func(r *MLDeleteDatafeedRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -38,6 +38,8 @@ type MLDeleteExpiredDataRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -98,9 +100,7 @@ func (r MLDeleteExpiredDataRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.delete_expired_data"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -179,3 +179,11 @@ func (f MLDeleteExpiredData) WithOpaqueID(s string) func(*MLDeleteExpiredDataReq
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLDeleteExpiredData) WithRequestOptions(o RequestOptions) func(*MLDeleteExpiredDataRequest) {
+	return func(r *MLDeleteExpiredDataRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
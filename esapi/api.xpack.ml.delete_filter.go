@@ -40,6 +40,8 @@ type MLDeleteFilterRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -105,9 +107,7 @@ func (r MLDeleteFilterRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.delete_filter"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -186,3 +186,11 @@ func (f MLDeleteFilter) WithOpaqueID(s string) func(*MLDeleteFilterRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLDeleteFilter) WithRequestOptions(o RequestOptions) func(*MLDeleteFilterRequest) {
+	return func(r *MLDeleteFilterRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
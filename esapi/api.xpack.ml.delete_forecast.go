@@ -48,6 +48,8 @@ type MLDeleteForecastRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -127,9 +129,7 @@ func (r MLDeleteForecastRequest) Do(ctx context.Context, transport Transport) (*
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.delete_forecast"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -232,3 +232,11 @@ func (f MLDeleteForecast) WithOpaqueID(s string) func(*MLDeleteForecastRequest)
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLDeleteForecast) WithRequestOptions(o RequestOptions) func(*MLDeleteForecastRequest) {
+	return func(r *MLDeleteForecastRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
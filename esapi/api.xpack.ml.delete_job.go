@@ -46,6 +46,8 @@ type MLDeleteJobRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -119,9 +121,7 @@ func (r MLDeleteJobRequest) Do(ctx context.Context, transport Transport) (*Respo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.delete_job"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -216,3 +216,11 @@ func (f MLDeleteJob) WithOpaqueID(s string) func(*MLDeleteJobRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLDeleteJob) WithRequestOptions(o RequestOptions) func(*MLDeleteJobRequest) {
+	return func(r *MLDeleteJobRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
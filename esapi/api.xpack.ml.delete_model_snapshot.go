@@ -43,6 +43,8 @@ type MLDeleteModelSnapshotRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -112,9 +114,7 @@ func (r MLDeleteModelSnapshotRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.delete_model_snapshot"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -193,3 +193,11 @@ func (f MLDeleteModelSnapshot) WithOpaqueID(s string) func(*MLDeleteModelSnapsho
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLDeleteModelSnapshot) WithRequestOptions(o RequestOptions) func(*MLDeleteModelSnapshotRequest) {
+	return func(r *MLDeleteModelSnapshotRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -40,6 +40,8 @@ type MLDeleteTrainedModelRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -105,9 +107,7 @@ func (r MLDeleteTrainedModelRequest) Do(ctx context.Context, transport Transport
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.delete_trained_model"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -186,3 +186,11 @@ func (f MLDeleteTrainedModel) WithOpaqueID(s string) func(*MLDeleteTrainedModelR
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLDeleteTrainedModel) WithRequestOptions(o RequestOptions) func(*MLDeleteTrainedModelRequest) {
+	return func(r *MLDeleteTrainedModelRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -43,6 +43,8 @@ type MLEstimateMemoryUsageRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r MLEstimateMemoryUsageRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.estimate_memory_usage"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f MLEstimateMemoryUsage) WithOpaqueID(s string) func(*MLEstimateMemoryUsag
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLEstimateMemoryUsage) WithRequestOptions(o RequestOptions) func(*MLEstimateMemoryUsageRequest) {
+	return func(r *MLEstimateMemoryUsageRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -43,6 +43,8 @@ type MLEvaluateDataFrameRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r MLEvaluateDataFrameRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.evaluate_data_frame"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f MLEvaluateDataFrame) WithOpaqueID(s string) func(*MLEvaluateDataFrameReq
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLEvaluateDataFrame) WithRequestOptions(o RequestOptions) func(*MLEvaluateDataFrameRequest) {
+	return func(r *MLEvaluateDataFrameRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -45,6 +45,8 @@ type MLExplainDataFrameAnalyticsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -120,9 +122,7 @@ func (r MLExplainDataFrameAnalyticsRequest) Do(ctx context.Context, transport Tr
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.explain_data_frame_analytics"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -217,3 +217,11 @@ func (f MLExplainDataFrameAnalytics) WithOpaqueID(s string) func(*MLExplainDataF
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLExplainDataFrameAnalytics) WithRequestOptions(o RequestOptions) func(*MLExplainDataFrameAnalyticsRequest) {
+	return func(r *MLExplainDataFrameAnalyticsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
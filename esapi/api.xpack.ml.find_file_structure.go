@@ -60,6 +60,8 @@ type MLFindFileStructureRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -180,9 +182,7 @@ func (r MLFindFileStructureRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.find_file_structure"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -373,3 +373,11 @@ func (f MLFindFileStructure) WithOpaqueID(s string) func(*MLFindFileStructureReq
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLFindFileStructure) WithRequestOptions(o RequestOptions) func(*MLFindFileStructureRequest) {
+	return func(r *MLFindFileStructureRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
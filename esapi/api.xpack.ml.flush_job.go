@@ -52,6 +52,8 @@ type MLFlushJobRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -143,9 +145,7 @@ func (r MLFlushJobRequest) Do(ctx context.Context, transport Transport) (*Respon
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.flush_job"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -272,3 +272,11 @@ func (f MLFlushJob) WithOpaqueID(s string) func(*MLFlushJobRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLFlushJob) WithRequestOptions(o RequestOptions) func(*MLFlushJobRequest) {
+	return func(r *MLFlushJobRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -44,6 +44,8 @@ type MLForecastRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -119,9 +121,7 @@ func (r MLForecastRequest) Do(ctx context.Context, transport Transport) (*Respon
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.forecast"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -216,3 +216,11 @@ func (f MLForecast) WithOpaqueID(s string) func(*MLForecastRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLForecast) WithRequestOptions(o RequestOptions) func(*MLForecastRequest) {
+	return func(r *MLForecastRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -58,6 +58,8 @@ type MLGetBucketsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -171,9 +173,7 @@ func (r MLGetBucketsRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.get_buckets"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -340,3 +340,11 @@ func (f MLGetBuckets) WithOpaqueID(s string) func(*MLGetBucketsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLGetBuckets) WithRequestOptions(o RequestOptions) func(*MLGetBucketsRequest) {
+	return func(r *MLGetBucketsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
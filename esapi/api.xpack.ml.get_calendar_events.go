@@ -48,6 +48,8 @@ type MLGetCalendarEventsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -135,9 +137,7 @@ func (r MLGetCalendarEventsRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.get_calendar_events"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -256,3 +256,11 @@ func (f MLGetCalendarEvents) WithOpaqueID(s string) func(*MLGetCalendarEventsReq
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLGetCalendarEvents) WithRequestOptions(o RequestOptions) func(*MLGetCalendarEventsRequest) {
+	return func(r *MLGetCalendarEventsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -47,6 +47,8 @@ type MLGetCalendarsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -126,9 +128,7 @@ func (r MLGetCalendarsRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.get_calendars"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -239,3 +239,11 @@ func (f MLGetCalendars) WithOpaqueID(s string) func(*MLGetCalendarsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLGetCalendars) WithRequestOptions(o RequestOptions) func(*MLGetCalendarsRequest) {
+	return func(r *MLGetCalendarsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
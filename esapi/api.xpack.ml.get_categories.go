@@ -50,6 +50,8 @@ type MLGetCategoriesRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -137,9 +139,7 @@ func (r MLGetCategoriesRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.get_categories"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -250,3 +250,11 @@ func (f MLGetCategories) WithOpaqueID(s string) func(*MLGetCategoriesRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLGetCategories) WithRequestOptions(o RequestOptions) func(*MLGetCategoriesRequest) {
+	return func(r *MLGetCategoriesRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
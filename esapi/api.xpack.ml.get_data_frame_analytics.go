@@ -47,6 +47,8 @@ type MLGetDataFrameAnalyticsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -128,9 +130,7 @@ func (r MLGetDataFrameAnalyticsRequest) Do(ctx context.Context, transport Transp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.get_data_frame_analytics"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -241,3 +241,11 @@ func (f MLGetDataFrameAnalytics) WithOpaqueID(s string) func(*MLGetDataFrameAnal
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLGetDataFrameAnalytics) WithRequestOptions(o RequestOptions) func(*MLGetDataFrameAnalyticsRequest) {
+	return func(r *MLGetDataFrameAnalyticsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
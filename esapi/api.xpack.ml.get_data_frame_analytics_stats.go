@@ -47,6 +47,8 @@ type MLGetDataFrameAnalyticsStatsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -130,9 +132,7 @@ func (r MLGetDataFrameAnalyticsStatsRequest) Do(ctx context.Context, transport T
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.get_data_frame_analytics_stats"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -243,3 +243,11 @@ func (f MLGetDataFrameAnalyticsStats) WithOpaqueID(s string) func(*MLGetDataFram
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLGetDataFrameAnalyticsStats) WithRequestOptions(o RequestOptions) func(*MLGetDataFrameAnalyticsStatsRequest) {
+	return func(r *MLGetDataFrameAnalyticsStatsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -45,6 +45,8 @@ type MLGetDatafeedStatsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -118,9 +120,7 @@ func (r MLGetDatafeedStatsRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.get_datafeed_stats"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -215,3 +215,11 @@ func (f MLGetDatafeedStats) WithOpaqueID(s string) func(*MLGetDatafeedStatsReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLGetDatafeedStats) WithRequestOptions(o RequestOptions) func(*MLGetDatafeedStatsRequest) {
+	return func(r *MLGetDatafeedStatsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -45,6 +45,8 @@ type MLGetDatafeedsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -116,9 +118,7 @@ func (r MLGetDatafeedsRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.get_datafeeds"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -213,3 +213,11 @@ func (f MLGetDatafeeds) WithOpaqueID(s string) func(*MLGetDatafeedsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLGetDatafeeds) WithRequestOptions(o RequestOptions) func(*MLGetDatafeedsRequest) {
+	return func(r *MLGetDatafeedsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -44,6 +44,8 @@ type MLGetFiltersRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -119,9 +121,7 @@ func (r MLGetFiltersRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.get_filters"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -224,3 +224,11 @@ func (f MLGetFilters) WithOpaqueID(s string) func(*MLGetFiltersRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLGetFilters) WithRequestOptions(o RequestOptions) func(*MLGetFiltersRequest) {
+	return func(r *MLGetFiltersRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
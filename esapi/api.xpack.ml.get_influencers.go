@@ -56,6 +56,8 @@ type MLGetInfluencersRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -161,9 +163,7 @@ func (r MLGetInfluencersRequest) Do(ctx context.Context, transport Transport) (*
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.get_influencers"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -314,3 +314,11 @@ func (f MLGetInfluencers) WithOpaqueID(s string) func(*MLGetInfluencersRequest)
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLGetInfluencers) WithRequestOptions(o RequestOptions) func(*MLGetInfluencersRequest) {
+	return func(r *MLGetInfluencersRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
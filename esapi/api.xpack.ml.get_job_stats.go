@@ -45,6 +45,8 @@ type MLGetJobStatsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -118,9 +120,7 @@ func (r MLGetJobStatsRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.get_job_stats"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -215,3 +215,11 @@ func (f MLGetJobStats) WithOpaqueID(s string) func(*MLGetJobStatsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLGetJobStats) WithRequestOptions(o RequestOptions) func(*MLGetJobStatsRequest) {
+	return func(r *MLGetJobStatsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -45,6 +45,8 @@ type MLGetJobsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -116,9 +118,7 @@ func (r MLGetJobsRequest) Do(ctx context.Context, transport Transport) (*Respons
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.get_jobs"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -213,3 +213,11 @@ func (f MLGetJobs) WithOpaqueID(s string) func(*MLGetJobsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLGetJobs) WithRequestOptions(o RequestOptions) func(*MLGetJobsRequest) {
+	return func(r *MLGetJobsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -55,6 +55,8 @@ type MLGetModelSnapshotsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -154,9 +156,7 @@ func (r MLGetModelSnapshotsRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.get_model_snapshots"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -299,3 +299,11 @@ func (f MLGetModelSnapshots) WithOpaqueID(s string) func(*MLGetModelSnapshotsReq
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLGetModelSnapshots) WithRequestOptions(o RequestOptions) func(*MLGetModelSnapshotsRequest) {
+	return func(r *MLGetModelSnapshotsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
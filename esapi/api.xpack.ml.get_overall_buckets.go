@@ -55,6 +55,8 @@ type MLGetOverallBucketsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -156,9 +158,7 @@ func (r MLGetOverallBucketsRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.get_overall_buckets"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -301,3 +301,11 @@ func (f MLGetOverallBuckets) WithOpaqueID(s string) func(*MLGetOverallBucketsReq
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLGetOverallBuckets) WithRequestOptions(o RequestOptions) func(*MLGetOverallBucketsRequest) {
+	return func(r *MLGetOverallBucketsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -56,6 +56,8 @@ type MLGetRecordsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -161,9 +163,7 @@ func (r MLGetRecordsRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.get_records"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -314,3 +314,11 @@ func (f MLGetRecords) WithOpaqueID(s string) func(*MLGetRecordsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLGetRecords) WithRequestOptions(o RequestOptions) func(*MLGetRecordsRequest) {
+	return func(r *MLGetRecordsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
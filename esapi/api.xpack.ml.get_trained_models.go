@@ -46,6 +46,8 @@ type MLGetTrainedModelsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -129,9 +131,7 @@ func (r MLGetTrainedModelsRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.get_trained_models"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -250,3 +250,11 @@ func (f MLGetTrainedModels) WithOpaqueID(s string) func(*MLGetTrainedModelsReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLGetTrainedModels) WithRequestOptions(o RequestOptions) func(*MLGetTrainedModelsRequest) {
+	return func(r *MLGetTrainedModelsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -45,6 +45,8 @@ type MLGetTrainedModelsStatsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -126,9 +128,7 @@ func (r MLGetTrainedModelsStatsRequest) Do(ctx context.Context, transport Transp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.get_trained_models_stats"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -239,3 +239,11 @@ func (f MLGetTrainedModelsStats) WithOpaqueID(s string) func(*MLGetTrainedModels
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLGetTrainedModelsStats) WithRequestOptions(o RequestOptions) func(*MLGetTrainedModelsStatsRequest) {
+	return func(r *MLGetTrainedModelsStatsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -38,6 +38,8 @@ type MLInfoRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -98,9 +100,7 @@ func (r MLInfoRequest) Do(ctx context.Context, transport Transport) (*Response,
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.info"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -179,3 +179,11 @@ func (f MLInfo) WithOpaqueID(s string) func(*MLInfoRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLInfo) WithRequestOptions(o RequestOptions) func(*MLInfoRequest) {
+	return func(r *MLInfoRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -42,6 +42,8 @@ type MLOpenJobRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -109,9 +111,7 @@ func (r MLOpenJobRequest) Do(ctx context.Context, transport Transport) (*Respons
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.open_job"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -190,3 +190,11 @@ func (f MLOpenJob) WithOpaqueID(s string) func(*MLOpenJobRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLOpenJob) WithRequestOptions(o RequestOptions) func(*MLOpenJobRequest) {
+	return func(r *MLOpenJobRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
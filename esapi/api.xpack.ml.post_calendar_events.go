@@ -43,6 +43,8 @@ type MLPostCalendarEventsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r MLPostCalendarEventsRequest) Do(ctx context.Context, transport Transport
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.post_calendar_events"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -195,3 +195,11 @@ func (f MLPostCalendarEvents) WithOpaqueID(s string) func(*MLPostCalendarEventsR
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLPostCalendarEvents) WithRequestOptions(o RequestOptions) func(*MLPostCalendarEventsRequest) {
+	return func(r *MLPostCalendarEventsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
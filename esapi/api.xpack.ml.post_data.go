@@ -48,6 +48,8 @@ type MLPostDataRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -127,9 +129,7 @@ func (r MLPostDataRequest) Do(ctx context.Context, transport Transport) (*Respon
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.post_data"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -224,3 +224,11 @@ func (f MLPostData) WithOpaqueID(s string) func(*MLPostDataRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLPostData) WithRequestOptions(o RequestOptions) func(*MLPostDataRequest) {
+	return func(r *MLPostDataRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
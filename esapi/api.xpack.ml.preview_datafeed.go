@@ -42,6 +42,8 @@ type MLPreviewDatafeedRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -109,9 +111,7 @@ func (r MLPreviewDatafeedRequest) Do(ctx context.Context, transport Transport) (
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.preview_datafeed"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -190,3 +190,11 @@ func (f MLPreviewDatafeed) WithOpaqueID(s string) func(*MLPreviewDatafeedRequest
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLPreviewDatafeed) WithRequestOptions(o RequestOptions) func(*MLPreviewDatafeedRequest) {
+	return func(r *MLPreviewDatafeedRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -43,6 +43,8 @@ type MLPutCalendarRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -112,9 +114,7 @@ func (r MLPutCalendarRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.put_calendar"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -201,3 +201,11 @@ func (f MLPutCalendar) WithOpaqueID(s string) func(*MLPutCalendarRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLPutCalendar) WithRequestOptions(o RequestOptions) func(*MLPutCalendarRequest) {
+	return func(r *MLPutCalendarRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -41,6 +41,8 @@ type MLPutCalendarJobRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -110,9 +112,7 @@ func (r MLPutCalendarJobRequest) Do(ctx context.Context, transport Transport) (*
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.put_calendar_job"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -191,3 +191,11 @@ func (f MLPutCalendarJob) WithOpaqueID(s string) func(*MLPutCalendarJobRequest)
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLPutCalendarJob) WithRequestOptions(o RequestOptions) func(*MLPutCalendarJobRequest) {
+	return func(r *MLPutCalendarJobRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -45,6 +45,8 @@ type MLPutDataFrameAnalyticsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -116,9 +118,7 @@ func (r MLPutDataFrameAnalyticsRequest) Do(ctx context.Context, transport Transp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.put_data_frame_analytics"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -197,3 +197,11 @@ func (f MLPutDataFrameAnalytics) WithOpaqueID(s string) func(*MLPutDataFrameAnal
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLPutDataFrameAnalytics) WithRequestOptions(o RequestOptions) func(*MLPutDataFrameAnalyticsRequest) {
+	return func(r *MLPutDataFrameAnalyticsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
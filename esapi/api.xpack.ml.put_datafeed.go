@@ -45,6 +45,8 @@ type MLPutDatafeedRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r MLPutDatafeedRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.put_datafeed"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -195,3 +195,11 @@ func (f MLPutDatafeed) WithOpaqueID(s string) func(*MLPutDatafeedRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLPutDatafeed) WithRequestOptions(o RequestOptions) func(*MLPutDatafeedRequest) {
+	return func(r *MLPutDatafeedRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
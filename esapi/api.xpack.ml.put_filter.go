@@ -43,6 +43,8 @@ type MLPutFilterRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -112,9 +114,7 @@ func (r MLPutFilterRequest) Do(ctx context.Context, transport Transport) (*Respo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.put_filter"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -193,3 +193,11 @@ func (f MLPutFilter) WithOpaqueID(s string) func(*MLPutFilterRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLPutFilter) WithRequestOptions(o RequestOptions) func(*MLPutFilterRequest) {
+	return func(r *MLPutFilterRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
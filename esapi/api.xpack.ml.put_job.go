@@ -45,6 +45,8 @@ type MLPutJobRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r MLPutJobRequest) Do(ctx context.Context, transport Transport) (*Response
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.put_job"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -195,3 +195,11 @@ func (f MLPutJob) WithOpaqueID(s string) func(*MLPutJobRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLPutJob) WithRequestOptions(o RequestOptions) func(*MLPutJobRequest) {
+	return func(r *MLPutJobRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
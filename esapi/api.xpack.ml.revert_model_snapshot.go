@@ -49,6 +49,8 @@ type MLRevertModelSnapshotRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -128,9 +130,7 @@ func (r MLRevertModelSnapshotRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.revert_model_snapshot"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -225,3 +225,11 @@ func (f MLRevertModelSnapshot) WithOpaqueID(s string) func(*MLRevertModelSnapsho
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLRevertModelSnapshot) WithRequestOptions(o RequestOptions) func(*MLRevertModelSnapshotRequest) {
+	return func(r *MLRevertModelSnapshotRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
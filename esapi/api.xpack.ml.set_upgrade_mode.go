@@ -45,6 +45,8 @@ type MLSetUpgradeModeRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -113,9 +115,7 @@ func (r MLSetUpgradeModeRequest) Do(ctx context.Context, transport Transport) (*
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.set_upgrade_mode"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -210,3 +210,11 @@ func (f MLSetUpgradeMode) WithOpaqueID(s string) func(*MLSetUpgradeModeRequest)
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLSetUpgradeMode) WithRequestOptions(o RequestOptions) func(*MLSetUpgradeModeRequest) {
+	return func(r *MLSetUpgradeModeRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
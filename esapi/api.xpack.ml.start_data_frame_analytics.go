@@ -48,6 +48,8 @@ type MLStartDataFrameAnalyticsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -125,9 +127,7 @@ func (r MLStartDataFrameAnalyticsRequest) Do(ctx context.Context, transport Tran
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.start_data_frame_analytics"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -222,3 +222,11 @@ func (f MLStartDataFrameAnalytics) WithOpaqueID(s string) func(*MLStartDataFrame
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLStartDataFrameAnalytics) WithRequestOptions(o RequestOptions) func(*MLStartDataFrameAnalyticsRequest) {
+	return func(r *MLStartDataFrameAnalyticsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
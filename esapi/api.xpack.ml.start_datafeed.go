@@ -50,6 +50,8 @@ type MLStartDatafeedRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -133,9 +135,7 @@ func (r MLStartDatafeedRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.start_datafeed"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -246,3 +246,11 @@ func (f MLStartDatafeed) WithOpaqueID(s string) func(*MLStartDatafeedRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLStartDatafeed) WithRequestOptions(o RequestOptions) func(*MLStartDatafeedRequest) {
+	return func(r *MLStartDatafeedRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
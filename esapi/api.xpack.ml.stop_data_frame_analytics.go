@@ -51,6 +51,8 @@ type MLStopDataFrameAnalyticsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -136,9 +138,7 @@ func (r MLStopDataFrameAnalyticsRequest) Do(ctx context.Context, transport Trans
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.stop_data_frame_analytics"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -249,3 +249,11 @@ func (f MLStopDataFrameAnalytics) WithOpaqueID(s string) func(*MLStopDataFrameAn
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLStopDataFrameAnalytics) WithRequestOptions(o RequestOptions) func(*MLStopDataFrameAnalyticsRequest) {
+	return func(r *MLStopDataFrameAnalyticsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -48,6 +48,8 @@ type MLStopDatafeedRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -127,9 +129,7 @@ func (r MLStopDatafeedRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.stop_datafeed"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -232,3 +232,11 @@ func (f MLStopDatafeed) WithOpaqueID(s string) func(*MLStopDatafeedRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLStopDatafeed) WithRequestOptions(o RequestOptions) func(*MLStopDatafeedRequest) {
+	return func(r *MLStopDatafeedRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
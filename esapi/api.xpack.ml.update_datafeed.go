@@ -45,6 +45,8 @@ type MLUpdateDatafeedRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -116,9 +118,7 @@ func (r MLUpdateDatafeedRequest) Do(ctx context.Context, transport Transport) (*
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.update_datafeed"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -197,3 +197,11 @@ func (f MLUpdateDatafeed) WithOpaqueID(s string) func(*MLUpdateDatafeedRequest)
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLUpdateDatafeed) WithRequestOptions(o RequestOptions) func(*MLUpdateDatafeedRequest) {
+	return func(r *MLUpdateDatafeedRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -43,6 +43,8 @@ type MLUpdateFilterRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r MLUpdateFilterRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.update_filter"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -195,3 +195,11 @@ func (f MLUpdateFilter) WithOpaqueID(s string) func(*MLUpdateFilterRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLUpdateFilter) WithRequestOptions(o RequestOptions) func(*MLUpdateFilterRequest) {
+	return func(r *MLUpdateFilterRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
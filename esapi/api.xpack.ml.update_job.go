@@ -45,6 +45,8 @@ type MLUpdateJobRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -116,9 +118,7 @@ func (r MLUpdateJobRequest) Do(ctx context.Context, transport Transport) (*Respo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.update_job"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -197,3 +197,11 @@ func (f MLUpdateJob) WithOpaqueID(s string) func(*MLUpdateJobRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLUpdateJob) WithRequestOptions(o RequestOptions) func(*MLUpdateJobRequest) {
+	return func(r *MLUpdateJobRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -46,6 +46,8 @@ type MLUpdateModelSnapshotRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -121,9 +123,7 @@ func (r MLUpdateModelSnapshotRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.update_model_snapshot"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -202,3 +202,11 @@ func (f MLUpdateModelSnapshot) WithOpaqueID(s string) func(*MLUpdateModelSnapsho
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLUpdateModelSnapshot) WithRequestOptions(o RequestOptions) func(*MLUpdateModelSnapshotRequest) {
+	return func(r *MLUpdateModelSnapshotRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -41,6 +41,8 @@ type MLValidateRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -105,9 +107,7 @@ func (r MLValidateRequest) Do(ctx context.Context, transport Transport) (*Respon
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.validate"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -186,3 +186,11 @@ func (f MLValidate) WithOpaqueID(s string) func(*MLValidateRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLValidate) WithRequestOptions(o RequestOptions) func(*MLValidateRequest) {
+	return func(r *MLValidateRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
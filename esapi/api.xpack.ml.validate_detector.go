@@ -41,6 +41,8 @@ type MLValidateDetectorRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -105,9 +107,7 @@ func (r MLValidateDetectorRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ml.validate_detector"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -186,3 +186,11 @@ func (f MLValidateDetector) WithOpaqueID(s string) func(*MLValidateDetectorReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MLValidateDetector) WithRequestOptions(o RequestOptions) func(*MLValidateDetectorRequest) {
+	return func(r *MLValidateDetectorRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -49,6 +49,8 @@ type MonitoringBulkRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -132,9 +134,7 @@ func (r MonitoringBulkRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "monitoring.bulk"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -245,3 +245,11 @@ func (f MonitoringBulk) WithOpaqueID(s string) func(*MonitoringBulkRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f MonitoringBulk) WithRequestOptions(o RequestOptions) func(*MonitoringBulkRequest) {
+	return func(r *MonitoringBulkRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -40,6 +40,8 @@ type RollupDeleteJobRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -105,9 +107,7 @@ func (r RollupDeleteJobRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "rollup.delete_job"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -186,3 +186,11 @@ func (f RollupDeleteJob) WithOpaqueID(s string) func(*RollupDeleteJobRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f RollupDeleteJob) WithRequestOptions(o RequestOptions) func(*RollupDeleteJobRequest) {
+	return func(r *RollupDeleteJobRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
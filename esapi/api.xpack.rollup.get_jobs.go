@@ -40,6 +40,8 @@ type RollupGetJobsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r RollupGetJobsRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "rollup.get_jobs"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -196,3 +196,11 @@ func (f RollupGetJobs) WithOpaqueID(s string) func(*RollupGetJobsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f RollupGetJobs) WithRequestOptions(o RequestOptions) func(*RollupGetJobsRequest) {
+	return func(r *RollupGetJobsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
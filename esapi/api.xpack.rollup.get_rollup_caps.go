@@ -40,6 +40,8 @@ type RollupGetRollupCapsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r RollupGetRollupCapsRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "rollup.get_rollup_caps", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -196,3 +196,11 @@ func (f RollupGetRollupCaps) WithOpaqueID(s string) func(*RollupGetRollupCapsReq
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f RollupGetRollupCaps) WithRequestOptions(o RequestOptions) func(*RollupGetRollupCapsRequest) {
+	return func(r *RollupGetRollupCapsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
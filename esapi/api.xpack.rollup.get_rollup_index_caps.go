@@ -40,6 +40,8 @@ type RollupGetRollupIndexCapsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -105,9 +107,7 @@ func (r RollupGetRollupIndexCapsRequest) Do(ctx context.Context, transport Trans
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "rollup.get_rollup_index_caps", r.Index), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -186,3 +186,11 @@ func (f RollupGetRollupIndexCaps) WithOpaqueID(s string) func(*RollupGetRollupIn
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f RollupGetRollupIndexCaps) WithRequestOptions(o RequestOptions) func(*RollupGetRollupIndexCapsRequest) {
+	return func(r *RollupGetRollupIndexCapsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
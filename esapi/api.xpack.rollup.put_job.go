@@ -43,6 +43,8 @@ type RollupPutJobRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -112,9 +114,7 @@ func (r RollupPutJobRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "rollup.put_job"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -193,3 +193,11 @@ func (f RollupPutJob) WithOpaqueID(s string) func(*RollupPutJobRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f RollupPutJob) WithRequestOptions(o RequestOptions) func(*RollupPutJobRequest) {
+	return func(r *RollupPutJobRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
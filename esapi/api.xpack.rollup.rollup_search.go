@@ -48,6 +48,8 @@ type RollupRollupSearchRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -127,9 +129,7 @@ func (r RollupRollupSearchRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "rollup.rollup_search", r.Index...), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -232,3 +232,11 @@ func (f RollupRollupSearch) WithOpaqueID(s string) func(*RollupRollupSearchReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f RollupRollupSearch) WithRequestOptions(o RequestOptions) func(*RollupRollupSearchRequest) {
+	return func(r *RollupRollupSearchRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
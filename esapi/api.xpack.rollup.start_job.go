@@ -40,6 +40,8 @@ type RollupStartJobRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r RollupStartJobRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "rollup.start_job"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f RollupStartJob) WithOpaqueID(s string) func(*RollupStartJobRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f RollupStartJob) WithRequestOptions(o RequestOptions) func(*RollupStartJobRequest) {
+	return func(r *RollupStartJobRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
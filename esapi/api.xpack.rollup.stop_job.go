@@ -45,6 +45,8 @@ type RollupStopJobRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -120,9 +122,7 @@ func (r RollupStopJobRequest) Do(ctx context.Context, transport Transport) (*Res
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "rollup.stop_job"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -217,3 +217,11 @@ func (f RollupStopJob) WithOpaqueID(s string) func(*RollupStopJobRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f RollupStopJob) WithRequestOptions(o RequestOptions) func(*RollupStopJobRequest) {
+	return func(r *RollupStopJobRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
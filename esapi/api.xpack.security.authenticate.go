@@ -40,6 +40,8 @@ type SecurityAuthenticateRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r SecurityAuthenticateRequest) Do(ctx context.Context, transport Transport
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.authenticate"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f SecurityAuthenticate) WithOpaqueID(s string) func(*SecurityAuthenticateR
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityAuthenticate) WithRequestOptions(o RequestOptions) func(*SecurityAuthenticateRequest) {
+	return func(r *SecurityAuthenticateRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
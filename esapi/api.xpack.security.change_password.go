@@ -47,6 +47,8 @@ type SecurityChangePasswordRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -124,9 +126,7 @@ func (r SecurityChangePasswordRequest) Do(ctx context.Context, transport Transpo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.change_password"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -221,3 +221,11 @@ func (f SecurityChangePassword) WithOpaqueID(s string) func(*SecurityChangePassw
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityChangePassword) WithRequestOptions(o RequestOptions) func(*SecurityChangePasswordRequest) {
+	return func(r *SecurityChangePasswordRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
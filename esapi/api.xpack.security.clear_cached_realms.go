@@ -44,6 +44,8 @@ type SecurityClearCachedRealmsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -115,9 +117,7 @@ func (r SecurityClearCachedRealmsRequest) Do(ctx context.Context, transport Tran
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.clear_cached_realms"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -204,3 +204,11 @@ func (f SecurityClearCachedRealms) WithOpaqueID(s string) func(*SecurityClearCac
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityClearCachedRealms) WithRequestOptions(o RequestOptions) func(*SecurityClearCachedRealmsRequest) {
+	return func(r *SecurityClearCachedRealmsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -42,6 +42,8 @@ type SecurityClearCachedRolesRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -109,9 +111,7 @@ func (r SecurityClearCachedRolesRequest) Do(ctx context.Context, transport Trans
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.clear_cached_roles"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -190,3 +190,11 @@ func (f SecurityClearCachedRoles) WithOpaqueID(s string) func(*SecurityClearCach
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityClearCachedRoles) WithRequestOptions(o RequestOptions) func(*SecurityClearCachedRolesRequest) {
+	return func(r *SecurityClearCachedRolesRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
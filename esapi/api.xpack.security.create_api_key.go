@@ -45,6 +45,8 @@ type SecurityCreateAPIKeyRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -113,9 +115,7 @@ func (r SecurityCreateAPIKeyRequest) Do(ctx context.Context, transport Transport
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.create_api_key"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -202,3 +202,11 @@ func (f SecurityCreateAPIKey) WithOpaqueID(s string) func(*SecurityCreateAPIKeyR
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityCreateAPIKey) WithRequestOptions(o RequestOptions) func(*SecurityCreateAPIKeyRequest) {
+	return func(r *SecurityCreateAPIKeyRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
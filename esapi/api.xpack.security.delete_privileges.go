@@ -43,6 +43,8 @@ type SecurityDeletePrivilegesRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r SecurityDeletePrivilegesRequest) Do(ctx context.Context, transport Trans
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.delete_privileges"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -203,3 +203,11 @@ func (f SecurityDeletePrivileges) WithOpaqueID(s string) func(*SecurityDeletePri
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityDeletePrivileges) WithRequestOptions(o RequestOptions) func(*SecurityDeletePrivilegesRequest) {
+	return func(r *SecurityDeletePrivilegesRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -44,6 +44,8 @@ type SecurityDeleteRoleRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -113,9 +115,7 @@ func (r SecurityDeleteRoleRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.delete_role"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -202,3 +202,11 @@ func (f SecurityDeleteRole) WithOpaqueID(s string) func(*SecurityDeleteRoleReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityDeleteRole) WithRequestOptions(o RequestOptions) func(*SecurityDeleteRoleRequest) {
+	return func(r *SecurityDeleteRoleRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -44,6 +44,8 @@ type SecurityDeleteRoleMappingRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -113,9 +115,7 @@ func (r SecurityDeleteRoleMappingRequest) Do(ctx context.Context, transport Tran
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.delete_role_mapping"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -202,3 +202,11 @@ func (f SecurityDeleteRoleMapping) WithOpaqueID(s string) func(*SecurityDeleteRo
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityDeleteRoleMapping) WithRequestOptions(o RequestOptions) func(*SecurityDeleteRoleMappingRequest) {
+	return func(r *SecurityDeleteRoleMappingRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -44,6 +44,8 @@ type SecurityDeleteUserRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -113,9 +115,7 @@ func (r SecurityDeleteUserRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.delete_user"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -202,3 +202,11 @@ func (f SecurityDeleteUser) WithOpaqueID(s string) func(*SecurityDeleteUserReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityDeleteUser) WithRequestOptions(o RequestOptions) func(*SecurityDeleteUserRequest) {
+	return func(r *SecurityDeleteUserRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -44,6 +44,8 @@ type SecurityDisableUserRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -115,9 +117,7 @@ func (r SecurityDisableUserRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.disable_user"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -204,3 +204,11 @@ func (f SecurityDisableUser) WithOpaqueID(s string) func(*SecurityDisableUserReq
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityDisableUser) WithRequestOptions(o RequestOptions) func(*SecurityDisableUserRequest) {
+	return func(r *SecurityDisableUserRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
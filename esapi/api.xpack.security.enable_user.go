@@ -44,6 +44,8 @@ type SecurityEnableUserRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -115,9 +117,7 @@ func (r SecurityEnableUserRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.enable_user"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -204,3 +204,11 @@ func (f SecurityEnableUser) WithOpaqueID(s string) func(*SecurityEnableUserReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityEnableUser) WithRequestOptions(o RequestOptions) func(*SecurityEnableUserRequest) {
+	return func(r *SecurityEnableUserRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -47,6 +47,8 @@ type SecurityGetAPIKeyRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -127,9 +129,7 @@ func (r SecurityGetAPIKeyRequest) Do(ctx context.Context, transport Transport) (
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.get_api_key"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -248,3 +248,11 @@ func (f SecurityGetAPIKey) WithOpaqueID(s string) func(*SecurityGetAPIKeyRequest
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityGetAPIKey) WithRequestOptions(o RequestOptions) func(*SecurityGetAPIKeyRequest) {
+	return func(r *SecurityGetAPIKeyRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
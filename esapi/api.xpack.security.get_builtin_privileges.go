@@ -40,6 +40,8 @@ type SecurityGetBuiltinPrivilegesRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r SecurityGetBuiltinPrivilegesRequest) Do(ctx context.Context, transport T
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.get_builtin_privileges"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f SecurityGetBuiltinPrivileges) WithOpaqueID(s string) func(*SecurityGetBu
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityGetBuiltinPrivileges) WithRequestOptions(o RequestOptions) func(*SecurityGetBuiltinPrivilegesRequest) {
+	return func(r *SecurityGetBuiltinPrivilegesRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -43,6 +43,8 @@ type SecurityGetPrivilegesRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r SecurityGetPrivilegesRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.get_privileges"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -211,3 +211,11 @@ func (f SecurityGetPrivileges) WithOpaqueID(s string) func(*SecurityGetPrivilege
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityGetPrivileges) WithRequestOptions(o RequestOptions) func(*SecurityGetPrivilegesRequest) {
+	return func(r *SecurityGetPrivilegesRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -42,6 +42,8 @@ type SecurityGetRoleRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -109,9 +111,7 @@ func (r SecurityGetRoleRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.get_role"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -198,3 +198,11 @@ func (f SecurityGetRole) WithOpaqueID(s string) func(*SecurityGetRoleRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityGetRole) WithRequestOptions(o RequestOptions) func(*SecurityGetRoleRequest) {
+	return func(r *SecurityGetRoleRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
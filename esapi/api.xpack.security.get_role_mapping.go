@@ -42,6 +42,8 @@ type SecurityGetRoleMappingRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -109,9 +111,7 @@ func (r SecurityGetRoleMappingRequest) Do(ctx context.Context, transport Transpo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.get_role_mapping"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -198,3 +198,11 @@ func (f SecurityGetRoleMapping) WithOpaqueID(s string) func(*SecurityGetRoleMapp
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityGetRoleMapping) WithRequestOptions(o RequestOptions) func(*SecurityGetRoleMappingRequest) {
+	return func(r *SecurityGetRoleMappingRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
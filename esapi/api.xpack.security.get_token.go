@@ -43,6 +43,8 @@ type SecurityGetTokenRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r SecurityGetTokenRequest) Do(ctx context.Context, transport Transport) (*
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.get_token"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f SecurityGetToken) WithOpaqueID(s string) func(*SecurityGetTokenRequest)
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityGetToken) WithRequestOptions(o RequestOptions) func(*SecurityGetTokenRequest) {
+	return func(r *SecurityGetTokenRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
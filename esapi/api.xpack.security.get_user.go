@@ -42,6 +42,8 @@ type SecurityGetUserRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -109,9 +111,7 @@ func (r SecurityGetUserRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.get_user"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -198,3 +198,11 @@ func (f SecurityGetUser) WithOpaqueID(s string) func(*SecurityGetUserRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityGetUser) WithRequestOptions(o RequestOptions) func(*SecurityGetUserRequest) {
+	return func(r *SecurityGetUserRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -40,6 +40,8 @@ type SecurityGetUserPrivilegesRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r SecurityGetUserPrivilegesRequest) Do(ctx context.Context, transport Tran
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.get_user_privileges"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f SecurityGetUserPrivileges) WithOpaqueID(s string) func(*SecurityGetUserP
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityGetUserPrivileges) WithRequestOptions(o RequestOptions) func(*SecurityGetUserPrivilegesRequest) {
+	return func(r *SecurityGetUserPrivilegesRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
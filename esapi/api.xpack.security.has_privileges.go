@@ -45,6 +45,8 @@ type SecurityHasPrivilegesRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -118,9 +120,7 @@ func (r SecurityHasPrivilegesRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.has_privileges"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -207,3 +207,11 @@ func (f SecurityHasPrivileges) WithOpaqueID(s string) func(*SecurityHasPrivilege
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityHasPrivileges) WithRequestOptions(o RequestOptions) func(*SecurityHasPrivilegesRequest) {
+	return func(r *SecurityHasPrivilegesRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
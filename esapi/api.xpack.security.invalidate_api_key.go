@@ -43,6 +43,8 @@ type SecurityInvalidateAPIKeyRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r SecurityInvalidateAPIKeyRequest) Do(ctx context.Context, transport Trans
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.invalidate_api_key"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f SecurityInvalidateAPIKey) WithOpaqueID(s string) func(*SecurityInvalidat
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityInvalidateAPIKey) WithRequestOptions(o RequestOptions) func(*SecurityInvalidateAPIKeyRequest) {
+	return func(r *SecurityInvalidateAPIKeyRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
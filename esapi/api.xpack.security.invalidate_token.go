@@ -43,6 +43,8 @@ type SecurityInvalidateTokenRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r SecurityInvalidateTokenRequest) Do(ctx context.Context, transport Transp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.invalidate_token"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f SecurityInvalidateToken) WithOpaqueID(s string) func(*SecurityInvalidate
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityInvalidateToken) WithRequestOptions(o RequestOptions) func(*SecurityInvalidateTokenRequest) {
+	return func(r *SecurityInvalidateTokenRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
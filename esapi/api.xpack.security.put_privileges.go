@@ -43,6 +43,8 @@ type SecurityPutPrivilegesRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -111,9 +113,7 @@ func (r SecurityPutPrivilegesRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.put_privileges"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -200,3 +200,11 @@ func (f SecurityPutPrivileges) WithOpaqueID(s string) func(*SecurityPutPrivilege
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityPutPrivileges) WithRequestOptions(o RequestOptions) func(*SecurityPutPrivilegesRequest) {
+	return func(r *SecurityPutPrivilegesRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -47,6 +47,8 @@ type SecurityPutRoleRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -120,9 +122,7 @@ func (r SecurityPutRoleRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.put_role"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -209,3 +209,11 @@ func (f SecurityPutRole) WithOpaqueID(s string) func(*SecurityPutRoleRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityPutRole) WithRequestOptions(o RequestOptions) func(*SecurityPutRoleRequest) {
+	return func(r *SecurityPutRoleRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -47,6 +47,8 @@ type SecurityPutRoleMappingRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -120,9 +122,7 @@ func (r SecurityPutRoleMappingRequest) Do(ctx context.Context, transport Transpo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.put_role_mapping"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -209,3 +209,11 @@ func (f SecurityPutRoleMapping) WithOpaqueID(s string) func(*SecurityPutRoleMapp
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityPutRoleMapping) WithRequestOptions(o RequestOptions) func(*SecurityPutRoleMappingRequest) {
+	return func(r *SecurityPutRoleMappingRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -47,6 +47,8 @@ type SecurityPutUserRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -120,9 +122,7 @@ func (r SecurityPutUserRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "security.put_user"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -209,3 +209,11 @@ func (f SecurityPutUser) WithOpaqueID(s string) func(*SecurityPutUserRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SecurityPutUser) WithRequestOptions(o RequestOptions) func(*SecurityPutUserRequest) {
+	return func(r *SecurityPutUserRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -42,6 +42,8 @@ type SlmDeleteLifecycleRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r SlmDeleteLifecycleRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "slm.delete_lifecycle"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f SlmDeleteLifecycle) WithOpaqueID(s string) func(*SlmDeleteLifecycleReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SlmDeleteLifecycle) WithRequestOptions(o RequestOptions) func(*SlmDeleteLifecycleRequest) {
+	return func(r *SlmDeleteLifecycleRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -42,6 +42,8 @@ type SlmExecuteLifecycleRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -109,9 +111,7 @@ func (r SlmExecuteLifecycleRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "slm.execute_lifecycle"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -190,3 +190,11 @@ func (f SlmExecuteLifecycle) WithOpaqueID(s string) func(*SlmExecuteLifecycleReq
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SlmExecuteLifecycle) WithRequestOptions(o RequestOptions) func(*SlmExecuteLifecycleRequest) {
+	return func(r *SlmExecuteLifecycleRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -40,6 +40,8 @@ type SlmExecuteRetentionRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r SlmExecuteRetentionRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "slm.execute_retention"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f SlmExecuteRetention) WithOpaqueID(s string) func(*SlmExecuteRetentionReq
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SlmExecuteRetention) WithRequestOptions(o RequestOptions) func(*SlmExecuteRetentionRequest) {
+	return func(r *SlmExecuteRetentionRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -40,6 +40,8 @@ type SlmGetStatsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r SlmGetStatsRequest) Do(ctx context.Context, transport Transport) (*Respo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "slm.get_stats"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f SlmGetStats) WithOpaqueID(s string) func(*SlmGetStatsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SlmGetStats) WithRequestOptions(o RequestOptions) func(*SlmGetStatsRequest) {
+	return func(r *SlmGetStatsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -40,6 +40,8 @@ type SlmGetStatusRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r SlmGetStatusRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "slm.get_status"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f SlmGetStatus) WithOpaqueID(s string) func(*SlmGetStatusRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SlmGetStatus) WithRequestOptions(o RequestOptions) func(*SlmGetStatusRequest) {
+	return func(r *SlmGetStatusRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
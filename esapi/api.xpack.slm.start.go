@@ -40,6 +40,8 @@ type SlmStartRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r SlmStartRequest) Do(ctx context.Context, transport Transport) (*Response
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "slm.start"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f SlmStart) WithOpaqueID(s string) func(*SlmStartRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SlmStart) WithRequestOptions(o RequestOptions) func(*SlmStartRequest) {
+	return func(r *SlmStartRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -40,6 +40,8 @@ type SlmStopRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r SlmStopRequest) Do(ctx context.Context, transport Transport) (*Response,
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "slm.stop"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f SlmStop) WithOpaqueID(s string) func(*SlmStopRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SlmStop) WithRequestOptions(o RequestOptions) func(*SlmStopRequest) {
+	return func(r *SlmStopRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -41,6 +41,8 @@ type SQLClearCursorRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -105,9 +107,7 @@ func (r SQLClearCursorRequest) Do(ctx context.Context, transport Transport) (*Re
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "sql.clear_cursor"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -186,3 +186,11 @@ func (f SQLClearCursor) WithOpaqueID(s string) func(*SQLClearCursorRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SQLClearCursor) WithRequestOptions(o RequestOptions) func(*SQLClearCursorRequest) {
+	return func(r *SQLClearCursorRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -43,6 +43,8 @@ type SQLQueryRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -111,9 +113,7 @@ func (r SQLQueryRequest) Do(ctx context.Context, transport Transport) (*Response
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "sql.query"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -200,3 +200,11 @@ func (f SQLQuery) WithOpaqueID(s string) func(*SQLQueryRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SQLQuery) WithRequestOptions(o RequestOptions) func(*SQLQueryRequest) {
+	return func(r *SQLQueryRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -41,6 +41,8 @@ type SQLTranslateRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -105,9 +107,7 @@ func (r SQLTranslateRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "sql.translate"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -186,3 +186,11 @@ func (f SQLTranslate) WithOpaqueID(s string) func(*SQLTranslateRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SQLTranslate) WithRequestOptions(o RequestOptions) func(*SQLTranslateRequest) {
+	return func(r *SQLTranslateRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -40,6 +40,8 @@ type SSLCertificatesRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r SSLCertificatesRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "ssl.certificates"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f SSLCertificates) WithOpaqueID(s string) func(*SSLCertificatesRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f SSLCertificates) WithRequestOptions(o RequestOptions) func(*SSLCertificatesRequest) {
+	return func(r *SSLCertificatesRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
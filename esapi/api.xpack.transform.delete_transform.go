@@ -45,6 +45,8 @@ type TransformDeleteTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -112,9 +114,7 @@ func (r TransformDeleteTransformRequest) Do(ctx context.Context, transport Trans
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "transform.delete_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -201,3 +201,11 @@ func (f TransformDeleteTransform) WithOpaqueID(s string) func(*TransformDeleteTr
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f TransformDeleteTransform) WithRequestOptions(o RequestOptions) func(*TransformDeleteTransformRequest) {
+	return func(r *TransformDeleteTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -47,6 +47,8 @@ type TransformGetTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -124,9 +126,7 @@ func (r TransformGetTransformRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "transform.get_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -237,3 +237,11 @@ func (f TransformGetTransform) WithOpaqueID(s string) func(*TransformGetTransfor
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f TransformGetTransform) WithRequestOptions(o RequestOptions) func(*TransformGetTransformRequest) {
+	return func(r *TransformGetTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -47,6 +47,8 @@ type TransformGetTransformStatsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -124,9 +126,7 @@ func (r TransformGetTransformStatsRequest) Do(ctx context.Context, transport Tra
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "transform.get_transform_stats"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -229,3 +229,11 @@ func (f TransformGetTransformStats) WithOpaqueID(s string) func(*TransformGetTra
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f TransformGetTransformStats) WithRequestOptions(o RequestOptions) func(*TransformGetTransformStatsRequest) {
+	return func(r *TransformGetTransformStatsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
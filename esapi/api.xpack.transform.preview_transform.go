@@ -43,6 +43,8 @@ type TransformPreviewTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r TransformPreviewTransformRequest) Do(ctx context.Context, transport Tran
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "transform.preview_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f TransformPreviewTransform) WithOpaqueID(s string) func(*TransformPreview
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f TransformPreviewTransform) WithRequestOptions(o RequestOptions) func(*TransformPreviewTransformRequest) {
+	return func(r *TransformPreviewTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
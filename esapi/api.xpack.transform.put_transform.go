@@ -48,6 +48,8 @@ type TransformPutTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -119,9 +121,7 @@ func (r TransformPutTransformRequest) Do(ctx context.Context, transport Transpor
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "transform.put_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -208,3 +208,11 @@ func (f TransformPutTransform) WithOpaqueID(s string) func(*TransformPutTransfor
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f TransformPutTransform) WithRequestOptions(o RequestOptions) func(*TransformPutTransformRequest) {
+	return func(r *TransformPutTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
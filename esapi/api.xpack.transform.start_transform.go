@@ -45,6 +45,8 @@ type TransformStartTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r TransformStartTransformRequest) Do(ctx context.Context, transport Transp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "transform.start_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -203,3 +203,11 @@ func (f TransformStartTransform) WithOpaqueID(s string) func(*TransformStartTran
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f TransformStartTransform) WithRequestOptions(o RequestOptions) func(*TransformStartTransformRequest) {
+	return func(r *TransformStartTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
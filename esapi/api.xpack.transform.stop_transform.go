@@ -50,6 +50,8 @@ type TransformStopTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -135,9 +137,7 @@ func (r TransformStopTransformRequest) Do(ctx context.Context, transport Transpo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "transform.stop_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -256,3 +256,11 @@ func (f TransformStopTransform) WithOpaqueID(s string) func(*TransformStopTransf
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f TransformStopTransform) WithRequestOptions(o RequestOptions) func(*TransformStopTransformRequest) {
+	return func(r *TransformStopTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -48,6 +48,8 @@ type TransformUpdateTransformRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -121,9 +123,7 @@ func (r TransformUpdateTransformRequest) Do(ctx context.Context, transport Trans
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "transform.update_transform"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -210,3 +210,11 @@ func (f TransformUpdateTransform) WithOpaqueID(s string) func(*TransformUpdateTr
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f TransformUpdateTransform) WithRequestOptions(o RequestOptions) func(*TransformUpdateTransformRequest) {
+	return func(r *TransformUpdateTransformRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
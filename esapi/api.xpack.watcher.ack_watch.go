@@ -43,6 +43,8 @@ type WatcherAckWatchRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -114,9 +116,7 @@ func (r WatcherAckWatchRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "watcher.ack_watch"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -203,3 +203,11 @@ func (f WatcherAckWatch) WithOpaqueID(s string) func(*WatcherAckWatchRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f WatcherAckWatch) WithRequestOptions(o RequestOptions) func(*WatcherAckWatchRequest) {
+	return func(r *WatcherAckWatchRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
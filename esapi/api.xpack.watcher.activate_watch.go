@@ -42,6 +42,8 @@ type WatcherActivateWatchRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -109,9 +111,7 @@ func (r WatcherActivateWatchRequest) Do(ctx context.Context, transport Transport
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "watcher.activate_watch"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -190,3 +190,11 @@ func (f WatcherActivateWatch) WithOpaqueID(s string) func(*WatcherActivateWatchR
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f WatcherActivateWatch) WithRequestOptions(o RequestOptions) func(*WatcherActivateWatchRequest) {
+	return func(r *WatcherActivateWatchRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
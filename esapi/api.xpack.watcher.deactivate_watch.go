@@ -42,6 +42,8 @@ type WatcherDeactivateWatchRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -109,9 +111,7 @@ func (r WatcherDeactivateWatchRequest) Do(ctx context.Context, transport Transpo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "watcher.deactivate_watch"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -190,3 +190,11 @@ func (f WatcherDeactivateWatch) WithOpaqueID(s string) func(*WatcherDeactivateWa
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f WatcherDeactivateWatch) WithRequestOptions(o RequestOptions) func(*WatcherDeactivateWatchRequest) {
+	return func(r *WatcherDeactivateWatchRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
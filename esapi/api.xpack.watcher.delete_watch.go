@@ -42,6 +42,8 @@ type WatcherDeleteWatchRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r WatcherDeleteWatchRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "watcher.delete_watch"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f WatcherDeleteWatch) WithOpaqueID(s string) func(*WatcherDeleteWatchReque
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f WatcherDeleteWatch) WithRequestOptions(o RequestOptions) func(*WatcherDeleteWatchRequest) {
+	return func(r *WatcherDeleteWatchRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
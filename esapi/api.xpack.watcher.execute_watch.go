@@ -48,6 +48,8 @@ type WatcherExecuteWatchRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -125,9 +127,7 @@ func (r WatcherExecuteWatchRequest) Do(ctx context.Context, transport Transport)
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "watcher.execute_watch"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -230,3 +230,11 @@ func (f WatcherExecuteWatch) WithOpaqueID(s string) func(*WatcherExecuteWatchReq
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f WatcherExecuteWatch) WithRequestOptions(o RequestOptions) func(*WatcherExecuteWatchRequest) {
+	return func(r *WatcherExecuteWatchRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
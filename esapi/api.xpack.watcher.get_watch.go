@@ -42,6 +42,8 @@ type WatcherGetWatchRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -107,9 +109,7 @@ func (r WatcherGetWatchRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "watcher.get_watch"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -188,3 +188,11 @@ func (f WatcherGetWatch) WithOpaqueID(s string) func(*WatcherGetWatchRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f WatcherGetWatch) WithRequestOptions(o RequestOptions) func(*WatcherGetWatchRequest) {
+	return func(r *WatcherGetWatchRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -51,6 +51,8 @@ type WatcherPutWatchRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -136,9 +138,7 @@ func (r WatcherPutWatchRequest) Do(ctx context.Context, transport Transport) (*R
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "watcher.put_watch"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -257,3 +257,11 @@ func (f WatcherPutWatch) WithOpaqueID(s string) func(*WatcherPutWatchRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f WatcherPutWatch) WithRequestOptions(o RequestOptions) func(*WatcherPutWatchRequest) {
+	return func(r *WatcherPutWatchRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -40,6 +40,8 @@ type WatcherStartRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r WatcherStartRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "watcher.start"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f WatcherStart) WithOpaqueID(s string) func(*WatcherStartRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f WatcherStart) WithRequestOptions(o RequestOptions) func(*WatcherStartRequest) {
+	return func(r *WatcherStartRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -45,6 +45,8 @@ type WatcherStatsRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -120,9 +122,7 @@ func (r WatcherStatsRequest) Do(ctx context.Context, transport Transport) (*Resp
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "watcher.stats"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -217,3 +217,11 @@ func (f WatcherStats) WithOpaqueID(s string) func(*WatcherStatsRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f WatcherStats) WithRequestOptions(o RequestOptions) func(*WatcherStatsRequest) {
+	return func(r *WatcherStatsRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -40,6 +40,8 @@ type WatcherStopRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -100,9 +102,7 @@ func (r WatcherStopRequest) Do(ctx context.Context, transport Transport) (*Respo
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "watcher.stop"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -181,3 +181,11 @@ func (f WatcherStop) WithOpaqueID(s string) func(*WatcherStopRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f WatcherStop) WithRequestOptions(o RequestOptions) func(*WatcherStopRequest) {
+	return func(r *WatcherStopRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
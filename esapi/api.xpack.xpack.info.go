@@ -42,6 +42,8 @@ type XPackInfoRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -106,9 +108,7 @@ func (r XPackInfoRequest) Do(ctx context.Context, transport Transport) (*Respons
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "xpack.info"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -195,3 +195,11 @@ func (f XPackInfo) WithOpaqueID(s string) func(*XPackInfoRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f XPackInfo) WithRequestOptions(o RequestOptions) func(*XPackInfoRequest) {
+	return func(r *XPackInfoRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
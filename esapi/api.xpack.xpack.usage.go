@@ -41,6 +41,8 @@ type XPackUsageRequest struct {
 
 	Header http.Header
 
+	opts *RequestOptions
+
 	ctx context.Context
 }
 
@@ -105,9 +107,7 @@ func (r XPackUsageRequest) Do(ctx context.Context, transport Transport) (*Respon
 		}
 	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
+	req = req.WithContext(withRequestOptions(withEndpoint(ctx, "xpack.usage"), r.opts))
 
 	res, err := transport.Perform(req)
 	if err != nil {
@@ -194,3 +194,11 @@ func (f XPackUsage) WithOpaqueID(s string) func(*XPackUsageRequest) {
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f XPackUsage) WithRequestOptions(o RequestOptions) func(*XPackUsageRequest) {
+	return func(r *XPackUsageRequest) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
@@ -55,6 +55,13 @@ to configure and perform the request in a more expressive way.
 It has a minor overhead compared to using a struct directly;
 refer to the esapi_benchmark_test.go suite for concrete numbers.
 
+Use the WithRequestOptions method of any API function to override the transport configuration
+for a single request, eg. to disable the retries or to set a timeout:
+
+	res, err := es.Update("test", "1", body,
+		es.Update.WithRequestOptions(esapi.RequestOptions{DisableRetry: true, Timeout: 5 * time.Second}),
+	)
+
 See the documentation for each API function or struct at
 https://godoc.org/github.com/elastic/go-elasticsearch,
 or locally by:
@@ -76,6 +83,46 @@ The Response type implements a couple of convenience methods for accessing
 the status, checking an error status code or printing
 the response body for debugging purposes.
 
+The Err() method parses an error response into the ResponseError type,
+with the error type, reason, root cause and shard failures;
+use the IsNotFound(), IsVersionConflict(), IsIndexAlreadyExists()
+and IsTooManyRequests() functions to check for specific errors:
+
+	if err := res.Err(); esapi.IsNotFound(err) {
+		log.Println("Document not found")
+	}
+
+The DecodeJSON() method decodes the response body into a value, and closes the body.
+The package provides types for the responses of the core APIs, such as SearchResponse,
+BulkResponse, GetResponse, MgetResponse, IndexResponse, UpdateResponse, DeleteResponse,
+CountResponse, ClusterHealthResponse, and CatResponse for the Cat APIs requested in the JSON format;
+the fields which depend on the request, such as the document source or the aggregations,
+are left as raw JSON:
+
+	if err := res.Err(); err != nil {
+		log.Fatalf("Error: %s", err)
+	}
+
+	var r esapi.SearchResponse
+	if err := res.DecodeJSON(&r); err != nil {
+		log.Fatalf("Error parsing the response body: %s", err)
+	}
+
+	for _, hit := range r.Hits.Hits {
+		log.Printf("* ID=%s, %s", hit.ID, hit.Source)
+	}
+
+The APIs with well-known request bodies, such as Reindex, IndicesCreate, IndicesPutSettings,
+IndicesPutMapping, IndicesUpdateAliases, SnapshotCreate, SnapshotRestore and ClusterReroute,
+provide a typed body struct and the WithBodyStruct() option, which encodes it into JSON;
+when the body is a required argument, pass nil and the option:
+
+	res, err := es.Reindex(nil, es.Reindex.WithBodyStruct(&esapi.ReindexBody{
+		Source: esapi.ReindexSource{Index: []string{"source"}},
+		Dest:   esapi.ReindexDest{Index: "dest"},
+		Script: &esapi.Script{Source: "ctx._source.count++"},
+	}))
+
 Additional Information
 
 See the Elasticsearch documentation at
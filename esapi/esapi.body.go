@@ -0,0 +1,78 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package esapi
+
+import (
+	"io"
+
+	"github.com/elastic/go-elasticsearch/v8/internal/jsonreader"
+)
+
+// Script represents a script in the request body, eg. for the Reindex API.
+//
+// Set either the Source, for an inline script, or the ID, for a stored script.
+//
+type Script struct {
+	Source string                 `json:"source,omitempty"`
+	ID     string                 `json:"id,omitempty"`
+	Lang   string                 `json:"lang,omitempty"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// IndexSettings represents the commonly used index settings.
+//
+// The Analysis field is encoded as is, eg. from a map or a JSON string wrapped in json.RawMessage.
+//
+type IndexSettings struct {
+	NumberOfShards   int         `json:"number_of_shards,omitempty"`
+	NumberOfReplicas *int        `json:"number_of_replicas,omitempty"`
+	RefreshInterval  string      `json:"refresh_interval,omitempty"`
+	MaxResultWindow  int         `json:"max_result_window,omitempty"`
+	DefaultPipeline  string      `json:"default_pipeline,omitempty"`
+	Analysis         interface{} `json:"analysis,omitempty"`
+}
+
+// TypeMapping represents the mappings of an index.
+//
+// The Dynamic field is either a boolean, or one of "true", "false", "strict" and "runtime".
+//
+type TypeMapping struct {
+	Dynamic    interface{}                `json:"dynamic,omitempty"`
+	Properties map[string]MappingProperty `json:"properties,omitempty"`
+}
+
+// MappingProperty represents a field in the mappings.
+//
+type MappingProperty struct {
+	Type           string                     `json:"type,omitempty"`
+	Index          *bool                      `json:"index,omitempty"`
+	Analyzer       string                     `json:"analyzer,omitempty"`
+	SearchAnalyzer string                     `json:"search_analyzer,omitempty"`
+	Format         string                     `json:"format,omitempty"`
+	IgnoreAbove    int                        `json:"ignore_above,omitempty"`
+	CopyTo         []string                   `json:"copy_to,omitempty"`
+	Fields         map[string]MappingProperty `json:"fields,omitempty"`
+	Properties     map[string]MappingProperty `json:"properties,omitempty"`
+}
+
+// IndexAlias represents an alias in the Indices Create API request body.
+//
+type IndexAlias struct {
+	Filter        interface{} `json:"filter,omitempty"`
+	Routing       string      `json:"routing,omitempty"`
+	IndexRouting  string      `json:"index_routing,omitempty"`
+	SearchRouting string      `json:"search_routing,omitempty"`
+	IsWriteIndex  *bool       `json:"is_write_index,omitempty"`
+	IsHidden      *bool       `json:"is_hidden,omitempty"`
+}
+
+// newJSONReader encodes v into JSON when the returned reader is first read.
+//
+// The reader is shared with esutil.NewJSONReader through an internal package,
+// because the esutil package depends on this package.
+//
+func newJSONReader(v interface{}) io.Reader {
+	return jsonreader.New(v)
+}
@@ -0,0 +1,168 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package esapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ResponseError represents an error returned by Elasticsearch.
+//
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/common-options.html#common-options-error-options
+//
+type ResponseError struct {
+	Status       int
+	Type         string
+	Reason       string
+	RootCause    []ErrorCause
+	CausedBy     *ErrorCause
+	FailedShards []ShardFailure
+
+	// Body contains the raw response body.
+	Body []byte
+}
+
+// ErrorCause represents the cause of an error.
+//
+type ErrorCause struct {
+	Type     string      `json:"type"`
+	Reason   string      `json:"reason"`
+	Index    string      `json:"index,omitempty"`
+	CausedBy *ErrorCause `json:"caused_by,omitempty"`
+}
+
+// ShardFailure represents a failure of a single shard.
+//
+type ShardFailure struct {
+	Shard  int        `json:"shard"`
+	Index  string     `json:"index"`
+	Node   string     `json:"node"`
+	Reason ErrorCause `json:"reason"`
+}
+
+// Error returns the error as a string.
+//
+func (e *ResponseError) Error() string {
+	var b strings.Builder
+	b.WriteString("[")
+	b.WriteString(strconv.Itoa(e.Status))
+	b.WriteString(" ")
+	b.WriteString(http.StatusText(e.Status))
+	b.WriteString("]")
+	if e.Type != "" {
+		b.WriteString(" ")
+		b.WriteString(e.Type)
+		b.WriteString(":")
+	}
+	if e.Reason != "" {
+		b.WriteString(" ")
+		b.WriteString(e.Reason)
+	}
+	if e.CausedBy != nil && e.CausedBy.Reason != "" {
+		b.WriteString(" (caused by: ")
+		b.WriteString(e.CausedBy.Type)
+		b.WriteString(": ")
+		b.WriteString(e.CausedBy.Reason)
+		b.WriteString(")")
+	}
+	return b.String()
+}
+
+// newResponseError creates a new ResponseError from status code and response body.
+//
+// The body is expected to contain the error in the following format:
+//
+//     {"error":{"type":"...","reason":"...","root_cause":[...]},"status":400}
+//
+// When the body is empty or cannot be parsed, the Reason is set to the status text.
+//
+func newResponseError(status int, body []byte) *ResponseError {
+	e := ResponseError{Status: status, Body: body}
+
+	var env struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &env); err != nil || len(env.Error) == 0 {
+		e.Reason = http.StatusText(status)
+		return &e
+	}
+
+	// Some APIs return the error as a plain string
+	var reason string
+	if err := json.Unmarshal(env.Error, &reason); err == nil {
+		e.Reason = reason
+		return &e
+	}
+
+	var info struct {
+		Type         string         `json:"type"`
+		Reason       string         `json:"reason"`
+		RootCause    []ErrorCause   `json:"root_cause"`
+		CausedBy     *ErrorCause    `json:"caused_by"`
+		FailedShards []ShardFailure `json:"failed_shards"`
+	}
+	if err := json.Unmarshal(env.Error, &info); err != nil {
+		e.Reason = http.StatusText(status)
+		return &e
+	}
+
+	e.Type = info.Type
+	e.Reason = info.Reason
+	e.RootCause = info.RootCause
+	e.CausedBy = info.CausedBy
+	e.FailedShards = info.FailedShards
+
+	return &e
+}
+
+// IsNotFound returns true when err is a ResponseError for a missing resource,
+// such as a missing index or document.
+//
+func IsNotFound(err error) bool {
+	e, ok := asResponseError(err)
+	return ok && e.Status == http.StatusNotFound
+}
+
+// IsVersionConflict returns true when err is a ResponseError for a version conflict.
+//
+func IsVersionConflict(err error) bool {
+	e, ok := asResponseError(err)
+	return ok && (e.Status == http.StatusConflict || e.Type == "version_conflict_engine_exception")
+}
+
+// IsIndexAlreadyExists returns true when err is a ResponseError for an index
+// which already exists.
+//
+func IsIndexAlreadyExists(err error) bool {
+	e, ok := asResponseError(err)
+	return ok && (e.Type == "resource_already_exists_exception" || e.Type == "index_already_exists_exception")
+}
+
+// IsTooManyRequests returns true when err is a ResponseError for a rejected request,
+// such as when a thread pool queue is full or a circuit breaker was tripped.
+//
+func IsTooManyRequests(err error) bool {
+	e, ok := asResponseError(err)
+	return ok && e.Status == http.StatusTooManyRequests
+}
+
+// asResponseError returns the ResponseError from err, unwrapping it when necessary.
+//
+func asResponseError(err error) (*ResponseError, bool) {
+	for err != nil {
+		if e, ok := err.(*ResponseError); ok {
+			return e, true
+		}
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return nil, false
+		}
+		err = u.Unwrap()
+	}
+	return nil, false
+}
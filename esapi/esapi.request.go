@@ -8,6 +8,9 @@ import (
 	"context"
 	"io"
 	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v8/internal/endpoint"
+	"github.com/elastic/go-elasticsearch/v8/internal/options"
 )
 
 const (
@@ -18,6 +21,15 @@ var (
 	headerContentTypeJSON = []string{"application/json"}
 )
 
+// RequestOptions represents the per-request overrides of the transport configuration;
+// see estransport.RequestOptions for the details.
+//
+// Use the WithRequestOptions method of any API to set the options for the request:
+//
+//     es.Update("test", "1", body, es.Update.WithRequestOptions(esapi.RequestOptions{DisableRetry: true}))
+//
+type RequestOptions = options.Request
+
 // Request defines the API request.
 //
 type Request interface {
@@ -29,3 +41,34 @@ type Request interface {
 func newRequest(method, path string, body io.Reader) (*http.Request, error) {
 	return http.NewRequest(method, path, body)
 }
+
+// withEndpoint returns a copy of ctx with the endpoint name and index,
+// to make them available to the transport instrumentation.
+//
+func withEndpoint(ctx context.Context, name string, index ...string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if len(index) == 1 && index[0] == "" {
+		index = nil
+	}
+	return endpoint.WithInfo(ctx, endpoint.Info{Name: name, Index: index})
+}
+
+// withRequestOptions returns a copy of ctx with the request options, if any.
+//
+func withRequestOptions(ctx context.Context, o *RequestOptions) context.Context {
+	if o == nil {
+		return ctx
+	}
+	return options.WithRequest(ctx, *o)
+}
+
+// mergeRequestOptions returns the request options merged with the previous ones.
+//
+func mergeRequestOptions(prev *RequestOptions, o RequestOptions) *RequestOptions {
+	if prev != nil {
+		o = prev.Merge(o)
+	}
+	return &o
+}
@@ -6,6 +6,8 @@ package esapi
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -78,3 +80,52 @@ func (r *Response) Status() string {
 func (r *Response) IsError() bool {
 	return r.StatusCode > 299
 }
+
+// DecodeJSON decodes the JSON response body into v, and closes the body.
+//
+// The body is decoded regardless of the response status; use the Err() method
+// to check for an error response first:
+//
+//     if err := res.Err(); err != nil {
+//         return err
+//     }
+//     var r esapi.SearchResponse
+//     if err := res.DecodeJSON(&r); err != nil {
+//         return err
+//     }
+//
+func (r *Response) DecodeJSON(v interface{}) error {
+	if r == nil || r.Body == nil {
+		return errors.New("cannot decode response: empty body")
+	}
+	defer r.Body.Close()
+
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return fmt.Errorf("cannot decode response: %s", err)
+	}
+	return nil
+}
+
+// Err returns a *ResponseError when the response status indicates failure, or nil.
+//
+// The response body is read and parsed, and replaced with a copy,
+// so it can be read again by the calling code.
+//
+func (r *Response) Err() error {
+	if r == nil || !r.IsError() {
+		return nil
+	}
+
+	var body []byte
+	if r.Body != nil {
+		b, err := ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(b))
+		if err != nil {
+			return &ResponseError{Status: r.StatusCode, Reason: fmt.Sprintf("error reading response body: %s", err)}
+		}
+		body = b
+	}
+
+	return newResponseError(r.StatusCode, body)
+}
@@ -6,8 +6,10 @@ package esapi_test
 
 import (
 	"log"
+	"strings"
 
 	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
 )
 
 func ExampleResponse_IsError() {
@@ -54,3 +56,26 @@ func ExampleResponse_String() {
 	// ...
 	// }
 }
+
+func ExampleResponse_Err() {
+	es, _ := elasticsearch.NewDefaultClient()
+
+	res, err := es.Indices.Create("test", es.Indices.Create.WithBody(strings.NewReader(`{}`)))
+	if err != nil {
+		log.Fatalf("ERROR: %v", err)
+	}
+	defer res.Body.Close()
+
+	// Handle error response by type
+	//
+	if err := res.Err(); err != nil {
+		switch {
+		case esapi.IsIndexAlreadyExists(err):
+			log.Println("Index already exists")
+		case esapi.IsTooManyRequests(err):
+			log.Println("Rejected, retry later")
+		default:
+			log.Fatalf("ERROR: %s", err)
+		}
+	}
+}
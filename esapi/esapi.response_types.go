@@ -0,0 +1,245 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package esapi
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// ShardsInfo represents the shard statistics of a response.
+//
+type ShardsInfo struct {
+	Total      int            `json:"total"`
+	Successful int            `json:"successful"`
+	Skipped    int            `json:"skipped,omitempty"`
+	Failed     int            `json:"failed"`
+	Failures   []ShardFailure `json:"failures,omitempty"`
+}
+
+// SearchResponse represents the response of the Search and Scroll APIs.
+//
+// The aggregations are left as raw JSON, to be decoded by the calling code.
+//
+type SearchResponse struct {
+	Took         int             `json:"took"`
+	TimedOut     bool            `json:"timed_out"`
+	Shards       ShardsInfo      `json:"_shards"`
+	Hits         SearchHits      `json:"hits"`
+	Aggregations json.RawMessage `json:"aggregations,omitempty"`
+	ScrollID     string          `json:"_scroll_id,omitempty"`
+	PitID        string          `json:"pit_id,omitempty"`
+}
+
+// SearchHits represents the hits of a search response.
+//
+type SearchHits struct {
+	Total    TotalHits   `json:"total"`
+	MaxScore *float64    `json:"max_score"`
+	Hits     []SearchHit `json:"hits"`
+}
+
+// TotalHits represents the total number of hits.
+//
+// The Relation is either "eq" for an exact number, or "gte" for a lower bound.
+//
+type TotalHits struct {
+	Value    int64  `json:"value"`
+	Relation string `json:"relation"`
+}
+
+// UnmarshalJSON decodes the total hits either from an object,
+// or from a number, as returned with the "rest_total_hits_as_int" parameter.
+//
+func (t *TotalHits) UnmarshalJSON(b []byte) error {
+	if b = bytes.TrimSpace(b); len(b) > 0 && b[0] != '{' {
+		if bytes.Equal(b, []byte("null")) {
+			return nil
+		}
+		t.Relation = "eq"
+		return json.Unmarshal(b, &t.Value)
+	}
+
+	type totalHits TotalHits
+	return json.Unmarshal(b, (*totalHits)(t))
+}
+
+// SearchHit represents a single hit in the search response.
+//
+type SearchHit struct {
+	Index       string              `json:"_index"`
+	ID          string              `json:"_id"`
+	Score       *float64            `json:"_score"`
+	Version     int64               `json:"_version,omitempty"`
+	SeqNo       *int64              `json:"_seq_no,omitempty"`
+	PrimaryTerm *int64              `json:"_primary_term,omitempty"`
+	Routing     string              `json:"_routing,omitempty"`
+	Source      json.RawMessage     `json:"_source,omitempty"`
+	Fields      json.RawMessage     `json:"fields,omitempty"`
+	Highlight   map[string][]string `json:"highlight,omitempty"`
+	Sort        []interface{}       `json:"sort,omitempty"`
+}
+
+// CountResponse represents the response of the Count API.
+//
+type CountResponse struct {
+	Count  int64      `json:"count"`
+	Shards ShardsInfo `json:"_shards"`
+}
+
+// GetResponse represents the response of the Get API, and a document in the response of the Mget API.
+//
+type GetResponse struct {
+	Index       string          `json:"_index"`
+	ID          string          `json:"_id"`
+	Version     int64           `json:"_version,omitempty"`
+	SeqNo       int64           `json:"_seq_no,omitempty"`
+	PrimaryTerm int64           `json:"_primary_term,omitempty"`
+	Routing     string          `json:"_routing,omitempty"`
+	Found       bool            `json:"found"`
+	Source      json.RawMessage `json:"_source,omitempty"`
+	Fields      json.RawMessage `json:"fields,omitempty"`
+
+	// Error is set for a document which could not be retrieved by the Mget API.
+	Error *ErrorCause `json:"error,omitempty"`
+}
+
+// MgetResponse represents the response of the Mget API.
+//
+type MgetResponse struct {
+	Docs []GetResponse `json:"docs"`
+}
+
+// IndexResponse represents the response of the Index and Create APIs.
+//
+type IndexResponse struct {
+	Index         string     `json:"_index"`
+	ID            string     `json:"_id"`
+	Version       int64      `json:"_version"`
+	Result        string     `json:"result"`
+	Shards        ShardsInfo `json:"_shards"`
+	SeqNo         int64      `json:"_seq_no"`
+	PrimaryTerm   int64      `json:"_primary_term"`
+	ForcedRefresh bool       `json:"forced_refresh,omitempty"`
+}
+
+// UpdateResponse represents the response of the Update API.
+//
+// The Get field is set when the updated source was requested with the "_source" parameter.
+//
+type UpdateResponse struct {
+	IndexResponse
+	Get *GetResponse `json:"get,omitempty"`
+}
+
+// DeleteResponse represents the response of the Delete API.
+//
+type DeleteResponse IndexResponse
+
+// BulkResponse represents the response of the Bulk API.
+//
+// Every item is a map with a single key, the action: "index", "create", "update" or "delete".
+//
+type BulkResponse struct {
+	Took   int                           `json:"took"`
+	Errors bool                          `json:"errors"`
+	Items  []map[string]BulkResponseItem `json:"items"`
+}
+
+// BulkResponseItem represents the result of a single action in the response of the Bulk API.
+//
+type BulkResponseItem struct {
+	Index       string      `json:"_index"`
+	ID          string      `json:"_id"`
+	Version     int64       `json:"_version,omitempty"`
+	Result      string      `json:"result,omitempty"`
+	Status      int         `json:"status"`
+	Shards      *ShardsInfo `json:"_shards,omitempty"`
+	SeqNo       int64       `json:"_seq_no,omitempty"`
+	PrimaryTerm int64       `json:"_primary_term,omitempty"`
+	Error       *ErrorCause `json:"error,omitempty"`
+}
+
+// ClusterHealthResponse represents the response of the Cluster Health API.
+//
+// The Indices field is set when the "level" parameter is "indices" or "shards".
+//
+type ClusterHealthResponse struct {
+	ClusterName                 string          `json:"cluster_name"`
+	Status                      string          `json:"status"`
+	TimedOut                    bool            `json:"timed_out"`
+	NumberOfNodes               int             `json:"number_of_nodes"`
+	NumberOfDataNodes           int             `json:"number_of_data_nodes"`
+	ActivePrimaryShards         int             `json:"active_primary_shards"`
+	ActiveShards                int             `json:"active_shards"`
+	RelocatingShards            int             `json:"relocating_shards"`
+	InitializingShards          int             `json:"initializing_shards"`
+	UnassignedShards            int             `json:"unassigned_shards"`
+	DelayedUnassignedShards     int             `json:"delayed_unassigned_shards"`
+	NumberOfPendingTasks        int             `json:"number_of_pending_tasks"`
+	NumberOfInFlightFetch       int             `json:"number_of_in_flight_fetch"`
+	TaskMaxWaitingInQueueMillis int64           `json:"task_max_waiting_in_queue_millis"`
+	ActiveShardsPercentAsNumber float64         `json:"active_shards_percent_as_number"`
+	Indices                     json.RawMessage `json:"indices,omitempty"`
+}
+
+// CatResponse represents the response of any Cat API requested with the "format" parameter set to "json";
+// the keys of the rows are the column names.
+//
+type CatResponse []map[string]string
+
+// CatIndicesResponse represents the response of the Cat Indices API requested in the JSON format.
+//
+type CatIndicesResponse []CatIndicesRecord
+
+// CatIndicesRecord represents a row in the response of the Cat Indices API.
+//
+type CatIndicesRecord struct {
+	Health       string `json:"health"`
+	Status       string `json:"status"`
+	Index        string `json:"index"`
+	UUID         string `json:"uuid"`
+	Primaries    string `json:"pri"`
+	Replicas     string `json:"rep"`
+	DocsCount    string `json:"docs.count"`
+	DocsDeleted  string `json:"docs.deleted"`
+	StoreSize    string `json:"store.size"`
+	PriStoreSize string `json:"pri.store.size"`
+}
+
+// CatHealthResponse represents the response of the Cat Health API requested in the JSON format.
+//
+type CatHealthResponse []CatHealthRecord
+
+// CatHealthRecord represents a row in the response of the Cat Health API.
+//
+type CatHealthRecord struct {
+	Epoch               string `json:"epoch"`
+	Timestamp           string `json:"timestamp"`
+	Cluster             string `json:"cluster"`
+	Status              string `json:"status"`
+	NodeTotal           string `json:"node.total"`
+	NodeData            string `json:"node.data"`
+	Shards              string `json:"shards"`
+	Primaries           string `json:"pri"`
+	Relocating          string `json:"relo"`
+	Initializing        string `json:"init"`
+	Unassigned          string `json:"unassign"`
+	PendingTasks        string `json:"pending_tasks"`
+	MaxTaskWaitTime     string `json:"max_task_wait_time"`
+	ActiveShardsPercent string `json:"active_shards_percent"`
+}
+
+// CatCountResponse represents the response of the Cat Count API requested in the JSON format.
+//
+type CatCountResponse []CatCountRecord
+
+// CatCountRecord represents a row in the response of the Cat Count API.
+//
+type CatCountRecord struct {
+	Epoch     string `json:"epoch"`
+	Timestamp string `json:"timestamp"`
+	Count     string `json:"count"`
+}
@@ -0,0 +1,118 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// +build !integration
+
+package esapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAPIBodyStruct(t *testing.T) {
+	var body []byte
+
+	tp := &mockTransport{
+		PerformFunc: func(req *http.Request) (*http.Response, error) {
+			body, _ = ioutil.ReadAll(req.Body)
+			return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader("{}"))}, nil
+		},
+	}
+
+	bodyEqual := func(t *testing.T, expected string) {
+		t.Helper()
+
+		var a, b interface{}
+		if err := json.Unmarshal(body, &a); err != nil {
+			t.Fatalf("Unexpected error: %s, body: %s", err, body)
+		}
+		if err := json.Unmarshal([]byte(expected), &b); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(a, b) {
+			t.Errorf("Unexpected body, want=%s, got=%s", expected, body)
+		}
+	}
+
+	t.Run("Reindex", func(t *testing.T) {
+		reindex := newReindexFunc(tp)
+		_, err := reindex(nil, reindex.WithBodyStruct(&ReindexBody{
+			Conflicts: "proceed",
+			Source:    ReindexSource{Index: []string{"a", "b"}, SourceFields: []string{"title"}},
+			Dest:      ReindexDest{Index: "c", OpType: "create"},
+			Script:    &Script{Source: "ctx._source.count++", Lang: "painless"},
+		}))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		bodyEqual(t, `{
+			"conflicts": "proceed",
+			"source": {"index": ["a", "b"], "_source": ["title"]},
+			"dest": {"index": "c", "op_type": "create"},
+			"script": {"source": "ctx._source.count++", "lang": "painless"}
+		}`)
+	})
+
+	t.Run("IndicesCreate", func(t *testing.T) {
+		replicas := 0
+
+		create := newIndicesCreateFunc(tp)
+		_, err := create("test", create.WithBodyStruct(&IndicesCreateBody{
+			Settings: &IndexSettings{NumberOfShards: 1, NumberOfReplicas: &replicas},
+			Mappings: &TypeMapping{
+				Properties: map[string]MappingProperty{
+					"title": {Type: "text", Fields: map[string]MappingProperty{"raw": {Type: "keyword"}}},
+				},
+			},
+			Aliases: map[string]IndexAlias{"alias": {}},
+		}))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		bodyEqual(t, `{
+			"settings": {"number_of_shards": 1, "number_of_replicas": 0},
+			"mappings": {"properties": {"title": {"type": "text", "fields": {"raw": {"type": "keyword"}}}}},
+			"aliases": {"alias": {}}
+		}`)
+	})
+
+	t.Run("IndicesUpdateAliases", func(t *testing.T) {
+		update := newIndicesUpdateAliasesFunc(tp)
+		_, err := update(nil, update.WithBodyStruct(&IndicesUpdateAliasesBody{
+			Actions: []IndicesAliasAction{
+				{Remove: &IndicesAliasActionParams{Index: "a", Alias: "alias"}},
+				{Add: &IndicesAliasActionParams{Index: "b", Alias: "alias"}},
+			},
+		}))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		bodyEqual(t, `{"actions": [
+			{"remove": {"index": "a", "alias": "alias"}},
+			{"add": {"index": "b", "alias": "alias"}}
+		]}`)
+	})
+
+	t.Run("ClusterReroute", func(t *testing.T) {
+		reroute := newClusterRerouteFunc(tp)
+		_, err := reroute(reroute.WithBodyStruct(&ClusterRerouteBody{
+			Commands: []ClusterRerouteCommand{
+				{Move: &ClusterRerouteMove{Index: "test", Shard: 0, FromNode: "node1", ToNode: "node2"}},
+			},
+		}))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		bodyEqual(t, `{"commands": [{"move": {"index": "test", "shard": 0, "from_node": "node1", "to_node": "node2"}}]}`)
+	})
+}
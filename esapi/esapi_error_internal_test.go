@@ -0,0 +1,117 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// +build !integration
+
+package esapi
+
+import (
+	"fmt"
+	"testing"
+)
+
+type wrappedError struct{ err error }
+
+func (e wrappedError) Error() string { return fmt.Sprintf("wrapped: %s", e.err) }
+func (e wrappedError) Unwrap() error { return e.err }
+
+func TestResponseError(t *testing.T) {
+	t.Run("Parse", func(t *testing.T) {
+		body := `{
+		  "error": {
+		    "root_cause": [
+		      {"type": "query_shard_exception", "reason": "failed to create query", "index": "test"}
+		    ],
+		    "type": "search_phase_execution_exception",
+		    "reason": "all shards failed",
+		    "phase": "query",
+		    "grouped": true,
+		    "failed_shards": [
+		      {
+		        "shard": 0,
+		        "index": "test",
+		        "node": "abc123",
+		        "reason": {"type": "query_shard_exception", "reason": "failed to create query"}
+		      }
+		    ],
+		    "caused_by": {"type": "number_format_exception", "reason": "For input string: \"foo\""}
+		  },
+		  "status": 400
+		}`
+
+		e := newResponseError(400, []byte(body))
+
+		if e.Status != 400 {
+			t.Errorf("Unexpected status: %d", e.Status)
+		}
+		if e.Type != "search_phase_execution_exception" {
+			t.Errorf("Unexpected type: %s", e.Type)
+		}
+		if e.Reason != "all shards failed" {
+			t.Errorf("Unexpected reason: %s", e.Reason)
+		}
+		if len(e.RootCause) != 1 || e.RootCause[0].Type != "query_shard_exception" || e.RootCause[0].Index != "test" {
+			t.Errorf("Unexpected root cause: %+v", e.RootCause)
+		}
+		if e.CausedBy == nil || e.CausedBy.Type != "number_format_exception" {
+			t.Errorf("Unexpected caused by: %+v", e.CausedBy)
+		}
+		if len(e.FailedShards) != 1 || e.FailedShards[0].Node != "abc123" || e.FailedShards[0].Reason.Type != "query_shard_exception" {
+			t.Errorf("Unexpected failed shards: %+v", e.FailedShards)
+		}
+
+		expected := `[400 Bad Request] search_phase_execution_exception: all shards failed (caused by: number_format_exception: For input string: "foo")`
+		if e.Error() != expected {
+			t.Errorf("Unexpected error string:\nwant: %s\ngot:  %s", expected, e.Error())
+		}
+	})
+
+	t.Run("Parse string error", func(t *testing.T) {
+		e := newResponseError(400, []byte(`{"error":"Incorrect HTTP method","status":405}`))
+
+		if e.Type != "" || e.Reason != "Incorrect HTTP method" {
+			t.Errorf("Unexpected error: %+v", e)
+		}
+	})
+
+	t.Run("Parse empty body", func(t *testing.T) {
+		e := newResponseError(404, nil)
+
+		if e.Reason != "Not Found" {
+			t.Errorf("Unexpected reason: %s", e.Reason)
+		}
+		if e.Error() != "[404 Not Found] Not Found" {
+			t.Errorf("Unexpected error string: %s", e.Error())
+		}
+	})
+
+	t.Run("Matchers", func(t *testing.T) {
+		tt := []struct {
+			name    string
+			err     error
+			matcher func(error) bool
+			want    bool
+		}{
+			{"IsNotFound", &ResponseError{Status: 404}, IsNotFound, true},
+			{"IsNotFound wrapped", wrappedError{&ResponseError{Status: 404}}, IsNotFound, true},
+			{"IsNotFound other status", &ResponseError{Status: 400}, IsNotFound, false},
+			{"IsNotFound other error", fmt.Errorf("MOCK ERROR"), IsNotFound, false},
+			{"IsNotFound nil", nil, IsNotFound, false},
+			{"IsVersionConflict", &ResponseError{Status: 409, Type: "version_conflict_engine_exception"}, IsVersionConflict, true},
+			{"IsVersionConflict other status", &ResponseError{Status: 400}, IsVersionConflict, false},
+			{"IsIndexAlreadyExists", &ResponseError{Status: 400, Type: "resource_already_exists_exception"}, IsIndexAlreadyExists, true},
+			{"IsIndexAlreadyExists other type", &ResponseError{Status: 400, Type: "illegal_argument_exception"}, IsIndexAlreadyExists, false},
+			{"IsTooManyRequests", &ResponseError{Status: 429, Type: "es_rejected_execution_exception"}, IsTooManyRequests, true},
+			{"IsTooManyRequests other status", &ResponseError{Status: 503}, IsTooManyRequests, false},
+		}
+
+		for _, tc := range tt {
+			t.Run(tc.name, func(t *testing.T) {
+				if got := tc.matcher(tc.err); got != tc.want {
+					t.Errorf("Unexpected result for %v: want=%v, got=%v", tc.err, tc.want, got)
+				}
+			})
+		}
+	})
+}
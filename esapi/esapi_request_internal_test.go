@@ -8,12 +8,26 @@ package esapi
 
 import (
 	"bytes"
+	"context"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/internal/endpoint"
+	"github.com/elastic/go-elasticsearch/v8/internal/options"
 )
 
+type mockTransport struct {
+	PerformFunc func(*http.Request) (*http.Response, error)
+}
+
+func (t *mockTransport) Perform(req *http.Request) (*http.Response, error) {
+	return t.PerformFunc(req)
+}
+
 func TestAPIRequest(t *testing.T) {
 	var (
 		body string
@@ -62,4 +76,76 @@ func TestAPIRequest(t *testing.T) {
 			t.Errorf("Unexpected type for req.Body: %T", req.Body)
 		}
 	})
+	t.Run("Endpoint", func(t *testing.T) {
+		var info endpoint.Info
+
+		tp := &mockTransport{
+			PerformFunc: func(req *http.Request) (*http.Response, error) {
+				info, _ = endpoint.FromContext(req.Context())
+				return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+			},
+		}
+
+		if _, err := (SearchRequest{Index: []string{"foo", "bar"}}).Do(context.Background(), tp); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if info.Name != "search" || strings.Join(info.Index, ",") != "foo,bar" {
+			t.Errorf("Unexpected endpoint info: %+v", info)
+		}
+
+		if _, err := (GetRequest{Index: "baz", DocumentID: "1"}).Do(nil, tp); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if info.Name != "get" || strings.Join(info.Index, ",") != "baz" {
+			t.Errorf("Unexpected endpoint info: %+v", info)
+		}
+
+		if _, err := (InfoRequest{}).Do(context.Background(), tp); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if info.Name != "info" || len(info.Index) != 0 {
+			t.Errorf("Unexpected endpoint info: %+v", info)
+		}
+	})
+
+	t.Run("RequestOptions", func(t *testing.T) {
+		var (
+			opts options.Request
+			ok   bool
+		)
+
+		tp := &mockTransport{
+			PerformFunc: func(req *http.Request) (*http.Response, error) {
+				opts, ok = options.FromContext(req.Context())
+				return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+			},
+		}
+
+		if _, err := (InfoRequest{}).Do(context.Background(), tp); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if ok {
+			t.Errorf("Expected no request options, got: %+v", opts)
+		}
+
+		update := newUpdateFunc(tp)
+		_, err := update("test", "1", strings.NewReader(`{}`),
+			update.WithRequestOptions(RequestOptions{DisableRetry: true, Header: http.Header{"X-Foo": []string{"foo"}}}),
+			update.WithContext(context.Background()),
+			update.WithRequestOptions(RequestOptions{Timeout: time.Second, Header: http.Header{"X-Foo": []string{"bar"}}}),
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if !ok {
+			t.Fatalf("Expected the request options in the context")
+		}
+		if !opts.DisableRetry || opts.Timeout != time.Second {
+			t.Errorf("Unexpected request options: %+v", opts)
+		}
+		if strings.Join(opts.Header["X-Foo"], ",") != "bar" {
+			t.Errorf("Unexpected headers: %v", opts.Header)
+		}
+	})
 }
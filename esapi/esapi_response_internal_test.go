@@ -8,6 +8,7 @@ package esapi
 
 import (
 	"errors"
+	"io"
 	"io/ioutil"
 	"strings"
 	"testing"
@@ -82,4 +83,175 @@ func TestAPIResponse(t *testing.T) {
 			t.Errorf("Expected error for response: %s", res.Status())
 		}
 	})
+
+	t.Run("Err", func(t *testing.T) {
+		res = &Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(`{}`))}
+
+		if err := res.Err(); err != nil {
+			t.Errorf("Unexpected error for response: %s", err)
+		}
+
+		body = `{"error":{"type":"index_not_found_exception","reason":"no such index [foo]"},"status":404}`
+		res = &Response{StatusCode: 404, Body: ioutil.NopCloser(strings.NewReader(body))}
+
+		err := res.Err()
+		if err == nil {
+			t.Fatalf("Expected error for response: %s", res.Status())
+		}
+
+		e, ok := err.(*ResponseError)
+		if !ok {
+			t.Fatalf("Unexpected error type: %T", err)
+		}
+
+		if e.Type != "index_not_found_exception" {
+			t.Errorf("Unexpected error type: %s", e.Type)
+		}
+
+		if res.String() != `[404 Not Found] `+body {
+			t.Errorf("Expected the response body to be preserved, got: %s", res.String())
+		}
+	})
+
+	t.Run("DecodeJSON", func(t *testing.T) {
+		body := &closeRecorder{Reader: strings.NewReader(`{"count":42}`)}
+		res = &Response{StatusCode: 200, Body: body}
+
+		var r CountResponse
+		if err := res.DecodeJSON(&r); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if r.Count != 42 {
+			t.Errorf("Unexpected count: %d", r.Count)
+		}
+		if !body.closed {
+			t.Errorf("Expected the body to be closed")
+		}
+
+		res = &Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(`{"count":`))}
+		if err := res.DecodeJSON(&r); err == nil || !strings.Contains(err.Error(), "cannot decode response") {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		res = &Response{StatusCode: 200}
+		if err := res.DecodeJSON(&r); err == nil {
+			t.Errorf("Expected error for empty body")
+		}
+	})
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestAPIResponseTypes(t *testing.T) {
+	decode := func(t *testing.T, body string, v interface{}) {
+		res := &Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(body))}
+		if err := res.DecodeJSON(v); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	t.Run("Search", func(t *testing.T) {
+		var r SearchResponse
+		decode(t, `{
+			"took":5,"timed_out":false,
+			"_shards":{"total":2,"successful":1,"skipped":0,"failed":1,
+				"failures":[{"shard":1,"index":"test","node":"abc","reason":{"type":"query_shard_exception","reason":"failed"}}]},
+			"hits":{"total":{"value":10000,"relation":"gte"},"max_score":1.5,
+				"hits":[{"_index":"test","_id":"1","_score":1.5,"_source":{"title":"Test"},"highlight":{"title":["<em>Test</em>"]}}]},
+			"aggregations":{"tags":{"buckets":[]}}}`, &r)
+
+		if r.Took != 5 || r.Shards.Failed != 1 || r.Shards.Failures[0].Reason.Type != "query_shard_exception" {
+			t.Errorf("Unexpected response: %+v", r)
+		}
+		if r.Hits.Total.Value != 10000 || r.Hits.Total.Relation != "gte" || *r.Hits.MaxScore != 1.5 {
+			t.Errorf("Unexpected hits: %+v", r.Hits)
+		}
+		if h := r.Hits.Hits[0]; h.ID != "1" || string(h.Source) != `{"title":"Test"}` || h.Highlight["title"][0] != "<em>Test</em>" {
+			t.Errorf("Unexpected hit: %+v", h)
+		}
+		if string(r.Aggregations) != `{"tags":{"buckets":[]}}` {
+			t.Errorf("Unexpected aggregations: %s", r.Aggregations)
+		}
+
+		decode(t, `{"hits":{"total":3,"hits":[]}}`, &r)
+		if r.Hits.Total.Value != 3 || r.Hits.Total.Relation != "eq" {
+			t.Errorf("Unexpected total: %+v", r.Hits.Total)
+		}
+	})
+
+	t.Run("Bulk", func(t *testing.T) {
+		var r BulkResponse
+		decode(t, `{"took":3,"errors":true,"items":[
+			{"index":{"_index":"test","_id":"1","_version":1,"result":"created","_shards":{"total":2,"successful":1,"failed":0},"status":201,"_seq_no":0,"_primary_term":1}},
+			{"create":{"_index":"test","_id":"2","status":409,"error":{"type":"version_conflict_engine_exception","reason":"[2]: version conflict"}}}]}`, &r)
+
+		if !r.Errors || len(r.Items) != 2 {
+			t.Fatalf("Unexpected response: %+v", r)
+		}
+		if item := r.Items[0]["index"]; item.Status != 201 || item.Result != "created" || item.Shards.Successful != 1 {
+			t.Errorf("Unexpected item: %+v", item)
+		}
+		if item := r.Items[1]["create"]; item.Status != 409 || item.Error.Type != "version_conflict_engine_exception" {
+			t.Errorf("Unexpected item: %+v", item)
+		}
+	})
+
+	t.Run("Get and Mget", func(t *testing.T) {
+		var r MgetResponse
+		decode(t, `{"docs":[
+			{"_index":"test","_id":"1","_version":2,"_seq_no":3,"_primary_term":1,"found":true,"_source":{"title":"Test"}},
+			{"_index":"test","_id":"2","found":false},
+			{"_index":"missing","_id":"3","error":{"type":"index_not_found_exception","reason":"no such index [missing]"}}]}`, &r)
+
+		if len(r.Docs) != 3 || !r.Docs[0].Found || r.Docs[0].Version != 2 || r.Docs[1].Found || r.Docs[2].Error == nil {
+			t.Errorf("Unexpected response: %+v", r)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		var r UpdateResponse
+		decode(t, `{"_index":"test","_id":"1","_version":3,"result":"updated","_shards":{"total":2,"successful":2,"failed":0},
+			"_seq_no":4,"_primary_term":1,"get":{"found":true,"_source":{"title":"Updated"}}}`, &r)
+
+		if r.Result != "updated" || r.Version != 3 || r.Shards.Successful != 2 || string(r.Get.Source) != `{"title":"Updated"}` {
+			t.Errorf("Unexpected response: %+v", r)
+		}
+
+		var d DeleteResponse
+		decode(t, `{"_index":"test","_id":"1","_version":4,"result":"deleted"}`, &d)
+		if d.Result != "deleted" {
+			t.Errorf("Unexpected response: %+v", d)
+		}
+	})
+
+	t.Run("Cluster health and cat", func(t *testing.T) {
+		var h ClusterHealthResponse
+		decode(t, `{"cluster_name":"test","status":"yellow","number_of_nodes":1,"unassigned_shards":5,"active_shards_percent_as_number":50.0}`, &h)
+
+		if h.Status != "yellow" || h.NumberOfNodes != 1 || h.UnassignedShards != 5 || h.ActiveShardsPercentAsNumber != 50 {
+			t.Errorf("Unexpected response: %+v", h)
+		}
+
+		var c CatIndicesResponse
+		decode(t, `[{"health":"green","status":"open","index":"test","pri":"1","rep":"0","docs.count":"42","store.size":null}]`, &c)
+
+		if len(c) != 1 || c[0].Index != "test" || c[0].DocsCount != "42" {
+			t.Errorf("Unexpected response: %+v", c)
+		}
+
+		var g CatResponse
+		decode(t, `[{"alias":"foo","index":"test"}]`, &g)
+
+		if len(g) != 1 || g[0]["alias"] != "foo" {
+			t.Errorf("Unexpected response: %+v", g)
+		}
+	})
 }
@@ -0,0 +1,28 @@
+/*
+Package esapitest provides a mock transport with expectations for testing the code using the client.
+
+Create the transport with the NewTransport function, and pass it to the client:
+
+		m := esapitest.NewTransport()
+		es := &elasticsearch.Client{Transport: m, API: esapi.New(m)}
+
+Add the expected requests with the Expect method, and configure the matching and the response
+with the chained methods:
+
+		m.Expect("GET", "/my-index/_search").
+		  WithBodyJSON(`{"query":{"match":{"title":"test"}}}`).
+		  Respond(200, `{"hits":{"total":{"value":0},"hits":[]}}`)
+
+		m.Expect("GET", "/my-index/_doc/1").Times(2).Respond(404, `{"found":false}`)
+
+By default, every expectation is expected to be called once, and the requests are matched against
+the expectations in any order; use the Times and AnyTimes methods to change the number of calls,
+and the InOrder method to require the expectations to be called in the order they were added.
+
+The requests which don't match any expectation fail with an error, unless a function is set with
+the OnUnexpected method. Call the AssertExpectations method at the end of the test to report the
+expectations called an unexpected number of times, and the unexpected requests:
+
+		defer m.AssertExpectations(t)
+*/
+package esapitest
@@ -0,0 +1,371 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package esapitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// TestingT defines the subset of testing.TB used for reporting the failed expectations.
+//
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+// Transport represents a mock transport, which returns the responses configured
+// for the expected requests.
+//
+// It implements the esapi.Transport and estransport.Interface interfaces.
+//
+type Transport struct {
+	mu sync.Mutex
+
+	expectations []*Expectation
+	ordered      bool
+	unexpected   []string
+	onUnexpected func(*http.Request) (*http.Response, error)
+}
+
+// Expectation represents an expected request and its response.
+//
+type Expectation struct {
+	method   string
+	path     string
+	query    url.Values
+	header   http.Header
+	body     *string
+	bodyJSON interface{}
+	match    []func(*http.Request) bool
+
+	respond func(*http.Request) (*http.Response, error)
+
+	times int // The expected number of calls; -1 for any number.
+	calls int
+}
+
+// NewTransport creates a new mock transport.
+//
+func NewTransport() *Transport {
+	return &Transport{}
+}
+
+// Expect adds an expectation for a request with the method and path,
+// which is expected to be called once; the query string in path is matched as well.
+//
+// The expectation responds with 200 OK and an empty JSON object, unless configured otherwise.
+//
+func (t *Transport) Expect(method, path string) *Expectation {
+	e := Expectation{
+		method: strings.ToUpper(method),
+		path:   path,
+		header: make(http.Header),
+		times:  1,
+	}
+
+	if i := strings.Index(path, "?"); i > -1 {
+		e.path = path[:i]
+		e.query, _ = url.ParseQuery(path[i+1:])
+	}
+
+	e.Respond(http.StatusOK, `{}`)
+
+	t.mu.Lock()
+	t.expectations = append(t.expectations, &e)
+	t.mu.Unlock()
+
+	return &e
+}
+
+// InOrder requires the expectations to be called in the order they were added.
+//
+func (t *Transport) InOrder() *Transport {
+	t.mu.Lock()
+	t.ordered = true
+	t.mu.Unlock()
+	return t
+}
+
+// OnUnexpected sets the function called for the requests which don't match any expectation.
+//
+// By default, an error is returned. The unexpected requests are reported by AssertExpectations
+// in either case.
+//
+func (t *Transport) OnUnexpected(fn func(*http.Request) (*http.Response, error)) *Transport {
+	t.mu.Lock()
+	t.onUnexpected = fn
+	t.mu.Unlock()
+	return t
+}
+
+// Perform returns the response of the first expectation matching the request.
+//
+func (t *Transport) Perform(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("esapitest: cannot read request body: %s", err)
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	t.mu.Lock()
+	e := t.find(req, body)
+	if e != nil {
+		e.calls++
+	} else {
+		t.unexpected = append(t.unexpected, describe(req, body))
+	}
+	onUnexpected := t.onUnexpected
+	t.mu.Unlock()
+
+	if e == nil {
+		if onUnexpected != nil {
+			return onUnexpected(req)
+		}
+		return nil, fmt.Errorf("esapitest: unexpected request %s", describe(req, body))
+	}
+
+	return e.respond(req)
+}
+
+// AssertExpectations reports the expectations which were not called the expected number of times,
+// and the unexpected requests, and returns true when there are none.
+//
+func (t *Transport) AssertExpectations(tt TestingT) bool {
+	if h, ok := tt.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ok := true
+	for _, e := range t.expectations {
+		if e.times >= 0 && e.calls != e.times {
+			tt.Errorf("esapitest: expected %s %s to be called %d time(s), got %d", e.method, e.path, e.times, e.calls)
+			ok = false
+		}
+	}
+	for _, r := range t.unexpected {
+		tt.Errorf("esapitest: unexpected request %s", r)
+		ok = false
+	}
+	return ok
+}
+
+// find returns the expectation for the request, or nil.
+//
+func (t *Transport) find(req *http.Request, body []byte) *Expectation {
+	for _, e := range t.expectations {
+		if e.times >= 0 && e.calls >= e.times {
+			continue
+		}
+		if e.matches(req, body) {
+			return e
+		}
+		// In the ordered mode, the next expectation has to be satisfied first
+		if t.ordered && e.times >= 0 {
+			return nil
+		}
+	}
+	return nil
+}
+
+// WithQuery requires the request to have the query parameter with the value.
+//
+func (e *Expectation) WithQuery(key, value string) *Expectation {
+	if e.query == nil {
+		e.query = make(url.Values)
+	}
+	e.query.Add(key, value)
+	return e
+}
+
+// WithHeader requires the request to have the header with the value.
+//
+func (e *Expectation) WithHeader(key, value string) *Expectation {
+	e.header.Add(key, value)
+	return e
+}
+
+// WithBody requires the request to have the exact body.
+//
+func (e *Expectation) WithBody(body string) *Expectation {
+	e.body = &body
+	return e
+}
+
+// WithBodyJSON requires the request to have a JSON body equal to v, regardless of the formatting
+// and the order of the keys; v is either a string, a byte slice, or a value encoded to JSON.
+//
+func (e *Expectation) WithBodyJSON(v interface{}) *Expectation {
+	b, err := jsonBytes(v)
+	if err != nil {
+		panic(fmt.Sprintf("esapitest: cannot encode body: %s", err))
+	}
+	if err := json.Unmarshal(b, &e.bodyJSON); err != nil {
+		panic(fmt.Sprintf("esapitest: invalid JSON body: %s", err))
+	}
+	return e
+}
+
+// Match requires the request to satisfy the function.
+//
+func (e *Expectation) Match(fn func(*http.Request) bool) *Expectation {
+	e.match = append(e.match, fn)
+	return e
+}
+
+// Times sets the expected number of calls.
+//
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+// AnyTimes allows the expectation to be called any number of times, including none.
+//
+func (e *Expectation) AnyTimes() *Expectation {
+	e.times = -1
+	return e
+}
+
+// Respond sets the status code and the body of the response.
+//
+func (e *Expectation) Respond(status int, body string) *Expectation {
+	return e.RespondFunc(func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, status, body), nil
+	})
+}
+
+// RespondJSON sets the status code and the body of the response, encoding v to JSON;
+// v is either a string, a byte slice, or a value encoded to JSON.
+//
+func (e *Expectation) RespondJSON(status int, v interface{}) *Expectation {
+	b, err := jsonBytes(v)
+	if err != nil {
+		panic(fmt.Sprintf("esapitest: cannot encode response: %s", err))
+	}
+	return e.Respond(status, string(b))
+}
+
+// RespondError returns the error for the request, eg. to simulate a network failure.
+//
+func (e *Expectation) RespondError(err error) *Expectation {
+	return e.RespondFunc(func(*http.Request) (*http.Response, error) {
+		return nil, err
+	})
+}
+
+// RespondFunc sets the function returning the response for the request.
+//
+func (e *Expectation) RespondFunc(fn func(*http.Request) (*http.Response, error)) *Expectation {
+	e.respond = fn
+	return e
+}
+
+// matches returns true when the request satisfies the expectation.
+//
+func (e *Expectation) matches(req *http.Request, body []byte) bool {
+	if e.method != req.Method || e.path != req.URL.Path {
+		return false
+	}
+
+	q := req.URL.Query()
+	for k, vv := range e.query {
+		if !reflect.DeepEqual(q[k], vv) {
+			return false
+		}
+	}
+
+	for k, vv := range e.header {
+		if !reflect.DeepEqual(req.Header[k], vv) {
+			return false
+		}
+	}
+
+	if e.body != nil && *e.body != string(body) {
+		return false
+	}
+
+	if e.bodyJSON != nil {
+		var v interface{}
+		if err := json.Unmarshal(body, &v); err != nil || !reflect.DeepEqual(v, e.bodyJSON) {
+			return false
+		}
+	}
+
+	for _, fn := range e.match {
+		if !fn(req) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newResponse returns a response with the status code and the body.
+//
+func newResponse(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// jsonBytes returns v as JSON bytes.
+//
+func jsonBytes(v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case json.RawMessage:
+		return v, nil
+	default:
+		return json.Marshal(v)
+	}
+}
+
+// describe returns the request as a string, for the error messages.
+//
+func describe(req *http.Request, body []byte) string {
+	var b strings.Builder
+	b.WriteString(req.Method)
+	b.WriteString(" ")
+	b.WriteString(req.URL.Path)
+	if req.URL.RawQuery != "" {
+		b.WriteString("?")
+		b.WriteString(req.URL.RawQuery)
+	}
+	if len(body) > 0 {
+		s := string(body)
+		if len(s) > 256 {
+			s = s[:256] + "..."
+		}
+		b.WriteString(" with body ")
+		b.WriteString(s)
+	}
+	return b.String()
+}
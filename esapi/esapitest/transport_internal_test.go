@@ -0,0 +1,163 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// +build !integration
+
+package esapitest
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+type mockT struct {
+	errors []string
+}
+
+func (t *mockT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func newClient(m *Transport) *elasticsearch.Client {
+	return &elasticsearch.Client{Transport: m, API: esapi.New(m)}
+}
+
+func TestTransport(t *testing.T) {
+	t.Run("Match and respond", func(t *testing.T) {
+		m := NewTransport()
+		es := newClient(m)
+
+		m.Expect("GET", "/my-index/_search?size=1").
+			WithBodyJSON(map[string]interface{}{"query": map[string]interface{}{"match_all": map[string]interface{}{}}}).
+			WithHeader("X-Opaque-Id", "abc").
+			Respond(200, `{"hits":{"hits":[]}}`)
+
+		res, err := es.Search(
+			es.Search.WithIndex("my-index"),
+			es.Search.WithSize(1),
+			es.Search.WithOpaqueID("abc"),
+			es.Search.WithBody(strings.NewReader(`{ "query" : { "match_all" : {} } }`)),
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		body, _ := ioutil.ReadAll(res.Body)
+
+		if res.StatusCode != 200 || string(body) != `{"hits":{"hits":[]}}` {
+			t.Errorf("Unexpected response: %d %s", res.StatusCode, body)
+		}
+
+		m.AssertExpectations(t)
+	})
+
+	t.Run("Call count", func(t *testing.T) {
+		m := NewTransport()
+		es := newClient(m)
+
+		m.Expect("GET", "/test/_doc/1").Times(2).RespondJSON(200, map[string]interface{}{"found": true})
+		m.Expect("GET", "/test/_doc/2").Respond(404, `{"found":false}`)
+		m.Expect("HEAD", "/").AnyTimes()
+
+		for i := 0; i < 3; i++ {
+			res, err := es.Get("test", "1")
+			if i < 2 && (err != nil || res.StatusCode != 200) {
+				t.Errorf("Unexpected response: %v, %v", res, err)
+			}
+			if i == 2 && (err == nil || !strings.Contains(err.Error(), "unexpected request GET /test/_doc/1")) {
+				t.Errorf("Expected error for unexpected call, got: %v", err)
+			}
+		}
+
+		mt := &mockT{}
+		if m.AssertExpectations(mt) {
+			t.Errorf("Expected the assertion to fail")
+		}
+		if len(mt.errors) != 2 ||
+			!strings.Contains(mt.errors[0], "expected GET /test/_doc/2 to be called 1 time(s), got 0") ||
+			!strings.Contains(mt.errors[1], "unexpected request GET /test/_doc/1") {
+			t.Errorf("Unexpected errors: %q", mt.errors)
+		}
+	})
+
+	t.Run("Ordered", func(t *testing.T) {
+		m := NewTransport().InOrder()
+		es := newClient(m)
+
+		m.Expect("PUT", "/test").Respond(200, `{"acknowledged":true}`)
+		m.Expect("PUT", "/test/_doc/1").WithBody(`{"title":"Test"}`).Respond(201, `{"result":"created"}`)
+
+		if _, err := es.Index("test", strings.NewReader(`{"title":"Test"}`), es.Index.WithDocumentID("1")); err == nil {
+			t.Errorf("Expected error for the call out of order")
+		}
+		if _, err := es.Indices.Create("test"); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+		if _, err := es.Index("test", strings.NewReader(`{"title":"Test"}`), es.Index.WithDocumentID("1")); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+
+		mt := &mockT{}
+		m.AssertExpectations(mt)
+		if len(mt.errors) != 1 {
+			t.Errorf("Unexpected errors: %q", mt.errors)
+		}
+	})
+
+	t.Run("Catch-all and errors", func(t *testing.T) {
+		m := NewTransport()
+		es := newClient(m)
+
+		m.Expect("GET", "/").RespondError(errors.New("connection refused"))
+		m.OnUnexpected(func(req *http.Request) (*http.Response, error) {
+			return newResponse(req, 400, `{"error":"no handler found"}`), nil
+		})
+
+		if _, err := es.Info(); err == nil || err.Error() != "connection refused" {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		res, err := es.Cat.Health()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if res.StatusCode != 400 {
+			t.Errorf("Unexpected status: %d", res.StatusCode)
+		}
+
+		mt := &mockT{}
+		m.AssertExpectations(mt)
+		if len(mt.errors) != 1 || !strings.Contains(mt.errors[0], "GET /_cat/health") {
+			t.Errorf("Unexpected errors: %q", mt.errors)
+		}
+	})
+
+	t.Run("Match function", func(t *testing.T) {
+		m := NewTransport()
+		es := newClient(m)
+
+		m.Expect("POST", "/_bulk").
+			Match(func(req *http.Request) bool { return req.URL.Query().Get("refresh") == "true" }).
+			RespondFunc(func(req *http.Request) (*http.Response, error) {
+				body, _ := ioutil.ReadAll(req.Body)
+				return newResponse(req, 200, fmt.Sprintf(`{"size":%d}`, len(body))), nil
+			})
+
+		res, err := es.Bulk(strings.NewReader("{\"delete\":{\"_index\":\"test\",\"_id\":\"1\"}}\n"), es.Bulk.WithRefresh("true"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if body, _ := ioutil.ReadAll(res.Body); string(body) != `{"size":39}` {
+			t.Errorf("Unexpected body: %s", body)
+		}
+
+		m.AssertExpectations(t)
+	})
+}
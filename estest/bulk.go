@@ -0,0 +1,118 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package estest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// bulkMeta represents the metadata of a bulk action.
+//
+type bulkMeta struct {
+	Index string `json:"_index"`
+	ID    string `json:"_id"`
+}
+
+func (s *Server) handleBulk(w http.ResponseWriter, r request, defaultIndex string) {
+	if r.method != "POST" && r.method != "PUT" {
+		writeMethodNotAllowed(w, r, "POST,PUT")
+		return
+	}
+
+	if len(bytes.TrimSpace(r.body)) == 0 {
+		writeError(w, http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: no requests added;", "")
+		return
+	}
+	if !bytes.HasSuffix(r.body, []byte("\n")) {
+		writeError(w, http.StatusBadRequest, "illegal_argument_exception", "The bulk request must be terminated by a newline [\\n]", "")
+		return
+	}
+
+	var (
+		lines  = bytes.Split(r.body, []byte("\n"))
+		items  []interface{}
+		errors bool
+	)
+
+	for i := 0; i < len(lines); i++ {
+		line := bytes.TrimSpace(lines[i])
+		if len(line) == 0 {
+			continue
+		}
+
+		var action map[string]bulkMeta
+		if err := json.Unmarshal(line, &action); err != nil || len(action) != 1 {
+			writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("Malformed action/metadata line [%d], expected START_OBJECT", i+1), "")
+			return
+		}
+
+		var (
+			op   string
+			meta bulkMeta
+		)
+		for k, v := range action {
+			op, meta = k, v
+		}
+		if meta.Index == "" {
+			meta.Index = defaultIndex
+		}
+
+		var source []byte
+		switch op {
+		case "index", "create", "update":
+			i++
+			if i >= len(lines) || len(bytes.TrimSpace(lines[i])) == 0 {
+				writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("Malformed action/metadata line [%d], missing the source for the [%s] action", i, op), "")
+				return
+			}
+			source = lines[i]
+		case "delete":
+		default:
+			writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("Malformed action/metadata line [%d], expected field [create], [delete], [index] or [update] but found [%s]", i+1, op), "")
+			return
+		}
+
+		status, res, err := s.bulkItem(op, meta, source)
+		if err != nil {
+			errors = true
+			items = append(items, map[string]interface{}{op: map[string]interface{}{
+				"_index": meta.Index,
+				"_id":    meta.ID,
+				"status": err.status,
+				"error":  newErrorCause(err.typ, err.reason, err.index, false),
+			}})
+			continue
+		}
+		res["status"] = status
+		items = append(items, map[string]interface{}{op: res})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"took": 1, "errors": errors, "items": items})
+}
+
+// bulkItem executes a single bulk action.
+//
+func (s *Server) bulkItem(op string, meta bulkMeta, source []byte) (int, map[string]interface{}, *apiError) {
+	if meta.Index == "" {
+		return 0, nil, validateIndexName(meta.Index)
+	}
+	if meta.ID == "" && (op == "update" || op == "delete") {
+		return 0, nil, &apiError{http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: id is missing;", meta.Index}
+	}
+
+	switch op {
+	case "index":
+		return s.indexDocument(meta.Index, meta.ID, source, false)
+	case "create":
+		return s.indexDocument(meta.Index, meta.ID, source, true)
+	case "update":
+		return s.updateDocument(meta.Index, meta.ID, source)
+	default:
+		return s.deleteDocument(meta.Index, meta.ID)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Package estest provides an in-process fake Elasticsearch server for unit tests.
+
+The NewServer function starts an HTTP server backed by in-memory indices; pass its URL
+to the client to get a meaningful behaviour without a running cluster:
+
+		srv := estest.NewServer()
+		defer srv.Close()
+
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{srv.URL}})
+
+		es.Index("test", strings.NewReader(`{"title":"Test"}`), es.Index.WithDocumentID("1"))
+		res, _ := es.Search(es.Search.WithIndex("test"), es.Search.WithBody(strings.NewReader(`{"query":{"match":{"title":"test"}}}`)))
+
+The server supports the following APIs:
+
+		GET|HEAD /
+		PUT|GET|HEAD|DELETE /{index}
+		PUT|POST|GET|HEAD|DELETE /{index}/_doc/{id}, POST /{index}/_doc
+		PUT|POST /{index}/_create/{id}, POST /{index}/_update/{id}
+		POST|PUT /_bulk, /{index}/_bulk
+		GET|POST /_search, /{index}/_search, /_count, /{index}/_count
+		POST|GET /_refresh, /{index}/_refresh
+
+The indices are created automatically when a document is indexed, and the documents are visible
+for search immediately. The search supports the "match_all", "match" and "term" queries, and the
+"from" and "size" parameters: the "match" query matches any of the lowercased words in the field value,
+and the "term" query matches the exact field value; nested fields are referenced with a dot.
+
+The errors are returned with the status code and the body used by Elasticsearch,
+such as index_not_found_exception or version_conflict_engine_exception, so they can be inspected
+with the esapi.Response.Err method.
+
+The server records every request; use the Requests method to inspect them, and the Reset method
+to remove the recorded requests and all indices.
+
+The Recorder type is a HTTP transport which records the interactions with a real cluster into a JSON cassette
+file, and replays them in the tests, without sending any request:
+
+		rec, _ := estest.NewRecorder(estest.RecorderConfig{Mode: estest.ModeRecord, Path: "testdata/search.json"})
+		defer rec.Save()
+
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: rec})
+
+In replay mode, which is the default, every request is matched to the first unused interaction with the same method,
+path, query parameters and body; the JSON bodies are compared regardless of the formatting and the order of the keys.
+A request without a matching interaction fails with a *NoMatchError describing the request.
+
+The values of the Authorization, Cookie and Set-Cookie headers are replaced with "[REDACTED]" in the cassette;
+use the RedactHeaders option to configure the list of headers, and the Redact option to redact the bodies.
+*/
+package estest
@@ -0,0 +1,372 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package estest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// index represents an in-memory index.
+//
+type index struct {
+	name  string
+	docs  map[string]*document
+	seqNo int64
+}
+
+// document represents a document stored in the index.
+//
+type document struct {
+	id      string
+	source  json.RawMessage
+	fields  map[string]interface{}
+	version int64
+	seqNo   int64
+}
+
+// apiError represents an error returned by the API.
+//
+type apiError struct {
+	status int
+	typ    string
+	reason string
+	index  string
+}
+
+func newIndex(name string) *index {
+	return &index{name: name, docs: make(map[string]*document)}
+}
+
+func indexNotFound(name string) *apiError {
+	return &apiError{http.StatusNotFound, "index_not_found_exception", fmt.Sprintf("no such index [%s]", name), name}
+}
+
+// validateIndexName returns an error when the name is not a valid index name.
+//
+func validateIndexName(name string) *apiError {
+	switch {
+	case name == "":
+		return &apiError{http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: index is missing;", ""}
+	case strings.ToLower(name) != name:
+		return &apiError{http.StatusBadRequest, "invalid_index_name_exception", fmt.Sprintf("Invalid index name [%s], must be lowercase", name), name}
+	case strings.HasPrefix(name, "_") || strings.HasPrefix(name, "-") || strings.HasPrefix(name, "+"):
+		return &apiError{http.StatusBadRequest, "invalid_index_name_exception", fmt.Sprintf("Invalid index name [%s], must not start with '_', '-', or '+'", name), name}
+	case strings.ContainsAny(name, `\/*?"<>| ,#:`):
+		return &apiError{http.StatusBadRequest, "invalid_index_name_exception", fmt.Sprintf("Invalid index name [%s], must not contain the following characters [ , \", *, \\, <, |, ,, >, /, ?]", name), name}
+	}
+	return nil
+}
+
+// newID returns a random document ID.
+//
+func newID() string {
+	b := make([]byte, 15)
+	rand.Read(b) // errcheck exclude
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// result returns the response for a write operation on the document.
+//
+func (d *document) result(index, result string) map[string]interface{} {
+	return map[string]interface{}{
+		"_index":        index,
+		"_id":           d.id,
+		"_version":      d.version,
+		"result":        result,
+		"_shards":       map[string]interface{}{"total": 1, "successful": 1, "failed": 0},
+		"_seq_no":       d.seqNo,
+		"_primary_term": 1,
+	}
+}
+
+// indexDocument stores the document, creating the index when necessary,
+// and returns the status code and the result.
+//
+func (s *Server) indexDocument(name, id string, source []byte, create bool) (int, map[string]interface{}, *apiError) {
+	if err := validateIndexName(name); err != nil {
+		return 0, nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(source, &fields); err != nil || fields == nil {
+		return 0, nil, &apiError{http.StatusBadRequest, "mapper_parsing_exception", "failed to parse", name}
+	}
+
+	idx, ok := s.indices[name]
+	if !ok {
+		idx = newIndex(name)
+		s.indices[name] = idx
+	}
+
+	if id == "" {
+		id = newID()
+	}
+
+	doc, exists := idx.docs[id]
+	if exists && create {
+		return 0, nil, &apiError{
+			http.StatusConflict,
+			"version_conflict_engine_exception",
+			fmt.Sprintf("[%s]: version conflict, document already exists (current version [%d])", id, doc.version),
+			name,
+		}
+	}
+	if !exists {
+		doc = &document{id: id}
+		idx.docs[id] = doc
+	}
+
+	doc.source = append(json.RawMessage(nil), source...)
+	doc.fields = fields
+	doc.version++
+	doc.seqNo = idx.seqNo
+	idx.seqNo++
+
+	if exists {
+		return http.StatusOK, doc.result(name, "updated"), nil
+	}
+	return http.StatusCreated, doc.result(name, "created"), nil
+}
+
+// updateDocument merges the partial document into the stored document,
+// and returns the status code and the result.
+//
+func (s *Server) updateDocument(name, id string, body []byte) (int, map[string]interface{}, *apiError) {
+	var update struct {
+		Doc         map[string]interface{} `json:"doc"`
+		DocAsUpsert bool                   `json:"doc_as_upsert"`
+		Upsert      json.RawMessage        `json:"upsert"`
+		Script      json.RawMessage        `json:"script"`
+	}
+	if err := json.Unmarshal(body, &update); err != nil {
+		return 0, nil, &apiError{http.StatusBadRequest, "x_content_parse_exception", fmt.Sprintf("failed to parse the update request: %s", err), name}
+	}
+	if update.Script != nil {
+		return 0, nil, &apiError{http.StatusBadRequest, "illegal_argument_exception", "scripted updates are not supported", name}
+	}
+	if update.Doc == nil {
+		return 0, nil, &apiError{http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: script or doc is missing;", name}
+	}
+
+	var doc *document
+	if idx, ok := s.indices[name]; ok {
+		doc = idx.docs[id]
+	}
+
+	if doc == nil {
+		switch {
+		case update.Upsert != nil:
+			return s.indexDocument(name, id, update.Upsert, true)
+		case update.DocAsUpsert:
+			source, _ := json.Marshal(update.Doc)
+			return s.indexDocument(name, id, source, true)
+		}
+		return 0, nil, &apiError{http.StatusNotFound, "document_missing_exception", fmt.Sprintf("[%s]: document missing", id), name}
+	}
+
+	merged := mergeFields(doc.fields, update.Doc)
+
+	if reflect.DeepEqual(merged, doc.fields) {
+		return http.StatusOK, doc.result(name, "noop"), nil
+	}
+
+	source, err := json.Marshal(merged)
+	if err != nil {
+		return 0, nil, &apiError{http.StatusBadRequest, "mapper_parsing_exception", "failed to parse", name}
+	}
+	return s.indexDocument(name, id, source, false)
+}
+
+// deleteDocument removes the document, and returns the status code and the result.
+//
+func (s *Server) deleteDocument(name, id string) (int, map[string]interface{}, *apiError) {
+	if err := validateIndexName(name); err != nil {
+		return 0, nil, err
+	}
+
+	idx, ok := s.indices[name]
+	if !ok {
+		return 0, nil, indexNotFound(name)
+	}
+
+	doc, ok := idx.docs[id]
+	if !ok {
+		doc := document{id: id, version: 1, seqNo: idx.seqNo}
+		idx.seqNo++
+		return http.StatusNotFound, doc.result(name, "not_found"), nil
+	}
+
+	delete(idx.docs, id)
+	doc.version++
+	doc.seqNo = idx.seqNo
+	idx.seqNo++
+
+	return http.StatusOK, doc.result(name, "deleted"), nil
+}
+
+// mergeFields returns a copy of dst with the fields of src, merging the nested objects.
+//
+func mergeFields(dst, src map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(dst)+len(src))
+	for k, v := range dst {
+		out[k] = v
+	}
+	for k, v := range src {
+		if srcObj, ok := v.(map[string]interface{}); ok {
+			if dstObj, ok := out[k].(map[string]interface{}); ok {
+				out[k] = mergeFields(dstObj, srcObj)
+				continue
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r request, name string) {
+	idx, exists := s.indices[name]
+
+	switch r.method {
+	case "PUT":
+		if err := validateIndexName(name); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		if exists {
+			writeError(w, http.StatusBadRequest, "resource_already_exists_exception", fmt.Sprintf("index [%s] already exists", name), name)
+			return
+		}
+		s.indices[name] = newIndex(name)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true, "shards_acknowledged": true, "index": name})
+	case "HEAD":
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	case "GET":
+		if !exists {
+			writeAPIError(w, indexNotFound(name))
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			idx.name: map[string]interface{}{
+				"aliases":  map[string]interface{}{},
+				"mappings": map[string]interface{}{},
+				"settings": map[string]interface{}{
+					"index": map[string]interface{}{
+						"number_of_shards":   "1",
+						"number_of_replicas": "0",
+						"provided_name":      idx.name,
+					},
+				},
+			},
+		})
+	case "DELETE":
+		if !exists {
+			writeAPIError(w, indexNotFound(name))
+			return
+		}
+		delete(s.indices, name)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+	default:
+		writeMethodNotAllowed(w, r, "DELETE,GET,HEAD,PUT")
+	}
+}
+
+func (s *Server) handleDocument(w http.ResponseWriter, r request, name, id string) {
+	switch r.method {
+	case "PUT", "POST":
+		s.handleIndexDocument(w, r, name, id, r.query.Get("op_type") == "create")
+	case "GET", "HEAD":
+		idx, ok := s.indices[name]
+		if !ok {
+			if r.method == "HEAD" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writeAPIError(w, indexNotFound(name))
+			return
+		}
+
+		doc, ok := idx.docs[id]
+		if r.method == "HEAD" {
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"_index": name, "_id": id, "found": false})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"_index":        name,
+			"_id":           id,
+			"_version":      doc.version,
+			"_seq_no":       doc.seqNo,
+			"_primary_term": 1,
+			"found":         true,
+			"_source":       doc.source,
+		})
+	case "DELETE":
+		status, res, err := s.deleteDocument(name, id)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writeJSON(w, status, res)
+	default:
+		writeMethodNotAllowed(w, r, "DELETE,GET,HEAD,POST,PUT")
+	}
+}
+
+func (s *Server) handleIndexDocument(w http.ResponseWriter, r request, name, id string, create bool) {
+	status, res, err := s.indexDocument(name, id, r.body, create)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeJSON(w, status, res)
+}
+
+func (s *Server) handleUpdateDocument(w http.ResponseWriter, r request, name, id string) {
+	status, res, err := s.updateDocument(name, id, r.body)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeJSON(w, status, res)
+}
+
+func (s *Server) handleRefresh(w http.ResponseWriter, r request, expr string) {
+	if r.method != "POST" && r.method != "GET" {
+		writeMethodNotAllowed(w, r, "GET,POST")
+		return
+	}
+
+	indices, err := s.resolveIndices(expr)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	n := len(indices)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"_shards": map[string]interface{}{"total": n, "successful": n, "failed": 0},
+	})
+}
+
+func writeAPIError(w http.ResponseWriter, err *apiError) {
+	writeError(w, err.status, err.typ, err.reason, err.index)
+}
@@ -0,0 +1,389 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package estest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode represents the mode of the recorder.
+//
+type Mode int
+
+const (
+	// ModeReplay returns the responses recorded in the cassette, without sending the requests.
+	ModeReplay Mode = iota
+	// ModeRecord sends the requests, and records the interactions in the cassette.
+	ModeRecord
+)
+
+const redacted = "[REDACTED]"
+
+var defaultRedactHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// RecorderConfig represents the configuration of the recorder.
+//
+type RecorderConfig struct {
+	Mode Mode   // Record or replay the interactions. Default: replay.
+	Path string // The path of the cassette file.
+
+	// The transport for sending the requests in record mode. Default: http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// The headers with the values replaced by "[REDACTED]" in the cassette.
+	// Default: Authorization, Cookie, Set-Cookie.
+	RedactHeaders []string
+
+	// Optional function to redact the interaction before it is stored, eg. to remove secrets from the bodies.
+	Redact func(*Interaction)
+}
+
+// Recorder represents a HTTP transport which records the interactions with Elasticsearch in a cassette,
+// or replays them from the cassette.
+//
+// Pass it as the transport in the client configuration:
+//
+//     rec, _ := estest.NewRecorder(estest.RecorderConfig{Mode: estest.ModeReplay, Path: "testdata/search.json"})
+//     es, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: rec})
+//
+type Recorder struct {
+	mu sync.Mutex
+
+	mode          Mode
+	path          string
+	transport     http.RoundTripper
+	redactHeaders []string
+	redact        func(*Interaction)
+
+	cassette Cassette
+	used     []bool
+}
+
+// Cassette represents the recorded interactions.
+//
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction represents a recorded request and response.
+//
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest represents a recorded request.
+//
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse represents a recorded response.
+//
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// NoMatchError is returned in replay mode for a request without a matching interaction in the cassette.
+//
+type NoMatchError struct {
+	Path    string // The path of the cassette file.
+	Request RecordedRequest
+}
+
+// Error returns the error as a string.
+//
+func (e *NoMatchError) Error() string {
+	var b strings.Builder
+	b.WriteString("estest: no recorded interaction in ")
+	b.WriteString(e.Path)
+	b.WriteString(" matches the request ")
+	b.WriteString(e.Request.Method)
+	b.WriteString(" ")
+	b.WriteString(e.Request.Path)
+	if e.Request.Query != "" {
+		b.WriteString("?")
+		b.WriteString(e.Request.Query)
+	}
+	if e.Request.Body != "" {
+		body := e.Request.Body
+		if len(body) > 256 {
+			body = body[:256] + "..."
+		}
+		b.WriteString(" with body ")
+		b.WriteString(body)
+	}
+	return b.String()
+}
+
+// NewRecorder creates a new recorder; in replay mode, the cassette is loaded from the file.
+//
+func NewRecorder(cfg RecorderConfig) (*Recorder, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("estest: missing cassette path")
+	}
+
+	if cfg.Transport == nil {
+		cfg.Transport = http.DefaultTransport
+	}
+
+	if cfg.RedactHeaders == nil {
+		cfg.RedactHeaders = defaultRedactHeaders
+	}
+
+	r := Recorder{
+		mode:          cfg.Mode,
+		path:          cfg.Path,
+		transport:     cfg.Transport,
+		redactHeaders: cfg.RedactHeaders,
+		redact:        cfg.Redact,
+	}
+
+	if r.mode == ModeReplay {
+		b, err := ioutil.ReadFile(r.path)
+		if err != nil {
+			return nil, fmt.Errorf("estest: cannot read cassette: %s", err)
+		}
+		if err := json.Unmarshal(b, &r.cassette); err != nil {
+			return nil, fmt.Errorf("estest: cannot parse cassette %s: %s", r.path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return &r, nil
+}
+
+// RoundTrip records or replays the request.
+//
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rreq, err := newRecordedRequest(req, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(req, body, rreq)
+	}
+	return r.replay(req, rreq)
+}
+
+// Interactions returns the recorded interactions.
+//
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// Save writes the recorded interactions to the cassette file; it has no effect in replay mode.
+//
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("estest: cannot encode cassette: %s", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("estest: cannot write cassette: %s", err)
+	}
+	if err := ioutil.WriteFile(r.path, append(b, '\n'), 0644); err != nil {
+		return fmt.Errorf("estest: cannot write cassette: %s", err)
+	}
+	return nil
+}
+
+// record sends the request, and stores the interaction.
+//
+func (r *Recorder) record(req *http.Request, body []byte, rreq RecordedRequest) (*http.Response, error) {
+	out := new(http.Request)
+	*out = *req
+	if body != nil {
+		out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	res, err := r.transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+
+	var resBody []byte
+	if res.Body != nil {
+		resBody, err = ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+
+	i := Interaction{
+		Request: rreq,
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Header:     cloneHeader(res.Header),
+			Body:       string(resBody),
+		},
+	}
+	i.Request.Header = cloneHeader(req.Header)
+	i.Request.Header.Del("Content-Encoding") // The body is stored decompressed
+
+	for _, h := range r.redactHeaders {
+		redactHeader(i.Request.Header, h)
+		redactHeader(i.Response.Header, h)
+	}
+	if r.redact != nil {
+		r.redact(&i)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+	r.mu.Unlock()
+
+	return res, nil
+}
+
+// replay returns the response of the first unused interaction matching the request.
+//
+func (r *Recorder) replay(req *http.Request, rreq RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for n, i := range r.cassette.Interactions {
+		if r.used[n] || !i.Request.matches(rreq) {
+			continue
+		}
+		r.used[n] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+			StatusCode:    i.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        cloneHeader(i.Response.Header),
+			Body:          ioutil.NopCloser(strings.NewReader(i.Response.Body)),
+			ContentLength: int64(len(i.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, &NoMatchError{Path: r.path, Request: rreq}
+}
+
+// newRecordedRequest returns the request with the normalized query and body.
+//
+func newRecordedRequest(req *http.Request, body []byte) (RecordedRequest, error) {
+	body, err := decodeBody(req.Header, body)
+	if err != nil {
+		return RecordedRequest{}, fmt.Errorf("estest: cannot read request body: %s", err)
+	}
+
+	return RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query().Encode(),
+		Body:   string(body),
+	}, nil
+}
+
+// matches returns true when the request has the same method, path, query and body as other;
+// the JSON bodies are compared regardless of the formatting and the order of the keys.
+//
+func (r RecordedRequest) matches(other RecordedRequest) bool {
+	return r.Method == other.Method &&
+		r.Path == other.Path &&
+		normalizeQuery(r.Query) == normalizeQuery(other.Query) &&
+		normalizeBody(r.Body) == normalizeBody(other.Body)
+}
+
+func normalizeQuery(q string) string {
+	v, err := url.ParseQuery(q)
+	if err != nil {
+		return q
+	}
+	return v.Encode()
+}
+
+// normalizeBody returns the body with every JSON line re-encoded with sorted keys and without whitespace.
+//
+func normalizeBody(body string) string {
+	var out []string
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var v interface{}
+		if err := json.Unmarshal([]byte(line), &v); err == nil {
+			if b, err := json.Marshal(v); err == nil {
+				line = string(b)
+			}
+		}
+		out = append(out, line)
+	}
+	if len(out) == 1 {
+		return out[0]
+	}
+
+	// Re-encode the whole body, when it is a single JSON document spanning multiple lines
+	var v interface{}
+	if err := json.Unmarshal([]byte(body), &v); err == nil {
+		if b, err := json.Marshal(v); err == nil {
+			return string(b)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+func redactHeader(h http.Header, name string) {
+	name = http.CanonicalHeaderKey(name)
+	for i := range h[name] {
+		h[name][i] = redacted
+	}
+}
+
+func cloneHeader(h http.Header) http.Header {
+	if h == nil {
+		return nil
+	}
+	h2 := make(http.Header, len(h))
+	for k, vv := range h {
+		h2[k] = append([]string(nil), vv...)
+	}
+	return h2
+}
@@ -0,0 +1,157 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// +build !integration
+
+package estest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+func TestRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "estest")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "testdata", "cassette.json")
+
+	t.Run("Record", func(t *testing.T) {
+		srv := NewServer()
+		defer srv.Close()
+
+		rec, err := NewRecorder(RecorderConfig{
+			Mode:          ModeRecord,
+			Path:          path,
+			RedactHeaders: []string{"authorization", "X-Tenant"},
+			Redact: func(i *Interaction) {
+				i.Request.Body = strings.Replace(i.Request.Body, "secret", "xxx", -1)
+				i.Response.Body = strings.Replace(i.Response.Body, "secret", "xxx", -1)
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{
+			Addresses:           []string{srv.URL},
+			Username:            "elastic",
+			Password:            "changeme",
+			Header:              map[string][]string{"X-Tenant": {"abc"}},
+			CompressRequestBody: true,
+			Transport:           rec,
+		})
+
+		res, err := es.Index("test", strings.NewReader(`{"title":"Test","token":"secret"}`), es.Index.WithDocumentID("1"), es.Index.WithRefresh("true"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		res.Body.Close()
+
+		for i := 0; i < 2; i++ {
+			res, err = es.Get("test", "1")
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			res.Body.Close()
+			if i == 0 {
+				es.Delete("test", "1")
+			}
+		}
+
+		if err := rec.Save(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		cassette := string(b)
+
+		if strings.Contains(cassette, "Basic ") || strings.Contains(cassette, `"abc"`) {
+			t.Errorf("Expected the headers to be redacted, got: %s", cassette)
+		}
+		if strings.Contains(cassette, "secret") {
+			t.Errorf("Expected the body to be redacted, got: %s", cassette)
+		}
+		if n := len(rec.Interactions()); n != 4 {
+			t.Errorf("Unexpected number of interactions: %d", n)
+		}
+	})
+
+	t.Run("Replay", func(t *testing.T) {
+		rec, err := NewRecorder(RecorderConfig{Path: path})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{"http://example.com"}, Transport: rec})
+
+		res, err := es.Index(
+			"test",
+			strings.NewReader("{\n  \"token\": \"xxx\",\n  \"title\": \"Test\"\n}"),
+			es.Index.WithRefresh("true"),
+			es.Index.WithDocumentID("1"),
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		res.Body.Close()
+		if res.StatusCode != 201 {
+			t.Errorf("Unexpected status: %s", res.Status())
+		}
+
+		for _, expected := range []int{200, 404} {
+			res, err = es.Get("test", "1")
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			res.Body.Close()
+			if res.StatusCode != expected {
+				t.Errorf("Unexpected status, want=%d, got=%d", expected, res.StatusCode)
+			}
+		}
+
+		_, err = es.Get("test", "2")
+		if err == nil {
+			t.Fatalf("Expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "no recorded interaction") || !strings.Contains(err.Error(), "GET /test/_doc/2") {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Missing cassette", func(t *testing.T) {
+		if _, err := NewRecorder(RecorderConfig{Path: filepath.Join(dir, "missing.json")}); err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+}
+
+func TestNormalizeBody(t *testing.T) {
+	var tt = []struct {
+		a, b  string
+		equal bool
+	}{
+		{`{"a":1,"b":2}`, "{\n  \"b\": 2,\n  \"a\": 1\n}", true},
+		{"{\"index\":{}}\n{\"a\":1}\n", "{ \"index\" : {} }\n{ \"a\" : 1 }\n", true},
+		{`{"a":1}`, `{"a":2}`, false},
+		{`foo`, `foo`, true},
+	}
+
+	for _, tc := range tt {
+		if actual := normalizeBody(tc.a) == normalizeBody(tc.b); actual != tc.equal {
+			t.Errorf("%q == %q: want=%v, got=%v", tc.a, tc.b, tc.equal, actual)
+		}
+	}
+}
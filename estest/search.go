@@ -0,0 +1,323 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package estest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const defaultSearchSize = 10
+
+// query represents a compiled search query.
+//
+type query func(fields map[string]interface{}) (float64, bool)
+
+// hit represents a document matching the query.
+//
+type hit struct {
+	index string
+	doc   *document
+	score float64
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r request, expr string, count bool) {
+	if r.method != "GET" && r.method != "POST" {
+		writeMethodNotAllowed(w, r, "GET,POST")
+		return
+	}
+
+	var body struct {
+		Query map[string]json.RawMessage `json:"query"`
+		From  *int                       `json:"from"`
+		Size  *int                       `json:"size"`
+	}
+	if len(strings.TrimSpace(string(r.body))) > 0 {
+		if err := json.Unmarshal(r.body, &body); err != nil {
+			writeError(w, http.StatusBadRequest, "parsing_exception", fmt.Sprintf("failed to parse the search request: %s", err), "")
+			return
+		}
+	}
+
+	from, size := 0, defaultSearchSize
+	if body.From != nil {
+		from = *body.From
+	}
+	if body.Size != nil {
+		size = *body.Size
+	}
+	for _, p := range []struct {
+		name string
+		val  *int
+	}{{"from", &from}, {"size", &size}} {
+		if v := r.query.Get(p.name); v != "" {
+			i, err := strconv.Atoi(v)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("Failed to parse int parameter [%s] with value [%s]", p.name, v), "")
+				return
+			}
+			*p.val = i
+		}
+		if *p.val < 0 {
+			writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("[%s] parameter cannot be negative, found [%d]", p.name, *p.val), "")
+			return
+		}
+	}
+
+	q, err := compileQuery(body.Query)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	indices, err := s.resolveIndices(expr)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	var hits []hit
+	for _, idx := range indices {
+		for _, doc := range idx.docs {
+			if score, ok := q(doc.fields); ok {
+				hits = append(hits, hit{index: idx.name, doc: doc, score: score})
+			}
+		}
+	}
+
+	shards := map[string]interface{}{"total": len(indices), "successful": len(indices), "skipped": 0, "failed": 0}
+
+	if count {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"count": len(hits), "_shards": shards})
+		return
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		if hits[i].index != hits[j].index {
+			return hits[i].index < hits[j].index
+		}
+		return hits[i].doc.seqNo < hits[j].doc.seqNo
+	})
+
+	var maxScore interface{}
+	if len(hits) > 0 {
+		maxScore = hits[0].score
+	}
+	total := len(hits)
+
+	if from > len(hits) {
+		from = len(hits)
+	}
+	hits = hits[from:]
+	if size < len(hits) {
+		hits = hits[:size]
+	}
+
+	out := make([]interface{}, 0, len(hits))
+	for _, h := range hits {
+		out = append(out, map[string]interface{}{
+			"_index":  h.index,
+			"_id":     h.doc.id,
+			"_score":  h.score,
+			"_source": h.doc.source,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"took":      1,
+		"timed_out": false,
+		"_shards":   shards,
+		"hits": map[string]interface{}{
+			"total":     map[string]interface{}{"value": total, "relation": "eq"},
+			"max_score": maxScore,
+			"hits":      out,
+		},
+	})
+}
+
+// resolveIndices returns the indices for a comma-separated list of names and wildcard patterns.
+//
+func (s *Server) resolveIndices(expr string) ([]*index, *apiError) {
+	var (
+		out  []*index
+		seen = make(map[string]bool)
+	)
+
+	add := func(idx *index) {
+		if !seen[idx.name] {
+			seen[idx.name] = true
+			out = append(out, idx)
+		}
+	}
+
+	for _, name := range strings.Split(expr, ",") {
+		switch {
+		case name == "_all" || name == "*":
+			for _, idx := range s.indices {
+				add(idx)
+			}
+		case strings.Contains(name, "*"):
+			for _, idx := range s.indices {
+				if ok, _ := path.Match(name, idx.name); ok {
+					add(idx)
+				}
+			}
+		default:
+			idx, ok := s.indices[name]
+			if !ok {
+				return nil, indexNotFound(name)
+			}
+			add(idx)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].name < out[j].name })
+	return out, nil
+}
+
+// compileQuery returns the query for the JSON query definition.
+//
+func compileQuery(def map[string]json.RawMessage) (query, *apiError) {
+	if len(def) == 0 {
+		return matchAll, nil
+	}
+	if len(def) > 1 {
+		return nil, &apiError{http.StatusBadRequest, "parsing_exception", "[query] malformed query, expected [END_OBJECT] but found [FIELD_NAME]", ""}
+	}
+
+	for name, body := range def {
+		switch name {
+		case "match_all":
+			return matchAll, nil
+		case "match":
+			field, value, err := parseFieldQuery(name, body, "query")
+			if err != nil {
+				return nil, err
+			}
+			return matchQuery(field, value), nil
+		case "term":
+			field, value, err := parseFieldQuery(name, body, "value")
+			if err != nil {
+				return nil, err
+			}
+			return termQuery(field, value), nil
+		default:
+			return nil, &apiError{http.StatusBadRequest, "parsing_exception", fmt.Sprintf("unknown query [%s]", name), ""}
+		}
+	}
+	return nil, nil
+}
+
+// parseFieldQuery returns the field and the value for a query in the short form, eg. {"title":"foo"},
+// or in the long form, eg. {"title":{"query":"foo"}}.
+//
+func parseFieldQuery(name string, body json.RawMessage, key string) (string, interface{}, *apiError) {
+	var def map[string]interface{}
+	if err := json.Unmarshal(body, &def); err != nil || len(def) != 1 {
+		return "", nil, &apiError{http.StatusBadRequest, "parsing_exception", fmt.Sprintf("[%s] query doesn't support multiple fields", name), ""}
+	}
+
+	for field, v := range def {
+		if obj, ok := v.(map[string]interface{}); ok {
+			value, ok := obj[key]
+			if !ok {
+				return "", nil, &apiError{http.StatusBadRequest, "parsing_exception", fmt.Sprintf("[%s] query is missing the [%s] parameter", name, key), ""}
+			}
+			return field, value, nil
+		}
+		return field, v, nil
+	}
+	return "", nil, nil
+}
+
+func matchAll(map[string]interface{}) (float64, bool) {
+	return 1.0, true
+}
+
+// matchQuery returns a query matching the documents containing any of the words of value in field;
+// the score is the number of matching words.
+//
+func matchQuery(field string, value interface{}) query {
+	words := tokenize(fmt.Sprint(value))
+	return func(fields map[string]interface{}) (float64, bool) {
+		var score float64
+		for _, v := range lookupField(fields, field) {
+			tokens := make(map[string]bool)
+			for _, t := range tokenize(fmt.Sprint(v)) {
+				tokens[t] = true
+			}
+			for _, w := range words {
+				if tokens[w] {
+					score++
+				}
+			}
+		}
+		return score, score > 0
+	}
+}
+
+// termQuery returns a query matching the documents with the exact value in field.
+//
+func termQuery(field string, value interface{}) query {
+	expected := fmt.Sprint(value)
+	return func(fields map[string]interface{}) (float64, bool) {
+		for _, v := range lookupField(fields, field) {
+			if fmt.Sprint(v) == expected {
+				return 1.0, true
+			}
+		}
+		return 0, false
+	}
+}
+
+// lookupField returns the values of the field, referenced with a dot for the nested objects;
+// the arrays are flattened.
+//
+func lookupField(fields map[string]interface{}, name string) []interface{} {
+	values := []interface{}{fields}
+	for _, key := range strings.Split(name, ".") {
+		var next []interface{}
+		for _, v := range flatten(values) {
+			if obj, ok := v.(map[string]interface{}); ok {
+				if fv, ok := obj[key]; ok {
+					next = append(next, fv)
+				}
+			}
+		}
+		values = next
+	}
+	return flatten(values)
+}
+
+func flatten(values []interface{}) []interface{} {
+	var out []interface{}
+	for _, v := range values {
+		if arr, ok := v.([]interface{}); ok {
+			out = append(out, flatten(arr)...)
+			continue
+		}
+		if v != nil {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// tokenize returns the lowercased words of s.
+//
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
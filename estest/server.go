@@ -0,0 +1,259 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package estest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	clusterName = "estest"
+	version     = "8.0.0-SNAPSHOT"
+)
+
+// Server represents a fake Elasticsearch server with in-memory indices.
+//
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	indices  map[string]*index
+	requests []Request
+}
+
+// Request represents a request recorded by the server.
+//
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// NewServer starts and returns a new server; call Close to shut it down.
+//
+func NewServer() *Server {
+	s := Server{indices: make(map[string]*index)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return &s
+}
+
+// Requests returns the requests recorded by the server.
+//
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// Reset removes the recorded requests and all indices.
+//
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.indices = make(map[string]*index)
+	s.requests = nil
+}
+
+// handle records the request, and dispatches it to the API handler.
+//
+func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
+	body, err := readBody(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "parse_exception", fmt.Sprintf("cannot read request body: %s", err), "")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query(),
+		Header: req.Header,
+		Body:   body,
+	})
+
+	w.Header().Set("X-Elastic-Product", "Elasticsearch")
+
+	parts, err := splitPath(req.URL)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "illegal_argument_exception", err.Error(), "")
+		return
+	}
+
+	r := request{method: req.Method, parts: parts, query: req.URL.Query(), body: body}
+
+	switch {
+	case len(parts) == 0:
+		s.handleInfo(w, r)
+	case len(parts) == 1 && parts[0] == "_bulk":
+		s.handleBulk(w, r, "")
+	case len(parts) == 1 && (parts[0] == "_search" || parts[0] == "_count"):
+		s.handleSearch(w, r, "_all", parts[0] == "_count")
+	case len(parts) == 1 && parts[0] == "_refresh":
+		s.handleRefresh(w, r, "_all")
+	case len(parts) == 1 && !strings.HasPrefix(parts[0], "_"):
+		s.handleIndex(w, r, parts[0])
+	case len(parts) == 2 && parts[1] == "_bulk":
+		s.handleBulk(w, r, parts[0])
+	case len(parts) == 2 && (parts[1] == "_search" || parts[1] == "_count"):
+		s.handleSearch(w, r, parts[0], parts[1] == "_count")
+	case len(parts) == 2 && parts[1] == "_refresh":
+		s.handleRefresh(w, r, parts[0])
+	case len(parts) == 2 && parts[1] == "_doc" && r.method == "POST":
+		s.handleIndexDocument(w, r, parts[0], "", false)
+	case len(parts) == 3 && parts[1] == "_doc":
+		s.handleDocument(w, r, parts[0], parts[2])
+	case len(parts) == 3 && parts[1] == "_create" && (r.method == "PUT" || r.method == "POST"):
+		s.handleIndexDocument(w, r, parts[0], parts[2], true)
+	case len(parts) == 3 && parts[1] == "_update" && r.method == "POST":
+		s.handleUpdateDocument(w, r, parts[0], parts[2])
+	default:
+		s.handleUnknown(w, r, req)
+	}
+}
+
+// request represents the parsed HTTP request.
+//
+type request struct {
+	method string
+	parts  []string
+	query  url.Values
+	body   []byte
+}
+
+func (s *Server) handleInfo(w http.ResponseWriter, r request) {
+	switch r.method {
+	case "HEAD":
+		w.WriteHeader(http.StatusOK)
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"name":         clusterName,
+			"cluster_name": clusterName,
+			"cluster_uuid": "estest",
+			"version": map[string]interface{}{
+				"number":       version,
+				"build_flavor": "default",
+			},
+			"tagline": "You Know, for Search",
+		})
+	default:
+		writeMethodNotAllowed(w, r, "GET,HEAD")
+	}
+}
+
+func (s *Server) handleUnknown(w http.ResponseWriter, r request, req *http.Request) {
+	writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+		"error":  fmt.Sprintf("no handler found for uri [%s] and method [%s]", req.URL.RequestURI(), r.method),
+		"status": http.StatusBadRequest,
+	})
+}
+
+// readBody returns the request body, decompressed when necessary.
+//
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	defer req.Body.Close()
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeBody(req.Header, body)
+}
+
+// decodeBody returns the body decompressed according to the Content-Encoding header.
+//
+func decodeBody(header http.Header, body []byte) ([]byte, error) {
+	if header.Get("Content-Encoding") != "gzip" || len(body) == 0 {
+		return body, nil
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	return ioutil.ReadAll(zr)
+}
+
+// splitPath returns the unescaped segments of the URL path.
+//
+func splitPath(u *url.URL) ([]string, error) {
+	var parts []string
+	for _, p := range strings.Split(u.EscapedPath(), "/") {
+		if p == "" {
+			continue
+		}
+		p, err := url.PathUnescape(p)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, p)
+	}
+	return parts, nil
+}
+
+// writeJSON writes v as the JSON response body with the status code.
+//
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v) // errcheck exclude
+}
+
+// writeError writes the error in the format used by Elasticsearch.
+//
+func writeError(w http.ResponseWriter, status int, typ, reason, index string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error":  newErrorCause(typ, reason, index, true),
+		"status": status,
+	})
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter, r request, allowed string) {
+	w.Header().Set("Allow", allowed)
+	writeJSON(w, http.StatusMethodNotAllowed, map[string]interface{}{
+		"error": fmt.Sprintf(
+			"Incorrect HTTP method for uri [/%s] and method [%s], allowed: [%s]",
+			strings.Join(r.parts, "/"), r.method, allowed),
+		"status": http.StatusMethodNotAllowed,
+	})
+}
+
+// newErrorCause returns the error object, optionally with the root cause.
+//
+func newErrorCause(typ, reason, index string, rootCause bool) map[string]interface{} {
+	e := map[string]interface{}{"type": typ, "reason": reason}
+	if index != "" {
+		e["index"] = index
+	}
+	if rootCause {
+		cause := make(map[string]interface{}, len(e))
+		for k, v := range e {
+			cause[k] = v
+		}
+		e["root_cause"] = []interface{}{cause}
+	}
+	return e
+}
@@ -0,0 +1,324 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// +build !integration
+
+package estest
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+func newClient(t *testing.T, srv *Server) *elasticsearch.Client {
+	es, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{srv.URL}, DisableRetry: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return es
+}
+
+func decode(t *testing.T, res *esapi.Response, v interface{}) {
+	defer res.Body.Close()
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		t.Fatalf("Error parsing the response: %s", err)
+	}
+}
+
+func TestServer(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	es := newClient(t, srv)
+
+	t.Run("Info", func(t *testing.T) {
+		res, err := es.Info()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		var info struct {
+			Version struct{ Number string }
+		}
+		decode(t, res, &info)
+
+		if info.Version.Number == "" {
+			t.Errorf("Expected the version in the response")
+		}
+	})
+
+	t.Run("Index, get and delete", func(t *testing.T) {
+		res, err := es.Index("test", strings.NewReader(`{"title":"Test"}`), es.Index.WithDocumentID("1"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if res.StatusCode != 201 {
+			t.Errorf("Unexpected status: %s", res.Status())
+		}
+
+		res, err = es.Index("test", strings.NewReader(`{"title":"Test 2"}`), es.Index.WithDocumentID("1"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		var result struct {
+			Result  string
+			Version int `json:"_version"`
+		}
+		decode(t, res, &result)
+
+		if result.Result != "updated" || result.Version != 2 {
+			t.Errorf("Unexpected result: %+v", result)
+		}
+
+		res, err = es.Get("test", "1")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		var doc struct {
+			Found  bool
+			Source map[string]interface{} `json:"_source"`
+		}
+		decode(t, res, &doc)
+
+		if !doc.Found || doc.Source["title"] != "Test 2" {
+			t.Errorf("Unexpected document: %+v", doc)
+		}
+
+		res, err = es.Delete("test", "1")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		res.Body.Close()
+		if res.StatusCode != 200 {
+			t.Errorf("Unexpected status: %s", res.Status())
+		}
+
+		res, err = es.Get("test", "1")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		res.Body.Close()
+		if res.StatusCode != 404 {
+			t.Errorf("Unexpected status: %s", res.Status())
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		res, err := es.Get("missing", "1")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if err := res.Err(); !esapi.IsNotFound(err) || !strings.Contains(err.Error(), "index_not_found_exception") {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		es.Create("test", "2", strings.NewReader(`{"title":"Test"}`))
+		res, err = es.Create("test", "2", strings.NewReader(`{"title":"Test"}`))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if err := res.Err(); !esapi.IsVersionConflict(err) {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		res, err = es.Indices.Create("test")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if err := res.Err(); !esapi.IsIndexAlreadyExists(err) {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		res, err = es.Search(es.Search.WithBody(strings.NewReader(`{"query":{"fuzzy":{"title":"tset"}}}`)))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if err := res.Err(); err == nil || !strings.Contains(err.Error(), "parsing_exception") {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Bulk and search", func(t *testing.T) {
+		srv.Reset()
+
+		body := `{"index":{"_id":"1"}}
+{"title":"The Quick Brown Fox","tags":["animal","fast"],"meta":{"year":2019}}
+{"index":{"_id":"2"}}
+{"title":"A lazy dog","tags":["animal"],"meta":{"year":2020}}
+{"create":{"_index":"other","_id":"3"}}
+{"title":"The fox and the dog"}
+{"update":{"_id":"2"}}
+{"doc":{"meta":{"rating":5}}}
+{"delete":{"_id":"4"}}
+{"create":{"_id":"1"}}
+{"title":"Duplicate"}
+`
+		res, err := es.Bulk(strings.NewReader(body), es.Bulk.WithIndex("test"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		var bulk struct {
+			Errors bool
+			Items  []map[string]struct {
+				Status int
+				Result string
+				Error  *esapi.ErrorCause
+			}
+		}
+		decode(t, res, &bulk)
+
+		if !bulk.Errors || len(bulk.Items) != 6 {
+			t.Fatalf("Unexpected response: %+v", bulk)
+		}
+		for i, expected := range []int{201, 201, 201, 200, 404, 409} {
+			for _, item := range bulk.Items[i] {
+				if item.Status != expected {
+					t.Errorf("Unexpected status for item %d: %d", i, item.Status)
+				}
+			}
+		}
+		if e := bulk.Items[5]["create"].Error; e == nil || e.Type != "version_conflict_engine_exception" {
+			t.Errorf("Unexpected error: %+v", e)
+		}
+
+		var tt = []struct {
+			index    string
+			query    string
+			expected []string
+		}{
+			{"test", `{"query":{"match_all":{}}}`, []string{"1", "2"}},
+			{"", ``, []string{"3", "1", "2"}},
+			{"test,other", `{"query":{"match":{"title":"fox dog"}}}`, []string{"3", "1", "2"}},
+			{"test", `{"query":{"match":{"title":{"query":"QUICK"}}}}`, []string{"1"}},
+			{"test", `{"query":{"term":{"tags":"fast"}}}`, []string{"1"}},
+			{"test", `{"query":{"term":{"meta.year":{"value":2020}}}}`, []string{"2"}},
+			{"test", `{"query":{"term":{"meta.rating":5}}}`, []string{"2"}},
+			{"oth*", `{"query":{"match_all":{}},"size":1}`, []string{"3"}},
+			{"test", `{"query":{"match_all":{}},"from":1}`, []string{"2"}},
+		}
+
+		for _, tc := range tt {
+			opts := []func(*esapi.SearchRequest){es.Search.WithBody(strings.NewReader(tc.query))}
+			if tc.index != "" {
+				opts = append(opts, es.Search.WithIndex(tc.index))
+			}
+
+			res, err := es.Search(opts...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if res.IsError() {
+				t.Fatalf("Unexpected response: %s", res)
+			}
+
+			var r struct {
+				Hits struct {
+					Hits []struct {
+						ID string `json:"_id"`
+					}
+				}
+			}
+			decode(t, res, &r)
+
+			var ids []string
+			for _, h := range r.Hits.Hits {
+				ids = append(ids, h.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tc.expected, ",") {
+				t.Errorf("%s %s: want=%v, got=%v", tc.index, tc.query, tc.expected, ids)
+			}
+		}
+
+		res, err = es.Count(es.Count.WithIndex("test"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		var count struct{ Count int }
+		decode(t, res, &count)
+
+		if count.Count != 2 {
+			t.Errorf("Unexpected count: %d", count.Count)
+		}
+	})
+
+	t.Run("Invalid from and size", func(t *testing.T) {
+		var tt = []struct {
+			opts     []func(*esapi.SearchRequest)
+			expected string
+		}{
+			{[]func(*esapi.SearchRequest){es.Search.WithFrom(-1)}, "[from] parameter cannot be negative, found [-1]"},
+			{[]func(*esapi.SearchRequest){es.Search.WithSize(-5)}, "[size] parameter cannot be negative, found [-5]"},
+			{[]func(*esapi.SearchRequest){es.Search.WithBody(strings.NewReader(`{"from":-10}`))}, "[from] parameter cannot be negative, found [-10]"},
+			{[]func(*esapi.SearchRequest){es.Search.WithBody(strings.NewReader(`{"size":-1}`))}, "[size] parameter cannot be negative, found [-1]"},
+		}
+
+		for _, tc := range tt {
+			res, err := es.Search(tc.opts...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			err = res.Err()
+			if res.StatusCode != 400 || err == nil {
+				t.Fatalf("Expected 400 error, got: %s", res)
+			}
+			if !strings.Contains(err.Error(), "illegal_argument_exception") || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("Unexpected error: %s", err)
+			}
+		}
+	})
+
+	t.Run("Requests", func(t *testing.T) {
+		srv.Reset()
+
+		es.Index("test", strings.NewReader(`{"title":"Test"}`), es.Index.WithOpaqueID("abc"), es.Index.WithRefresh("true"))
+
+		reqs := srv.Requests()
+		if len(reqs) != 1 {
+			t.Fatalf("Unexpected number of requests: %d", len(reqs))
+		}
+
+		req := reqs[0]
+		if req.Method != "POST" || req.Path != "/test/_doc" || req.Query.Get("refresh") != "true" {
+			t.Errorf("Unexpected request: %s %s?%s", req.Method, req.Path, req.Query.Encode())
+		}
+		if req.Header.Get("X-Opaque-Id") != "abc" {
+			t.Errorf("Unexpected header: %v", req.Header)
+		}
+		if string(req.Body) != `{"title":"Test"}` {
+			t.Errorf("Unexpected body: %s", req.Body)
+		}
+	})
+
+	t.Run("Compressed request body", func(t *testing.T) {
+		srv.Reset()
+
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{srv.URL}, CompressRequestBody: true})
+		res, err := es.Index("test", strings.NewReader(`{"title":"Test"}`), es.Index.WithDocumentID("1"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		res.Body.Close()
+		if res.StatusCode != 201 {
+			t.Errorf("Unexpected status: %s", res.Status())
+		}
+	})
+
+	t.Run("Unknown endpoint", func(t *testing.T) {
+		res, err := es.Cat.Health()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if err := res.Err(); err == nil || !strings.Contains(err.Error(), "no handler found") {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
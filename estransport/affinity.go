@@ -0,0 +1,139 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package estransport
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// preferenceEndpoints defines the API endpoints which accept the preference parameter.
+//
+var preferenceEndpoints = map[string]bool{
+	"count":           true,
+	"delete_by_query": true,
+	"exists":          true,
+	"exists_source":   true,
+	"explain":         true,
+	"get":             true,
+	"get_source":      true,
+	"mget":            true,
+	"mtermvectors":    true,
+	"search":          true,
+	"search_shards":   true,
+	"search_template": true,
+	"termvectors":     true,
+	"update_by_query": true,
+}
+
+// PreferenceSelector defines the interface for selectors which set the preference parameter
+// of the requests, so the shard copies are chosen in the same way as the connections.
+//
+// The parameter is only set for the API endpoints which accept it, and only when the request
+// doesn't specify it already.
+//
+type PreferenceSelector interface {
+	Selector
+	Preference() string
+}
+
+// AffinitySelector prefers the connections to nodes with a matching attribute, eg. an availability zone.
+//
+// The connections to other nodes are only used when no matching connection is alive.
+//
+type AffinitySelector struct {
+	attribute  string
+	value      string
+	preference string
+
+	local  Selector
+	remote Selector
+}
+
+// NewAffinitySelector returns a selector which prefers the connections to nodes
+// with the attribute set to value, for example:
+//
+//     estransport.NewAffinitySelector("zone", "us-east-1a")
+//
+// The attribute name may be passed with the "attr." prefix, as in the node settings.
+// The selection among the matching nodes, and among the other nodes, is round-robin.
+//
+func NewAffinitySelector(attribute, value string) *AffinitySelector {
+	return &AffinitySelector{
+		attribute: strings.TrimPrefix(attribute, "attr."),
+		value:     value,
+		local:     &roundRobinSelector{curr: -1},
+		remote:    &roundRobinSelector{curr: -1},
+	}
+}
+
+// WithPreference sets the value of the preference parameter, eg. "_local",
+// added to the requests for API endpoints which accept it.
+//
+func (s *AffinitySelector) WithPreference(preference string) *AffinitySelector {
+	s.preference = preference
+	return s
+}
+
+// Preference returns the value of the preference parameter.
+//
+func (s *AffinitySelector) Preference() string {
+	return s.preference
+}
+
+// Select returns a connection to a matching node, or to another node when none is available.
+//
+func (s *AffinitySelector) Select(conns []*Connection) (*Connection, error) {
+	var local []*Connection
+	for _, c := range conns {
+		if s.matches(c) {
+			local = append(local, c)
+		}
+	}
+
+	if len(local) > 0 {
+		return s.local.Select(local)
+	}
+
+	if debugLogger != nil {
+		debugLogger.Logf("No live connection with %s=%s, falling back to other nodes\n", s.attribute, s.value)
+	}
+	return s.remote.Select(conns)
+}
+
+// matches returns true when the connection has the attribute set to the value.
+//
+func (s *AffinitySelector) matches(c *Connection) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	v, ok := c.Attributes[s.attribute]
+	return ok && fmt.Sprint(v) == s.value
+}
+
+// setReqPreference sets the preference parameter of the request, when the selector provides it.
+//
+func (c *Client) setReqPreference(req *http.Request, endpoint string) {
+	selector, ok := c.selector.(PreferenceSelector)
+	if !ok || !preferenceEndpoints[endpoint] {
+		return
+	}
+
+	preference := selector.Preference()
+	if preference == "" {
+		return
+	}
+
+	q := req.URL.Query()
+	if q.Get("preference") != "" {
+		return
+	}
+	q.Set("preference", preference)
+
+	u := *req.URL
+	u.RawQuery = q.Encode()
+	req.URL = &u
+}
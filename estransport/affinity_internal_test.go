@@ -0,0 +1,129 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// +build !integration
+
+package estransport
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/internal/endpoint"
+)
+
+func TestAffinitySelector(t *testing.T) {
+	newConnections := func() []*Connection {
+		conns := newTestConnections("foo1", "foo2", "foo3", "foo4")
+		conns[0].Attributes = map[string]interface{}{"zone": "us-east-1b"}
+		conns[1].Attributes = map[string]interface{}{"zone": "us-east-1a"}
+		conns[2].Attributes = map[string]interface{}{"zone": "us-east-1b"}
+		conns[3].Attributes = map[string]interface{}{"zone": "us-east-1a"}
+		return conns
+	}
+
+	t.Run("Prefer local connections", func(t *testing.T) {
+		conns := newConnections()
+		s := NewAffinitySelector("attr.zone", "us-east-1a")
+
+		for i, expected := range []string{"foo2", "foo4", "foo2"} {
+			c, err := s.Select(conns)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if c.URL.Host != expected {
+				t.Errorf("%d: Unexpected connection, want=%s, got=%s", i, expected, c.URL.Host)
+			}
+		}
+	})
+
+	t.Run("Fall back to other zones", func(t *testing.T) {
+		conns := newConnections()
+		s := NewAffinitySelector("zone", "us-east-1c")
+
+		for i, expected := range []string{"foo1", "foo2", "foo3", "foo4"} {
+			c, _ := s.Select(conns)
+			if c.URL.Host != expected {
+				t.Errorf("%d: Unexpected connection, want=%s, got=%s", i, expected, c.URL.Host)
+			}
+		}
+	})
+
+	t.Run("Fall back when local connections are dead", func(t *testing.T) {
+		conns := newConnections()
+		pool := &statusConnectionPool{
+			live:          conns,
+			selector:      NewAffinitySelector("zone", "us-east-1a"),
+			healthChecked: true,
+		}
+
+		pool.OnFailure(conns[1])
+		pool.OnFailure(conns[3])
+
+		for i := 0; i < 4; i++ {
+			c, _ := pool.Next()
+			if c.Attributes["zone"] != "us-east-1b" {
+				t.Errorf("Unexpected connection: %s", c.URL)
+			}
+		}
+
+		pool.OnSuccess(conns[3])
+
+		c, _ := pool.Next()
+		if c != conns[3] {
+			t.Errorf("Expected the resurrected local connection, got: %s", c.URL)
+		}
+	})
+
+	t.Run("Preference", func(t *testing.T) {
+		var query url.Values
+
+		u1, _ := url.Parse("http://foo1")
+		u2, _ := url.Parse("http://foo2")
+		tp := New(Config{
+			URLs:     []*url.URL{u1, u2},
+			Selector: NewAffinitySelector("zone", "us-east-1a").WithPreference("_local"),
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					query = req.URL.Query()
+					return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+				},
+			},
+		})
+
+		var tt = []struct {
+			name     string
+			endpoint string
+			path     string
+			expected string
+		}{
+			{"Search", "search", "/test/_search?q=foo", "_local"},
+			{"Count", "count", "/test/_count", "_local"},
+			{"Explicit", "search", "/test/_search?preference=_shards:0", "_shards:0"},
+			{"Unsupported endpoint", "index", "/test/_doc", ""},
+			{"Unknown endpoint", "", "/test/_search", ""},
+		}
+
+		for _, tc := range tt {
+			t.Run(tc.name, func(t *testing.T) {
+				req, _ := http.NewRequest("GET", tc.path, nil)
+				if tc.endpoint != "" {
+					req = req.WithContext(endpoint.WithInfo(req.Context(), endpoint.Info{Name: tc.endpoint}))
+				}
+
+				if _, err := tp.Perform(req); err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+
+				if p := query.Get("preference"); p != tc.expected {
+					t.Errorf("Unexpected preference, want=%q, got=%q", tc.expected, p)
+				}
+				if req.URL.Query().Get("preference") != "" && tc.path == "/test/_search?q=foo" {
+					t.Errorf("Expected the original request to not be modified")
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,335 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package estransport
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when the request is rejected by an open circuit breaker.
+//
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+var (
+	defaultCircuitErrorThreshold   = 0.5
+	defaultCircuitMinRequests      = 10
+	defaultCircuitWindow           = 10 * time.Second
+	defaultCircuitOpenTimeout      = 30 * time.Second
+	defaultCircuitHalfOpenRequests = 1
+)
+
+// circuitClusterName is the name of the global circuit breaker in logs and metrics.
+//
+const circuitClusterName = "cluster"
+
+// CircuitState represents the state of a circuit breaker.
+//
+type CircuitState int
+
+// Circuit breaker states.
+//
+const (
+	CircuitClosed   CircuitState = iota // Requests are allowed
+	CircuitOpen                         // Requests are rejected
+	CircuitHalfOpen                     // A limited number of trial requests is allowed
+)
+
+// CircuitBreakerConfig represents the configuration of the circuit breakers.
+//
+// A circuit breaker is kept for every connection and for the whole cluster. The breaker opens
+// when the ratio of failed requests in the window exceeds the threshold; a request is failed
+// when it returns an error or a response with one of the RetryOnStatus codes. For the connection
+// breakers, the attempts slower than the latency threshold are counted as failed as well.
+// After the open timeout, the breaker lets the trial requests through, and closes when they succeed.
+//
+type CircuitBreakerConfig struct {
+	ErrorThreshold   float64       // The ratio of failed requests which opens the breaker. Default: 0.5.
+	LatencyThreshold time.Duration // Count the slower attempts to a node as failed. Default: disabled.
+	MinRequests      int           // The minimum number of requests in the window to open the breaker. Default: 10.
+	Window           time.Duration // The duration of the window for counting the requests. Default: 10s.
+	OpenTimeout      time.Duration // The duration of the open state. Default: 30s.
+	HalfOpenRequests int           // The number of trial requests in the half-open state. Default: 1.
+
+	// OnStateChange is called when the state of a breaker changes; name is the node URL,
+	// or "cluster" for the global breaker. The function must not block. The state changes
+	// are logged by the configured Logger as well, when it has a Logf method, like the bundled loggers.
+	OnStateChange func(name string, from, to CircuitState)
+}
+
+// circuitResult represents the outcome of a request reported to the circuit breaker.
+//
+type circuitResult int
+
+const (
+	circuitSuccess circuitResult = iota
+	circuitFailure
+	circuitIgnored // The request was cancelled by the caller
+)
+
+// circuitBreaker represents the inner state of a circuit breaker.
+//
+type circuitBreaker struct {
+	sync.Mutex
+
+	name string
+	cfg  *CircuitBreakerConfig
+
+	state       CircuitState
+	requests    int
+	failures    int
+	windowStart time.Time
+	openedAt    time.Time
+	trials      int
+}
+
+// newCircuitBreakerConfig returns a copy of cfg with the default values filled in.
+//
+func newCircuitBreakerConfig(cfg *CircuitBreakerConfig) *CircuitBreakerConfig {
+	c := *cfg
+	if c.ErrorThreshold <= 0 {
+		c.ErrorThreshold = defaultCircuitErrorThreshold
+	}
+	if c.MinRequests <= 0 {
+		c.MinRequests = defaultCircuitMinRequests
+	}
+	if c.Window <= 0 {
+		c.Window = defaultCircuitWindow
+	}
+	if c.OpenTimeout <= 0 {
+		c.OpenTimeout = defaultCircuitOpenTimeout
+	}
+	if c.HalfOpenRequests <= 0 {
+		c.HalfOpenRequests = defaultCircuitHalfOpenRequests
+	}
+	return &c
+}
+
+// String returns the state name.
+//
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the state as its name.
+//
+func (s CircuitState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// allow reports whether the request is allowed, and returns the previous and the current state.
+//
+func (cb *circuitBreaker) allow(now time.Time) (ok bool, from, to CircuitState) {
+	cb.Lock()
+	defer cb.Unlock()
+
+	from = cb.state
+
+	if cb.state == CircuitOpen {
+		if now.Sub(cb.openedAt) < cb.cfg.OpenTimeout {
+			return false, from, cb.state
+		}
+		cb.state = CircuitHalfOpen
+		cb.trials = 0
+	}
+
+	if cb.state == CircuitHalfOpen {
+		if cb.trials >= cb.cfg.HalfOpenRequests {
+			return false, from, cb.state
+		}
+		cb.trials++
+	}
+
+	return true, from, cb.state
+}
+
+// done records the result of an allowed request, and returns the previous and the current state.
+//
+func (cb *circuitBreaker) done(now time.Time, result circuitResult) (from, to CircuitState) {
+	cb.Lock()
+	defer cb.Unlock()
+
+	from = cb.state
+
+	switch cb.state {
+	case CircuitHalfOpen:
+		switch result {
+		case circuitSuccess:
+			cb.reset(now)
+			cb.state = CircuitClosed
+		case circuitFailure:
+			cb.open(now)
+		case circuitIgnored:
+			if cb.trials > 0 {
+				cb.trials--
+			}
+		}
+	case CircuitClosed:
+		if result == circuitIgnored {
+			break
+		}
+		if now.Sub(cb.windowStart) >= cb.cfg.Window {
+			cb.reset(now)
+		}
+		cb.requests++
+		if result == circuitFailure {
+			cb.failures++
+		}
+		if cb.requests >= cb.cfg.MinRequests &&
+			float64(cb.failures)/float64(cb.requests) >= cb.cfg.ErrorThreshold {
+			cb.open(now)
+		}
+	}
+
+	return from, cb.state
+}
+
+// open switches the breaker to the open state. The calling code is responsible for locking.
+//
+func (cb *circuitBreaker) open(now time.Time) {
+	cb.state = CircuitOpen
+	cb.openedAt = now
+	cb.trials = 0
+}
+
+// reset starts a new window. The calling code is responsible for locking.
+//
+func (cb *circuitBreaker) reset(now time.Time) {
+	cb.requests = 0
+	cb.failures = 0
+	cb.windowStart = now
+}
+
+// allowCircuit reports whether the circuit breaker allows the request, recording the state changes.
+//
+func (c *Client) allowCircuit(cb *circuitBreaker) bool {
+	ok, from, to := cb.allow(time.Now())
+	c.observeCircuit(cb.name, from, to)
+	return ok
+}
+
+// reportCircuit records the result of the request in the circuit breaker, recording the state changes.
+//
+func (c *Client) reportCircuit(cb *circuitBreaker, result circuitResult) {
+	from, to := cb.done(time.Now(), result)
+	c.observeCircuit(cb.name, from, to)
+}
+
+// observeCircuit logs and records the change of the circuit breaker state.
+//
+func (c *Client) observeCircuit(name string, from, to CircuitState) {
+	if from == to {
+		return
+	}
+
+	if debugLogger != nil {
+		debugLogger.Logf("Circuit breaker for %s changed from %s to %s\n", name, from, to)
+	}
+
+	if l, ok := c.logger.(messageLogger); ok {
+		l.Logf("Circuit breaker for %s changed from %s to %s", name, from, to) // errcheck exclude
+	}
+
+	if c.metrics != nil {
+		c.metrics.Lock()
+		c.metrics.circuitStates[name] = to
+		if to == CircuitOpen {
+			c.metrics.circuitOpens++
+		}
+		c.metrics.Unlock()
+	}
+
+	if c.circuitBreakerConfig.OnStateChange != nil {
+		c.circuitBreakerConfig.OnStateChange(name, from, to)
+	}
+}
+
+// connectionBreaker returns the circuit breaker of the connection.
+//
+func (c *Client) connectionBreaker(conn *Connection) *circuitBreaker {
+	conn.Lock()
+	defer conn.Unlock()
+
+	if conn.breaker == nil {
+		conn.breaker = &circuitBreaker{name: conn.URL.String(), cfg: c.circuitBreakerConfig}
+	}
+	return conn.breaker
+}
+
+// nextConnection returns the next connection from the pool,
+// skipping the connections with an open circuit breaker.
+//
+func (c *Client) nextConnection() (*Connection, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.circuitBreaker == nil {
+		return c.pool.Next()
+	}
+
+	for i := 0; i <= len(c.pool.URLs()); i++ {
+		conn, err := c.pool.Next()
+		if err != nil {
+			return nil, err
+		}
+		if c.allowCircuit(c.connectionBreaker(conn)) {
+			return conn, nil
+		}
+	}
+
+	c.rejectCircuit()
+	return nil, ErrCircuitOpen
+}
+
+// rejectCircuit records the request rejected by a circuit breaker.
+//
+func (c *Client) rejectCircuit() {
+	if c.metrics != nil {
+		c.metrics.Lock()
+		c.metrics.circuitRejections++
+		c.metrics.Unlock()
+	}
+}
+
+// circuitResult returns the outcome of the request for the circuit breaker.
+//
+func (c *Client) circuitResult(res *http.Response, err error, cancelled bool) circuitResult {
+	if cancelled {
+		return circuitIgnored
+	}
+	if err != nil {
+		return circuitFailure
+	}
+	if res != nil {
+		for _, code := range c.retryOnStatus {
+			if res.StatusCode == code {
+				return circuitFailure
+			}
+		}
+	}
+	return circuitSuccess
+}
+
+// connectionResult returns the outcome of the attempt for the circuit breaker of the connection,
+// counting the attempts slower than the latency threshold as failed.
+//
+func (c *Client) connectionResult(res *http.Response, err error, dur time.Duration, cancelled bool) circuitResult {
+	result := c.circuitResult(res, err, cancelled)
+	if result == circuitSuccess && c.circuitBreakerConfig.LatencyThreshold > 0 && dur > c.circuitBreakerConfig.LatencyThreshold {
+		return circuitFailure
+	}
+	return result
+}
@@ -0,0 +1,328 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// +build !integration
+
+package estransport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	newBreaker := func() *circuitBreaker {
+		return &circuitBreaker{
+			name: "test",
+			cfg: newCircuitBreakerConfig(&CircuitBreakerConfig{
+				MinRequests:      4,
+				Window:           time.Minute,
+				OpenTimeout:      time.Second,
+				HalfOpenRequests: 2,
+			}),
+		}
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := newCircuitBreakerConfig(&CircuitBreakerConfig{})
+
+		if cfg.ErrorThreshold != defaultCircuitErrorThreshold ||
+			cfg.MinRequests != defaultCircuitMinRequests ||
+			cfg.Window != defaultCircuitWindow ||
+			cfg.OpenTimeout != defaultCircuitOpenTimeout ||
+			cfg.HalfOpenRequests != defaultCircuitHalfOpenRequests {
+			t.Errorf("Unexpected config: %+v", cfg)
+		}
+	})
+
+	t.Run("Open on error rate", func(t *testing.T) {
+		cb := newBreaker()
+		now := time.Now()
+
+		for _, r := range []circuitResult{circuitFailure, circuitSuccess, circuitIgnored, circuitFailure} {
+			if ok, _, _ := cb.allow(now); !ok {
+				t.Fatalf("Expected the request to be allowed")
+			}
+			cb.done(now, r)
+		}
+		if cb.state != CircuitClosed {
+			t.Fatalf("Expected the breaker to be closed below the minimum number of requests, got: %s", cb.state)
+		}
+
+		cb.allow(now)
+		from, to := cb.done(now, circuitSuccess)
+		if from != CircuitClosed || to != CircuitOpen {
+			t.Fatalf("Unexpected transition: %s -> %s", from, to)
+		}
+
+		if ok, _, _ := cb.allow(now.Add(500 * time.Millisecond)); ok {
+			t.Errorf("Expected the request to be rejected")
+		}
+	})
+
+	t.Run("Reset the window", func(t *testing.T) {
+		cb := newBreaker()
+		now := time.Now()
+
+		for i := 0; i < 3; i++ {
+			cb.done(now, circuitFailure)
+		}
+		cb.done(now.Add(2*time.Minute), circuitFailure)
+
+		if cb.state != CircuitClosed {
+			t.Errorf("Expected the breaker to be closed, got: %s", cb.state)
+		}
+	})
+
+	t.Run("Half-open", func(t *testing.T) {
+		cb := newBreaker()
+		now := time.Now()
+		cb.open(now)
+
+		now = now.Add(time.Second)
+		ok, from, to := cb.allow(now)
+		if !ok || from != CircuitOpen || to != CircuitHalfOpen {
+			t.Fatalf("Unexpected result: ok=%v %s -> %s", ok, from, to)
+		}
+		if ok, _, _ := cb.allow(now); !ok {
+			t.Errorf("Expected the second trial request to be allowed")
+		}
+		if ok, _, _ := cb.allow(now); ok {
+			t.Errorf("Expected the third trial request to be rejected")
+		}
+
+		cb.done(now, circuitIgnored)
+		if ok, _, _ := cb.allow(now); !ok {
+			t.Errorf("Expected the trial request to be allowed after a cancelled one")
+		}
+
+		if _, to := cb.done(now, circuitFailure); to != CircuitOpen {
+			t.Errorf("Expected the breaker to open after a failed trial, got: %s", to)
+		}
+
+		now = now.Add(time.Second)
+		cb.allow(now)
+		if _, to := cb.done(now, circuitSuccess); to != CircuitClosed {
+			t.Errorf("Expected the breaker to close after a successful trial, got: %s", to)
+		}
+	})
+
+	t.Run("State", func(t *testing.T) {
+		if s := CircuitHalfOpen.String(); s != "half-open" {
+			t.Errorf("Unexpected state: %s", s)
+		}
+		b, _ := json.Marshal(map[string]CircuitState{"cluster": CircuitOpen})
+		if string(b) != `{"cluster":"open"}` {
+			t.Errorf("Unexpected output: %s", b)
+		}
+	})
+}
+
+func TestTransportCircuitBreaker(t *testing.T) {
+	t.Run("Fail fast when the cluster breaker is open", func(t *testing.T) {
+		var (
+			mu          sync.Mutex
+			numReqs     int
+			transitions []string
+		)
+
+		u, _ := url.Parse("http://foo1")
+		tp := New(Config{
+			URLs:          []*url.URL{u},
+			DisableRetry:  true,
+			EnableMetrics: true,
+			CircuitBreaker: &CircuitBreakerConfig{
+				MinRequests: 2,
+				OnStateChange: func(name string, from, to CircuitState) {
+					mu.Lock()
+					transitions = append(transitions, fmt.Sprintf("%s:%s->%s", name, from, to))
+					mu.Unlock()
+				},
+			},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					numReqs++
+					return nil, &mockNetError{error: fmt.Errorf("Mock network error")}
+				},
+			},
+		})
+
+		for i := 0; i < 2; i++ {
+			req, _ := http.NewRequest("GET", "/", nil)
+			if _, err := tp.Perform(req); err == nil || err == ErrCircuitOpen {
+				t.Fatalf("Expected the network error, got: %v", err)
+			}
+		}
+
+		req, _ := http.NewRequest("GET", "/", nil)
+		_, err := tp.Perform(req)
+		if err != ErrCircuitOpen {
+			t.Fatalf("Expected ErrCircuitOpen, got: %v", err)
+		}
+
+		if numReqs != 2 {
+			t.Errorf("Unexpected number of requests, want=2, got=%d", numReqs)
+		}
+
+		mu.Lock()
+		if len(transitions) != 2 ||
+			transitions[0] != "http://foo1:closed->open" ||
+			transitions[1] != "cluster:closed->open" {
+			t.Errorf("Unexpected transitions: %v", transitions)
+		}
+		mu.Unlock()
+
+		m, _ := tp.Metrics()
+		if m.CircuitBreakerOpens != 2 {
+			t.Errorf("Unexpected number of opens: %d", m.CircuitBreakerOpens)
+		}
+		if m.CircuitBreakerRejections != 1 {
+			t.Errorf("Unexpected number of rejections: %d", m.CircuitBreakerRejections)
+		}
+		if m.CircuitBreakers["cluster"] != CircuitOpen || m.CircuitBreakers["http://foo1"] != CircuitOpen {
+			t.Errorf("Unexpected states: %v", m.CircuitBreakers)
+		}
+		if !strings.Contains(m.String(), "CircuitBreakerOpens:2 CircuitBreakerRejections:1") {
+			t.Errorf("Unexpected output: %s", m)
+		}
+	})
+
+	t.Run("Skip the slow node", func(t *testing.T) {
+		var (
+			mu    sync.Mutex
+			hosts = make(map[string]int)
+		)
+
+		u1, _ := url.Parse("http://foo1")
+		u2, _ := url.Parse("http://foo2")
+		tp := New(Config{
+			URLs: []*url.URL{u1, u2},
+			CircuitBreaker: &CircuitBreakerConfig{
+				MinRequests:      2,
+				LatencyThreshold: 20 * time.Millisecond,
+				OpenTimeout:      time.Hour,
+			},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					mu.Lock()
+					hosts[req.URL.Host]++
+					mu.Unlock()
+					if req.URL.Host == "foo1" {
+						time.Sleep(50 * time.Millisecond)
+					}
+					return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+				},
+			},
+		})
+
+		for i := 0; i < 10; i++ {
+			req, _ := http.NewRequest("GET", "/", nil)
+			if _, err := tp.Perform(req); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+
+		if hosts["foo1"] != 2 {
+			t.Errorf("Expected the slow node to be skipped after 2 requests, got: %v", hosts)
+		}
+		if hosts["foo2"] != 8 {
+			t.Errorf("Unexpected number of requests to the fast node: %v", hosts)
+		}
+	})
+
+	t.Run("Ignore cancelled requests", func(t *testing.T) {
+		u, _ := url.Parse("http://foo1")
+		tp := New(Config{
+			URLs:           []*url.URL{u},
+			CircuitBreaker: &CircuitBreakerConfig{MinRequests: 1},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					<-req.Context().Done()
+					return nil, req.Context().Err()
+				},
+			},
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		req, _ := http.NewRequest("GET", "/", nil)
+		if _, err := tp.Perform(req.WithContext(ctx)); err == nil {
+			t.Fatalf("Expected error")
+		}
+
+		if s := tp.circuitBreaker.state; s != CircuitClosed {
+			t.Errorf("Expected the breaker to be closed, got: %s", s)
+		}
+	})
+
+	t.Run("Release the trial request when the body can't be read", func(t *testing.T) {
+		u1, _ := url.Parse("http://foo1")
+		u2, _ := url.Parse("http://foo2")
+		tp := New(Config{
+			URLs:           []*url.URL{u1, u2},
+			CircuitBreaker: &CircuitBreakerConfig{OpenTimeout: time.Millisecond},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{StatusCode: 502, Body: http.NoBody}, nil
+				},
+			},
+		})
+
+		conn := tp.pool.(*statusConnectionPool).live[1]
+		cb := tp.connectionBreaker(conn)
+		cb.open(time.Now().Add(-time.Second))
+
+		req, _ := http.NewRequest("POST", "/", strings.NewReader("{}"))
+		req.GetBody = func() (io.ReadCloser, error) { return nil, fmt.Errorf("Mock error") }
+		if _, err := tp.Perform(req); err == nil || !strings.Contains(err.Error(), "cannot get request body") {
+			t.Fatalf("Expected the body error, got: %v", err)
+		}
+
+		if cb.state != CircuitHalfOpen || cb.trials != 0 {
+			t.Errorf("Expected the trial request to be released, got: %s, trials=%d", cb.state, cb.trials)
+		}
+		if ok, _, _ := cb.allow(time.Now()); !ok {
+			t.Errorf("Expected the trial request to be allowed")
+		}
+	})
+
+	t.Run("Log the state changes", func(t *testing.T) {
+		var dst bytes.Buffer
+
+		u, _ := url.Parse("http://foo1")
+		tp := New(Config{
+			URLs:           []*url.URL{u},
+			DisableRetry:   true,
+			CircuitBreaker: &CircuitBreakerConfig{MinRequests: 1},
+			Logger:         &TextLogger{Output: &dst},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					return nil, &mockNetError{error: fmt.Errorf("Mock network error")}
+				},
+			},
+		})
+
+		req, _ := http.NewRequest("GET", "/", nil)
+		tp.Perform(req)
+
+		for _, expected := range []string{
+			"Circuit breaker for http://foo1 changed from closed to open",
+			"Circuit breaker for cluster changed from closed to open",
+		} {
+			if !strings.Contains(dst.String(), expected) {
+				t.Errorf("Expected the output to contain %q, got: %s", expected, dst.String())
+			}
+		}
+	})
+}
@@ -48,6 +48,15 @@ type Connection struct {
 	Name       string
 	Roles      []string
 	Attributes map[string]interface{}
+
+	Weight int // The relative weight for the weighted selector, set by the ConnectionWeight option; zero is treated as 1.
+
+	resurrectTimer *time.Timer
+
+	inflight int32         // The number of outstanding requests; accessed atomically
+	latency  time.Duration // The moving average of the response time
+
+	breaker *circuitBreaker
 }
 
 type singleConnectionPool struct {
@@ -63,6 +72,8 @@ type statusConnectionPool struct {
 	dead     []*Connection // List of dead connections
 	selector Selector
 
+	healthChecked bool // Dead connections are resurrected by the health checks
+
 	metrics *metrics
 }
 
@@ -110,6 +121,9 @@ func (cp *statusConnectionPool) Next() (*Connection, error) {
 	// Return next live connection
 	if len(cp.live) > 0 {
 		return cp.selector.Select(cp.live)
+	} else if cp.healthChecked && len(cp.dead) > 0 {
+		// The dead connections are resurrected only by a successful probe.
+		return nil, errors.New("no live connection available")
 	} else if len(cp.dead) > 0 {
 		// No live connection is available, resurrect one of the dead ones.
 		c := cp.dead[len(cp.dead)-1]
@@ -160,22 +174,14 @@ func (cp *statusConnectionPool) OnFailure(c *Connection) error {
 		debugLogger.Logf("Removing %s...\n", c.URL)
 	}
 	c.markAsDead()
-	cp.scheduleResurrect(c)
+	if !cp.healthChecked {
+		cp.scheduleResurrect(c)
+	}
 	c.Unlock()
 
 	// Push item to dead list and sort slice by number of failures
 	cp.dead = append(cp.dead, c)
-	sort.Slice(cp.dead, func(i, j int) bool {
-		c1 := cp.dead[i]
-		c2 := cp.dead[j]
-		c1.Lock()
-		c2.Lock()
-		defer c1.Unlock()
-		defer c2.Unlock()
-
-		res := c1.Failures > c2.Failures
-		return res
-	})
+	cp.sortDead()
 
 	// Check if connection exists in the list, return error if not.
 	index := -1
@@ -255,7 +261,8 @@ func (cp *statusConnectionPool) scheduleResurrect(c *Connection) {
 		debugLogger.Logf("Resurrect %s (failures=%d, factor=%1.1f, timeout=%s) in %s\n", c.URL, c.Failures, factor, timeout, c.DeadSince.Add(timeout).Sub(time.Now().UTC()).Truncate(time.Second))
 	}
 
-	time.AfterFunc(timeout, func() {
+	c.cancelResurrect()
+	c.resurrectTimer = time.AfterFunc(timeout, func() {
 		cp.Lock()
 		defer cp.Unlock()
 
@@ -269,10 +276,38 @@ func (cp *statusConnectionPool) scheduleResurrect(c *Connection) {
 			return
 		}
 
+		// Skip connections removed from the pool, eg. during node discovery
+		var inPool bool
+		for _, conn := range cp.dead {
+			if conn == c {
+				inPool = true
+			}
+		}
+		if !inPool {
+			return
+		}
+
 		cp.resurrect(c, true)
 	})
 }
 
+// sortDead sorts the list of dead connections by the number of failures.
+// The calling code is responsible for locking.
+//
+func (cp *statusConnectionPool) sortDead() {
+	sort.Slice(cp.dead, func(i, j int) bool {
+		c1 := cp.dead[i]
+		c2 := cp.dead[j]
+		c1.Lock()
+		c2.Lock()
+		defer c1.Unlock()
+		defer c2.Unlock()
+
+		res := c1.Failures > c2.Failures
+		return res
+	})
+}
+
 // Select returns the connection in a round-robin fashion.
 //
 func (s *roundRobinSelector) Select(conns []*Connection) (*Connection, error) {
@@ -307,6 +342,16 @@ func (c *Connection) markAsHealthy() {
 	c.Failures = 0
 }
 
+// cancelResurrect stops the scheduled resurrection of the connection.
+// The calling code is responsible for locking.
+//
+func (c *Connection) cancelResurrect() {
+	if c.resurrectTimer != nil {
+		c.resurrectTimer.Stop()
+		c.resurrectTimer = nil
+	}
+}
+
 // String returns a readable connection representation.
 //
 func (c *Connection) String() string {
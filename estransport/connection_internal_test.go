@@ -152,6 +152,30 @@ func TestStatusConnectionPoolNext(t *testing.T) {
 			t.Errorf("Expected 1 connection in dead list, got: %s", pool.dead)
 		}
 	})
+
+	t.Run("Don't resurrect dead connection when health checked", func(t *testing.T) {
+		pool := &statusConnectionPool{
+			live: []*Connection{},
+			dead: []*Connection{
+				&Connection{URL: &url.URL{Scheme: "http", Host: "foo1"}, Failures: 3, IsDead: true},
+			},
+			selector:      &roundRobinSelector{curr: -1},
+			healthChecked: true,
+		}
+
+		c, err := pool.Next()
+		if err == nil {
+			t.Errorf("Expected error, got: %s", c)
+		}
+
+		if !pool.dead[0].IsDead {
+			t.Errorf("Expected connection to be dead")
+		}
+
+		if len(pool.live) != 0 || len(pool.dead) != 1 {
+			t.Errorf("Expected the lists to be unchanged, got: live=%s, dead=%s", pool.live, pool.dead)
+		}
+	})
 }
 
 func TestStatusConnectionPoolOnSuccess(t *testing.T) {
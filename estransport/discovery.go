@@ -22,11 +22,11 @@ type Discoverable interface {
 	DiscoverNodes() error
 }
 
-// nodeInfo represents the information about node in a cluster.
+// NodeInfo represents the information about node in a cluster.
 //
 // See: https://www.elastic.co/guide/en/elasticsearch/reference/current/cluster-nodes-info.html
 //
-type nodeInfo struct {
+type NodeInfo struct {
 	ID         string
 	Name       string
 	URL        *url.URL
@@ -42,6 +42,11 @@ type nodeInfo struct {
 func (c *Client) DiscoverNodes() error {
 	var conns []*Connection
 
+	if err := c.beginRequest(); err != nil {
+		return err
+	}
+	defer c.inflight.Done()
+
 	nodes, err := c.getNodesInfo()
 	if err != nil {
 		if debugLogger != nil {
@@ -51,32 +56,18 @@ func (c *Client) DiscoverNodes() error {
 	}
 
 	for _, node := range nodes {
-		var (
-			isDataNode   bool
-			isIngestNode bool
-		)
-
-		roles := append(node.Roles[:0:0], node.Roles...)
-		sort.Strings(roles)
-
-		if i := sort.SearchStrings(roles, "data"); i < len(roles) && roles[i] == "data" {
-			isDataNode = true
-		}
-		if i := sort.SearchStrings(roles, "ingest"); i < len(roles) && roles[i] == "ingest" {
-			isIngestNode = true
-		}
+		include := c.discoveryFilter(node)
 
 		if debugLogger != nil {
 			var skip string
-			if !isDataNode || !isIngestNode {
+			if !include {
 				skip = "; [SKIP]"
 			}
 			debugLogger.Logf("Discovered node [%s]; %s; roles=%s%s\n", node.Name, node.URL, node.Roles, skip)
 		}
 
-		// Skip master only nodes
-		// TODO(karmi): Move logic to Selector?
-		if !isDataNode || !isIngestNode {
+		// Skip the nodes rejected by the filter
+		if !include {
 			continue
 		}
 
@@ -97,11 +88,15 @@ func (c *Client) DiscoverNodes() error {
 		defer lockable.Unlock()
 	}
 
+	conns = c.mergeConnections(conns)
+	for _, conn := range conns {
+		c.setConnectionWeight(conn)
+	}
+
 	if c.poolFunc != nil {
 		c.pool = c.poolFunc(conns, c.selector)
 	} else {
-		// TODO(karmi): Replace only live connections, leave dead scheduled for resurrect?
-		c.pool, err = NewConnectionPool(conns, c.selector)
+		c.pool, err = c.mergeConnectionPool(conns)
 		if err != nil {
 			return err
 		}
@@ -110,9 +105,109 @@ func (c *Client) DiscoverNodes() error {
 	return nil
 }
 
-func (c *Client) getNodesInfo() ([]nodeInfo, error) {
+// mergeConnections replaces the discovered connections with the existing ones
+// for the same node, matched by node ID or URL, to keep their health state,
+// and cancels the scheduled resurrection of connections no longer in the cluster.
+// The calling code is responsible for locking.
+//
+func (c *Client) mergeConnections(conns []*Connection) []*Connection {
+	var existing []*Connection
+	if pool, ok := c.pool.(connectionable); ok {
+		existing = pool.connections()
+	}
+
 	var (
-		out    []nodeInfo
+		byID  = make(map[string]*Connection)
+		byURL = make(map[string]*Connection)
+		kept  = make(map[*Connection]bool)
+	)
+	for _, conn := range existing {
+		if conn.ID != "" {
+			byID[conn.ID] = conn
+		}
+		byURL[conn.URL.String()] = conn
+	}
+
+	for i, conn := range conns {
+		var prev *Connection
+		if conn.ID != "" {
+			prev = byID[conn.ID]
+		}
+		if prev == nil {
+			prev = byURL[conn.URL.String()]
+		}
+		if prev == nil || kept[prev] {
+			continue
+		}
+
+		prev.Lock()
+		prev.URL = conn.URL
+		prev.ID = conn.ID
+		prev.Name = conn.Name
+		prev.Roles = conn.Roles
+		prev.Attributes = conn.Attributes
+		prev.Unlock()
+
+		conns[i] = prev
+		kept[prev] = true
+	}
+
+	for _, conn := range existing {
+		if !kept[conn] {
+			if debugLogger != nil {
+				debugLogger.Logf("Removing %s from the pool\n", conn.URL)
+			}
+			conn.Lock()
+			conn.cancelResurrect()
+			conn.Unlock()
+		}
+	}
+
+	return conns
+}
+
+// mergeConnectionPool returns the connection pool with conns, updating the existing pool
+// when possible, and keeping the dead connections in the dead list.
+// The calling code is responsible for locking.
+//
+func (c *Client) mergeConnectionPool(conns []*Connection) (ConnectionPool, error) {
+	pool, ok := c.pool.(*statusConnectionPool)
+	if !ok || len(conns) == 1 {
+		for _, conn := range conns {
+			conn.Lock()
+			conn.cancelResurrect()
+			conn.Unlock()
+		}
+
+		p, err := NewConnectionPool(conns, c.selector)
+		if err != nil {
+			return nil, err
+		}
+		c.configurePool(p)
+		return p, nil
+	}
+
+	pool.live = nil
+	pool.dead = nil
+	for _, conn := range conns {
+		conn.Lock()
+		isDead := conn.IsDead
+		conn.Unlock()
+
+		if isDead {
+			pool.dead = append(pool.dead, conn)
+		} else {
+			pool.live = append(pool.live, conn)
+		}
+	}
+	pool.sortDead()
+
+	return pool, nil
+}
+
+func (c *Client) getNodesInfo() ([]NodeInfo, error) {
+	var (
+		out    []NodeInfo
 		scheme = c.urls[0].Scheme
 	)
 
@@ -132,6 +227,7 @@ func (c *Client) getNodesInfo() ([]nodeInfo, error) {
 	c.setReqURL(conn.URL, req)
 	c.setReqAuth(conn.URL, req)
 	c.setReqUserAgent(req)
+	c.setReqHeaders(req)
 
 	res, err := c.transport.RoundTrip(req)
 	if err != nil {
@@ -149,7 +245,7 @@ func (c *Client) getNodesInfo() ([]nodeInfo, error) {
 		return out, err
 	}
 
-	var nodes map[string]nodeInfo
+	var nodes map[string]NodeInfo
 	if err := json.Unmarshal(env["nodes"], &nodes); err != nil {
 		return out, err
 	}
@@ -167,7 +263,7 @@ func (c *Client) getNodesInfo() ([]nodeInfo, error) {
 	return out, nil
 }
 
-func (c *Client) getNodeURL(node nodeInfo, scheme string) (*url.URL, error) {
+func (c *Client) getNodeURL(node NodeInfo, scheme string) (*url.URL, error) {
 	var (
 		host string
 		port string
@@ -191,9 +287,74 @@ func (c *Client) getNodeURL(node nodeInfo, scheme string) (*url.URL, error) {
 	return u, nil
 }
 
+// ExcludeMasterOnlyNodes is a discovery filter which skips the dedicated master nodes,
+// ie. nodes with the master role, and without the data or ingest roles.
+//
+func ExcludeMasterOnlyNodes(node NodeInfo) bool {
+	var isMaster, isData, isIngest bool
+	for _, role := range node.Roles {
+		switch {
+		case role == "master":
+			isMaster = true
+		case role == "data" || strings.HasPrefix(role, "data_"):
+			isData = true
+		case role == "ingest":
+			isIngest = true
+		}
+	}
+	return !isMaster || isData || isIngest
+}
+
+// MatchNodeRoles returns a discovery filter which accepts only nodes with all the roles.
+//
+func MatchNodeRoles(roles ...string) func(NodeInfo) bool {
+	return func(node NodeInfo) bool {
+		nodeRoles := append(node.Roles[:0:0], node.Roles...)
+		sort.Strings(nodeRoles)
+
+		for _, role := range roles {
+			if i := sort.SearchStrings(nodeRoles, role); i >= len(nodeRoles) || nodeRoles[i] != role {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// MatchNodeAttribute returns a discovery filter which accepts only nodes
+// with the attribute set to value, for example "rack" and "eu-1".
+//
+func MatchNodeAttribute(name, value string) func(NodeInfo) bool {
+	return func(node NodeInfo) bool {
+		v, ok := node.Attributes[name]
+		return ok && fmt.Sprint(v) == value
+	}
+}
+
+// MatchAllFilters returns a discovery filter which accepts only nodes accepted by all the filters.
+//
+func MatchAllFilters(filters ...func(NodeInfo) bool) func(NodeInfo) bool {
+	return func(node NodeInfo) bool {
+		for _, f := range filters {
+			if !f(node) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
 func (c *Client) scheduleDiscoverNodes(d time.Duration) {
 	go c.DiscoverNodes()
-	time.AfterFunc(c.discoverNodesInterval, func() {
+
+	c.Lock()
+	defer c.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	c.discoverNodesTimer = time.AfterFunc(c.discoverNodesInterval, func() {
 		c.scheduleDiscoverNodes(c.discoverNodesInterval)
 	})
 }
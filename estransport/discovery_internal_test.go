@@ -7,9 +7,13 @@
 package estransport
 
 import (
+	"bytes"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -30,21 +34,28 @@ func TestDiscovery(t *testing.T) {
 	srv := &http.Server{Addr: "localhost:10100", Handler: http.HandlerFunc(defaultHandler)}
 	srvTLS := &http.Server{Addr: "localhost:10200", Handler: http.HandlerFunc(defaultHandler)}
 
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		t.Fatalf("Unable to start server: %s", err)
+	}
+	lnTLS, err := net.Listen("tcp", srvTLS.Addr)
+	if err != nil {
+		t.Fatalf("Unable to start server: %s", err)
+	}
+
 	go func() {
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			t.Fatalf("Unable to start server: %s", err)
+		if err := srv.Serve(ln); err != nil && err != http.ErrServerClosed {
+			t.Errorf("Unable to start server: %s", err)
 		}
 	}()
 	go func() {
-		if err := srvTLS.ListenAndServeTLS("testdata/cert.pem", "testdata/key.pem"); err != nil && err != http.ErrServerClosed {
-			t.Fatalf("Unable to start server: %s", err)
+		if err := srvTLS.ServeTLS(lnTLS, "testdata/cert.pem", "testdata/key.pem"); err != nil && err != http.ErrServerClosed {
+			t.Errorf("Unable to start server: %s", err)
 		}
 	}()
 	defer func() { srv.Close() }()
 	defer func() { srvTLS.Close() }()
 
-	time.Sleep(time.Millisecond)
-
 	t.Run("getNodesInfo()", func(t *testing.T) {
 		u, _ := url.Parse("http://" + srv.Addr)
 		tp := New(Config{URLs: []*url.URL{u}})
@@ -148,6 +159,127 @@ func TestDiscovery(t *testing.T) {
 		}
 	})
 
+	t.Run("DiscoverNodes() with filter", func(t *testing.T) {
+		u, _ := url.Parse("http://" + srv.Addr)
+		tp := New(Config{
+			URLs:            []*url.URL{u},
+			DiscoveryFilter: func(node NodeInfo) bool { return node.Name == "es3" },
+		})
+
+		if err := tp.DiscoverNodes(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		urls := tp.pool.URLs()
+		if len(urls) != 1 || urls[0].String() != "http://127.0.0.1:9202" {
+			t.Errorf("Unexpected URLs: %s", urls)
+		}
+	})
+
+	t.Run("DiscoverNodes() with connection weight", func(t *testing.T) {
+		u, _ := url.Parse("http://" + srv.Addr)
+		tp := New(Config{
+			URLs:             []*url.URL{u},
+			ConnectionWeight: AttributeWeight("ml.max_open_jobs"),
+		})
+
+		if w := tp.pool.(*singleConnectionPool).connection.Weight; w != 1 {
+			t.Errorf("Unexpected weight of the configured connection: %d", w)
+		}
+
+		if err := tp.DiscoverNodes(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		for _, conn := range tp.pool.(*statusConnectionPool).live {
+			if conn.Weight != 20 {
+				t.Errorf("Unexpected weight of %s: %d", conn.Name, conn.Weight)
+			}
+		}
+	})
+
+	t.Run("DiscoverNodes() keeps the connection state", func(t *testing.T) {
+		fixture, err := ioutil.ReadFile("testdata/nodes.info.json")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		body := fixture
+
+		u, _ := url.Parse("http://localhost:9200")
+		tp := New(Config{
+			URLs: []*url.URL{u},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewReader(body))}, nil
+				},
+			},
+		})
+
+		if err := tp.DiscoverNodes(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		pool, ok := tp.pool.(*statusConnectionPool)
+		if !ok {
+			t.Fatalf("Unexpected pool, want=statusConnectionPool, got=%T", tp.pool)
+		}
+
+		var es1 *Connection
+		for _, conn := range pool.live {
+			if conn.Name == "es1" {
+				es1 = conn
+			}
+		}
+		if es1 == nil {
+			t.Fatalf("Expected es1 in the pool, got: %s", pool.live)
+		}
+
+		pool.OnFailure(es1)
+		deadSince := es1.DeadSince
+
+		if err := tp.DiscoverNodes(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if tp.pool != pool {
+			t.Errorf("Expected the pool to be preserved")
+		}
+
+		if len(pool.live) != 1 || len(pool.dead) != 1 {
+			t.Fatalf("Unexpected pool state: live=%s, dead=%s", pool.live, pool.dead)
+		}
+
+		if pool.dead[0] != es1 || !es1.IsDead || es1.Failures != 1 || !es1.DeadSince.Equal(deadSince) {
+			t.Errorf("Expected the connection state to be preserved, got: %s", pool.dead[0])
+		}
+
+		if es1.resurrectTimer == nil {
+			t.Errorf("Expected the resurrect timer to be scheduled")
+		}
+
+		// Remove es1 from the cluster
+		var info map[string]map[string]json.RawMessage
+		json.Unmarshal(fixture, &info)
+		delete(info["nodes"], "8g1UNpQNS06tlH1DUMBNhg")
+		body, _ = json.Marshal(info)
+
+		if err := tp.DiscoverNodes(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if _, ok := tp.pool.(*singleConnectionPool); !ok {
+			t.Fatalf("Unexpected pool, want=singleConnectionPool, got=%T", tp.pool)
+		}
+
+		if urls := tp.pool.URLs(); len(urls) != 1 || urls[0].String() != "http://localhost:9201" {
+			t.Errorf("Unexpected URLs: %s", urls)
+		}
+
+		if es1.resurrectTimer != nil {
+			t.Errorf("Expected the resurrect timer to be cancelled")
+		}
+	})
+
 	t.Run("scheduleDiscoverNodes()", func(t *testing.T) {
 		u, _ := url.Parse("http://" + srv.Addr)
 
@@ -163,3 +295,63 @@ func TestDiscovery(t *testing.T) {
 		}
 	})
 }
+
+func TestDiscoveryFilters(t *testing.T) {
+	var (
+		master       = NodeInfo{Name: "master", Roles: []string{"master"}}
+		masterML     = NodeInfo{Name: "master-ml", Roles: []string{"master", "ml"}}
+		masterData   = NodeInfo{Name: "master-data", Roles: []string{"master", "data", "ingest"}}
+		hot          = NodeInfo{Name: "hot", Roles: []string{"master", "data_hot"}}
+		coordinating = NodeInfo{Name: "coordinating", Roles: []string{}}
+		rack         = NodeInfo{Name: "rack", Roles: []string{"data"}, Attributes: map[string]interface{}{"rack": "eu-1"}}
+	)
+
+	filterNames := func(f func(NodeInfo) bool, nodes ...NodeInfo) []string {
+		var names []string
+		for _, n := range nodes {
+			if f(n) {
+				names = append(names, n.Name)
+			}
+		}
+		return names
+	}
+
+	t.Run("ExcludeMasterOnlyNodes", func(t *testing.T) {
+		names := filterNames(ExcludeMasterOnlyNodes, master, masterML, masterData, hot, coordinating)
+		if fmt.Sprint(names) != "[master-data hot coordinating]" {
+			t.Errorf("Unexpected nodes: %s", names)
+		}
+	})
+
+	t.Run("MatchNodeRoles", func(t *testing.T) {
+		names := filterNames(MatchNodeRoles("data", "ingest"), master, masterData, hot, coordinating, rack)
+		if fmt.Sprint(names) != "[master-data]" {
+			t.Errorf("Unexpected nodes: %s", names)
+		}
+
+		names = filterNames(MatchNodeRoles(), master, coordinating)
+		if fmt.Sprint(names) != "[master coordinating]" {
+			t.Errorf("Unexpected nodes: %s", names)
+		}
+	})
+
+	t.Run("MatchNodeAttribute", func(t *testing.T) {
+		names := filterNames(MatchNodeAttribute("rack", "eu-1"), master, coordinating, rack)
+		if fmt.Sprint(names) != "[rack]" {
+			t.Errorf("Unexpected nodes: %s", names)
+		}
+
+		names = filterNames(MatchNodeAttribute("rack", "eu-2"), rack)
+		if len(names) != 0 {
+			t.Errorf("Unexpected nodes: %s", names)
+		}
+	})
+
+	t.Run("MatchAllFilters", func(t *testing.T) {
+		f := MatchAllFilters(ExcludeMasterOnlyNodes, MatchNodeAttribute("rack", "eu-1"))
+		names := filterNames(f, master, masterData, rack)
+		if fmt.Sprint(names) != "[rack]" {
+			t.Errorf("Unexpected nodes: %s", names)
+		}
+	})
+}
@@ -11,16 +11,111 @@ The package will automatically retry requests on network-related errors, and on
 response status codes (by default 502, 503, 504). Use the RetryOnStatus option to customize the list.
 The transport will not retry a timeout network error, unless enabled by setting EnableRetryOnTimeout to true.
 
+Use the CompressRequestBody option to compress the request body with gzip; the response body
+is decompressed transparently by the default HTTP transport.
+
 Use the MaxRetries option to configure the number of retries, and set DisableRetry to true
 to disable the retry behaviour altogether.
 
 By default, the retry will be performed without any delay; to configure a backoff interval,
 implement the RetryBackoff option function; see an example in the package unit tests for information.
 
+The retries stop as soon as the request context is done; the returned error wraps the context error
+together with the last transport error. Use the AttemptTimeout option to limit the duration of a single
+attempt (an exceeded attempt is retried on another node), and the RetryTimeout option to limit the total
+duration of the request, including all retries and backoff delays.
+
+Use the WithRequestOptions function to override the maximum number of attempts, the retries,
+the list of status codes for retry and the request timeout, or to set headers, for the requests
+performed with the returned context; the esapi package provides the WithRequestOptions method
+for every API.
+
+Use the Header option to add headers, eg. X-Opaque-Id or a tenant header, to every request, and the HeaderFunc
+option to set headers dynamically before every attempt. The headers are merged in this order: the headers
+set on the request, including the ones from the request options, take precedence over the headers set by the
+client, such as Authorization, which take precedence over the Header option; the HeaderFunc option is called
+last and can change any header. The caller's request headers are never modified by the default headers.
+
+Requests rejected by Elasticsearch with 429 Too Many Requests are not retried by default.
+Set RetryOnTooManyRequests to true to retry them: the delay respects the Retry-After response header,
+and a jittered exponential backoff is used when the RetryBackoff option is not set.
+The number of rejected attempts is reported as Throttled in the metrics.
+
 When multiple addresses are passed in configuration, the package will use them in a round-robin fashion,
 and will keep track of live and dead nodes. The status of dead nodes is checked periodically.
 
+By default, a dead node is resurrected after a timeout which grows with the number of its failures.
+Set the HealthCheckInterval option to probe the dead nodes with a "HEAD /" request instead: a node is
+returned to the live list only after a successful probe, so the requests fail when no node is alive.
+Use the HealthCheckTimeout and HealthCheckConcurrency options to limit the duration and the number
+of concurrent probes; the results are reported as HealthChecks and HealthCheckFailures in the metrics.
+
+Use the DiscoverNodes method or the DiscoverNodesInterval option to load the list of nodes from the cluster.
+By default, only the nodes with the data and ingest roles are used; provide a custom DiscoveryFilter function
+to change it, or use one of the bundled filters, eg. ExcludeMasterOnlyNodes, MatchNodeRoles or MatchNodeAttribute.
+The discovered nodes are merged into the existing connection pool: the connections to known nodes,
+matched by node ID or URL, keep their health state, and the nodes removed from the cluster are dropped.
+
+Set the CircuitBreaker option to enable the circuit breakers for every node and for the whole cluster.
+A node whose breaker is open, because of the error rate or the response times over the latency threshold,
+is skipped by the transport; when the breaker for the cluster is open, Perform fails fast with ErrCircuitOpen.
+After the open timeout, a limited number of trial requests is allowed, and the breaker closes when they succeed.
+The state changes are logged by the debugging logger, reported to the OnStateChange function, and recorded
+in the metrics.
+
+To reduce the tail latency of the read-only requests, enable the hedged requests with the WithHedging
+context option: when the node doesn't respond within the HedgeDelay, the request is sent to another
+live node as well, and the first successful response is returned, while the other request is cancelled.
+Only the search, count and multi-get requests, and the GET and HEAD requests for documents are hedged:
+
+	res, err := es.Search(es.Search.WithContext(estransport.WithHedging(ctx)))
+
+The duplicate request counts against the rate and concurrency limits, and it is sent only when
+the limits allow it without waiting.
+
+Use the RateLimits option to limit the rate of requests, the rate of request body bytes, and the number
+of concurrent requests, either for all requests or for the requests with a matching path, eg. to limit
+the bulk requests separately:
+
+	tp := estransport.New(estransport.Config{
+		RateLimits: []estransport.RateLimit{
+			{RequestsPerSecond: 100},
+			{PathPattern: regexp.MustCompile(`/_bulk$`), BytesPerSecond: 10 << 20, MaxInFlight: 2},
+		},
+	})
+
+The limits apply to every attempt, including the retries. The waiting respects the request context,
+and the time spent waiting is reported as RateLimitWait in the metrics.
+
+Call the Close method to stop the periodic node discovery, the health checks and the scheduled resurrection
+of dead connections, and to wait for the in-flight requests to finish; any request performed afterwards
+returns ErrClosed.
+
 To customize the node selection behaviour, provide a Selector implementation in the configuration.
+Besides the default round-robin selector, the package provides selectors returning a random connection
+(NewRandomSelector), a random connection weighted by the connection Weight (NewWeightedSelector),
+the connection with the lowest number of outstanding requests (NewLeastOutstandingSelector), and the less
+loaded of two random connections, based on the moving average of the response time (NewPowerOfTwoSelector).
+The transport keeps these statistics for every connection; use the InFlight and Latency methods
+to access them in custom selectors. A request is outstanding until its response body is closed.
+
+The weight of the connections is set by the ConnectionWeight function, when the connections are created
+and when they are updated by the node discovery; use AttributeWeight to read it from a node attribute:
+
+	tp := estransport.New(estransport.Config{
+		Selector:         estransport.NewWeightedSelector(),
+		ConnectionWeight: estransport.AttributeWeight("weight"),
+	})
+
+Use NewAffinitySelector to prefer the nodes with a matching attribute, eg. the availability zone
+of the application, as reported by the node discovery; the other nodes are used only when no matching
+node is alive. Call WithPreference to add the preference parameter, eg. "_local", to the requests
+for the API endpoints which accept it, so the shard copies are chosen in the same way:
+
+	tp := estransport.New(estransport.Config{
+		Selector: estransport.NewAffinitySelector("zone", "us-east-1a").WithPreference("_local"),
+	})
+
 To replace the connection pool entirely, provide a custom ConnectionPool implementation via
 the ConnectionPoolFunc option.
 
@@ -29,6 +124,16 @@ It comes with several bundled loggers for logging in text and JSON.
 
 Use the EnableDebugLogger option to enable the debugging logger for connection management.
 
-Use the EnableMetrics option to enable metric collection and export.
+Use the EnableMetrics option to enable metric collection and export. Besides the counters of requests,
+failures and responses, the metrics include the latency histograms per node and per API endpoint,
+the number of retries, and the number of bytes sent and received. Use NewPrometheusHandler to expose
+the metrics in the Prometheus text exposition format:
+
+	http.Handle("/metrics", estransport.NewPrometheusHandler(es))
+
+Provide an Instrumentation implementation in the configuration to trace the requests: a span is started
+for every logical request, with the endpoint name (eg. "search" or "indices.create") and index passed
+from the esapi package, and every attempt is recorded with the node URL, status code and error.
+The RecordingInstrumentation type records the spans in memory, which is useful in tests.
 */
 package estransport
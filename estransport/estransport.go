@@ -6,15 +6,20 @@ package estransport
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/rand"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -32,6 +37,16 @@ var (
 
 	defaultMaxRetries    = 3
 	defaultRetryOnStatus = [...]int{502, 503, 504}
+
+	defaultRetryBackoffMin = 100 * time.Millisecond
+	defaultRetryBackoffMax = 10 * time.Second
+
+	// ErrClosed is returned for requests performed after the client was closed.
+	ErrClosed = errors.New("transport is closed")
+
+	gzipWriterPool = sync.Pool{
+		New: func() interface{} { return gzip.NewWriter(ioutil.Discard) },
+	}
 )
 
 func init() {
@@ -44,6 +59,12 @@ type Interface interface {
 	Perform(*http.Request) (*http.Response, error)
 }
 
+// Closeable defines the interface for transports supporting graceful shutdown.
+//
+type Closeable interface {
+	Close(context.Context) error
+}
+
 // Config represents the configuration of HTTP client.
 //
 type Config struct {
@@ -52,21 +73,54 @@ type Config struct {
 	Password string
 	APIKey   string
 
+	CompressRequestBody bool
+
 	RetryOnStatus        []int
 	DisableRetry         bool
 	EnableRetryOnTimeout bool
 	MaxRetries           int
 	RetryBackoff         func(attempt int) time.Duration
+	RetryTimeout         time.Duration // The time limit for the request, including all retries and backoff delays.
+	AttemptTimeout       time.Duration // The time limit for a single attempt; an exceeded attempt is retried.
+
+	// RetryOnTooManyRequests enables retrying the requests rejected with 429 Too Many Requests,
+	// honouring the Retry-After header; when RetryBackoff is nil, a jittered exponential backoff is used.
+	RetryOnTooManyRequests bool
 
 	EnableMetrics     bool
 	EnableDebugLogger bool
 
+	Instrumentation Instrumentation
+
 	DiscoverNodesInterval time.Duration
+	DiscoveryFilter       func(NodeInfo) bool // Default: nodes with the data and ingest roles.
+
+	HealthCheckInterval    time.Duration // Probe the dead connections periodically. Default: disabled.
+	HealthCheckTimeout     time.Duration // The time limit for a single probe. Default: 5s.
+	HealthCheckConcurrency int           // The maximum number of concurrent probes. Default: 4.
+
+	CircuitBreaker *CircuitBreakerConfig // Enable the circuit breakers per node and per cluster. Default: disabled.
+
+	HedgeDelay time.Duration // The delay before hedging the requests enabled with WithHedging. Default: 100ms.
+
+	RateLimits []RateLimit // Limit the rate and concurrency of requests, globally or per path. Default: disabled.
+
+	// Header is added to every request; the headers already set on the request,
+	// or by the client, such as Authorization, are not overridden.
+	Header http.Header
+
+	// HeaderFunc is called before every attempt, after the headers have been set,
+	// and can add, change or remove any header of the request.
+	HeaderFunc func(*http.Request)
 
 	Transport http.RoundTripper
 	Logger    Logger
 	Selector  Selector
 
+	// ConnectionWeight returns the weight of the connection for the weighted selector;
+	// it is called when the connections are created, and when they are updated by the node discovery.
+	ConnectionWeight func(*Connection) int
+
 	ConnectionPoolFunc func([]*Connection, Selector) ConnectionPool
 }
 
@@ -80,18 +134,45 @@ type Client struct {
 	password string
 	apikey   string
 
+	compressRequestBody bool
+
 	retryOnStatus         []int
 	disableRetry          bool
 	enableRetryOnTimeout  bool
+	enableRetryOn429      bool
 	maxRetries            int
 	retryBackoff          func(attempt int) time.Duration
+	retryTimeout          time.Duration
+	attemptTimeout        time.Duration
 	discoverNodesInterval time.Duration
+	discoverNodesTimer    *time.Timer
+	discoveryFilter       func(NodeInfo) bool
+
+	healthCheckInterval    time.Duration
+	healthCheckTimeout     time.Duration
+	healthCheckConcurrency int
+	healthCheckTimer       *time.Timer
+
+	circuitBreakerConfig *CircuitBreakerConfig
+	circuitBreaker       *circuitBreaker
+
+	hedgeDelay time.Duration
+
+	rateLimiters []*rateLimiter
+
+	header     http.Header
+	headerFunc func(*http.Request)
 
-	metrics *metrics
+	closed   bool
+	inflight sync.WaitGroup
+
+	metrics         *metrics
+	instrumentation Instrumentation
 
 	transport http.RoundTripper
 	logger    Logger
 	selector  Selector
+	weight    func(*Connection) int
 	pool      ConnectionPool
 	poolFunc  func([]*Connection, Selector) ConnectionPool
 }
@@ -113,6 +194,26 @@ func New(cfg Config) *Client {
 		cfg.MaxRetries = defaultMaxRetries
 	}
 
+	if cfg.HealthCheckTimeout == 0 {
+		cfg.HealthCheckTimeout = defaultHealthCheckTimeout
+	}
+
+	if cfg.HealthCheckConcurrency == 0 {
+		cfg.HealthCheckConcurrency = defaultHealthCheckConcurrency
+	}
+
+	if cfg.HedgeDelay == 0 {
+		cfg.HedgeDelay = defaultHedgeDelay
+	}
+
+	if cfg.DiscoveryFilter == nil {
+		cfg.DiscoveryFilter = MatchNodeRoles("data", "ingest")
+	}
+
+	if cfg.Instrumentation == nil {
+		cfg.Instrumentation = noopInstrumentation{}
+	}
+
 	var conns []*Connection
 	for _, u := range cfg.URLs {
 		conns = append(conns, &Connection{URL: u})
@@ -124,19 +225,40 @@ func New(cfg Config) *Client {
 		password: cfg.Password,
 		apikey:   cfg.APIKey,
 
+		compressRequestBody: cfg.CompressRequestBody,
+
 		retryOnStatus:         cfg.RetryOnStatus,
 		disableRetry:          cfg.DisableRetry,
 		enableRetryOnTimeout:  cfg.EnableRetryOnTimeout,
+		enableRetryOn429:      cfg.RetryOnTooManyRequests,
 		maxRetries:            cfg.MaxRetries,
 		retryBackoff:          cfg.RetryBackoff,
+		retryTimeout:          cfg.RetryTimeout,
+		attemptTimeout:        cfg.AttemptTimeout,
 		discoverNodesInterval: cfg.DiscoverNodesInterval,
+		discoveryFilter:       cfg.DiscoveryFilter,
+
+		healthCheckInterval:    cfg.HealthCheckInterval,
+		healthCheckTimeout:     cfg.HealthCheckTimeout,
+		healthCheckConcurrency: cfg.HealthCheckConcurrency,
+
+		hedgeDelay: cfg.HedgeDelay,
+
+		headerFunc: cfg.HeaderFunc,
+
+		instrumentation: cfg.Instrumentation,
 
 		transport: cfg.Transport,
 		logger:    cfg.Logger,
 		selector:  cfg.Selector,
+		weight:    cfg.ConnectionWeight,
 		poolFunc:  cfg.ConnectionPoolFunc,
 	}
 
+	for _, conn := range conns {
+		client.setConnectionWeight(conn)
+	}
+
 	if client.poolFunc != nil {
 		client.pool = client.poolFunc(conns, client.selector)
 	} else {
@@ -148,28 +270,112 @@ func New(cfg Config) *Client {
 	}
 
 	if cfg.EnableMetrics {
-		client.metrics = &metrics{responses: make(map[int]int)}
-		// TODO(karmi): Type assertion to interface
-		if pool, ok := client.pool.(*singleConnectionPool); ok {
-			pool.metrics = client.metrics
-		}
-		if pool, ok := client.pool.(*statusConnectionPool); ok {
-			pool.metrics = client.metrics
+		client.metrics = newMetrics()
+	}
+
+	client.configurePool(client.pool)
+
+	if len(cfg.Header) > 0 {
+		client.header = make(http.Header, len(cfg.Header))
+		for k, vv := range cfg.Header {
+			k = http.CanonicalHeaderKey(k)
+			client.header[k] = append(client.header[k], vv...)
 		}
 	}
 
+	for _, l := range cfg.RateLimits {
+		client.rateLimiters = append(client.rateLimiters, newRateLimiter(l))
+	}
+
+	if cfg.CircuitBreaker != nil {
+		client.circuitBreakerConfig = newCircuitBreakerConfig(cfg.CircuitBreaker)
+		client.circuitBreaker = &circuitBreaker{name: circuitClusterName, cfg: client.circuitBreakerConfig}
+	}
+
 	if client.discoverNodesInterval > 0 {
-		time.AfterFunc(client.discoverNodesInterval, func() {
+		client.Lock()
+		client.discoverNodesTimer = time.AfterFunc(client.discoverNodesInterval, func() {
 			client.scheduleDiscoverNodes(client.discoverNodesInterval)
 		})
+		client.Unlock()
+	}
+
+	if client.healthCheckInterval > 0 {
+		client.scheduleHealthCheck(client.healthCheckInterval)
 	}
 
 	return &client
 }
 
+// configurePool passes the metrics and the health check configuration to the connection pool.
+//
+func (c *Client) configurePool(pool ConnectionPool) {
+	// TODO(karmi): Type assertion to interface
+	if pool, ok := pool.(*singleConnectionPool); ok {
+		pool.metrics = c.metrics
+	}
+	if pool, ok := pool.(*statusConnectionPool); ok {
+		pool.metrics = c.metrics
+		pool.healthChecked = c.healthCheckInterval > 0
+	}
+}
+
 // Perform executes the request and returns a response or error.
 //
 func (c *Client) Perform(req *http.Request) (*http.Response, error) {
+	if err := c.beginRequest(); err != nil {
+		return nil, err
+	}
+	defer c.inflight.Done()
+
+	// Fail fast when the circuit breaker for the cluster is open
+	if c.circuitBreaker != nil && !c.allowCircuit(c.circuitBreaker) {
+		c.rejectCircuit()
+		return nil, ErrCircuitOpen
+	}
+
+	info := newRequestInfo(req)
+	ctx, span := c.instrumentation.Start(req.Context(), info)
+
+	req = req.WithContext(ctx)
+	c.setReqPreference(req, info.Endpoint)
+
+	start := time.Now()
+	res, err := c.perform(req, span)
+	dur := time.Since(start)
+
+	var statusCode int
+	if res != nil {
+		statusCode = res.StatusCode
+	}
+	span.End(statusCode, err)
+
+	// Report the request to the circuit breaker for the cluster, unless it was cancelled by the caller
+	if c.circuitBreaker != nil {
+		c.reportCircuit(c.circuitBreaker, c.circuitResult(res, err, req.Context().Err() != nil))
+	}
+
+	// Record metrics, when enabled
+	if c.metrics != nil {
+		name := info.Endpoint
+		if name == "" {
+			name = "unknown"
+		}
+		c.metrics.Lock()
+		c.metrics.observeLatency(c.metrics.endpointLatency, name, dur)
+		c.metrics.Unlock()
+
+		if res != nil && res.Body != nil {
+			res.Body = &countingReadCloser{ReadCloser: res.Body, metrics: c.metrics}
+		}
+	}
+
+	return res, err
+}
+
+// perform executes the request, retrying it when necessary, and records the attempts in span.
+//
+func (c *Client) perform(req *http.Request, span Span) (*http.Response, error) {
 	var (
 		res *http.Response
 		err error
@@ -182,47 +388,119 @@ func (c *Client) Perform(req *http.Request) (*http.Response, error) {
 		c.metrics.Unlock()
 	}
 
+	// Apply the request options
+	rc := c.requestConfig(req)
+
 	// Update request
 	c.setReqUserAgent(req)
 
-	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
-		if !c.disableRetry || (c.logger != nil && c.logger.RequestBodyEnabled()) {
-			var buf bytes.Buffer
-			buf.ReadFrom(req.Body)
-			req.GetBody = func() (io.ReadCloser, error) {
-				r := buf
-				return ioutil.NopCloser(&r), nil
+	if req.Body != nil && req.Body != http.NoBody {
+		if c.compressRequestBody {
+			if err := c.compressReqBody(req); err != nil {
+				return nil, fmt.Errorf("cannot compress request body: %s", err)
 			}
-			if req.Body, err = req.GetBody(); err != nil {
-				return nil, fmt.Errorf("cannot get request body: %s", err)
+		} else if req.GetBody == nil {
+			if !rc.disableRetry || (c.logger != nil && c.logger.RequestBodyEnabled()) {
+				var buf bytes.Buffer
+				buf.ReadFrom(req.Body)
+				req.GetBody = func() (io.ReadCloser, error) {
+					r := buf
+					return ioutil.NopCloser(&r), nil
+				}
+				if req.Body, err = req.GetBody(); err != nil {
+					return nil, fmt.Errorf("cannot get request body: %s", err)
+				}
+				req.ContentLength = int64(buf.Len())
 			}
 		}
 	}
 
-	for i := 1; i <= c.maxRetries; i++ {
+	// Keep the request URL and headers, so every attempt is prepared for its connection from the original ones
+	var (
+		origURL = *req.URL
+		header  = req.Header
+	)
+
+	// Set up the context for the whole request, including retries
+	var (
+		ctx    = req.Context()
+		cancel = func() {}
+	)
+	if rc.retryTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, rc.retryTimeout)
+		req = req.WithContext(ctx)
+	}
+
+	var (
+		attemptCancel = func() {}
+		lastErr       error
+		hedgeDelay    = c.hedgeDelayFor(req)
+	)
+
+	for i := 1; i <= rc.maxRetries; i++ {
 		var (
 			conn        *Connection
 			shouldRetry bool
+			throttled   bool
+			retryAfter  time.Duration
+			release     = func() {}
 		)
 
+		// Stop when the context is cancelled or the deadline is exceeded
+		if ctx.Err() != nil {
+			cancel()
+			return nil, &contextError{err: ctx.Err(), lastErr: lastErr}
+		}
+
+		// Wait for the rate and concurrency limits, for every attempt
+		if len(c.rateLimiters) > 0 {
+			if release, err = c.waitRateLimits(ctx, req); err != nil {
+				cancel()
+				return nil, err
+			}
+		}
+
 		// Get connection from the pool
-		c.Lock()
-		conn, err = c.pool.Next()
-		c.Unlock()
+		conn, err = c.nextConnection()
 		if err != nil {
 			if c.logger != nil {
 				c.logRoundTrip(req, nil, err, time.Time{}, time.Duration(0))
 			}
+			cancel()
+			release()
+			if err == ErrCircuitOpen {
+				return nil, err
+			}
 			return nil, fmt.Errorf("cannot get connection: %s", err)
 		}
 
+		// Record metrics, when enabled
+		if c.metrics != nil && i > 1 {
+			c.metrics.Lock()
+			c.metrics.retries++
+			c.metrics.Unlock()
+		}
+
+		// Set up the context for a single attempt
+		if c.attemptTimeout > 0 {
+			var attemptCtx context.Context
+			attemptCtx, attemptCancel = context.WithTimeout(ctx, c.attemptTimeout)
+			req = req.WithContext(attemptCtx)
+		}
+
 		// Update request
-		c.setReqURL(conn.URL, req)
-		c.setReqAuth(conn.URL, req)
+		c.setReqConnection(req, conn, origURL, header)
 
-		if !c.disableRetry && i > 1 && req.Body != nil && req.Body != http.NoBody {
+		if !rc.disableRetry && i > 1 && req.Body != nil && req.Body != http.NoBody {
 			body, err := req.GetBody()
 			if err != nil {
+				// Release the trial request of the half-open circuit breaker
+				if c.circuitBreaker != nil {
+					c.reportCircuit(c.connectionBreaker(conn), circuitIgnored)
+				}
+				release()
+				attemptCancel()
+				cancel()
 				return nil, fmt.Errorf("cannot get request body: %s", err)
 			}
 			req.Body = body
@@ -230,7 +508,18 @@ func (c *Client) Perform(req *http.Request) (*http.Response, error) {
 
 		// Set up time measures and execute the request
 		start := time.Now().UTC()
-		res, err = c.transport.RoundTrip(req)
+		if hedgeDelay > 0 {
+			res, conn, err = c.hedgedRoundTrip(req, conn, hedgeDelay, origURL, header, release)
+		} else {
+			res, err = c.roundTrip(req, conn)
+
+			// Release the concurrency limits when the response body is closed
+			if res != nil && res.Body != nil {
+				res.Body = &cancelReadCloser{ReadCloser: res.Body, cancel: release}
+			} else {
+				release()
+			}
+		}
 		dur := time.Since(start)
 
 		// Log request and response
@@ -241,6 +530,28 @@ func (c *Client) Perform(req *http.Request) (*http.Response, error) {
 			c.logRoundTrip(req, res, err, start, dur)
 		}
 
+		// Record metrics, when enabled
+		if c.metrics != nil {
+			c.metrics.Lock()
+			if req.ContentLength > 0 {
+				c.metrics.bytesSent += req.ContentLength
+			}
+			c.metrics.observeLatency(c.metrics.nodeLatency, conn.URL.String(), dur)
+			c.metrics.Unlock()
+		}
+
+		// Record the attempt
+		attempt := AttemptInfo{Attempt: i, URL: conn.URL, Err: err, Duration: dur}
+		if res != nil {
+			attempt.StatusCode = res.StatusCode
+		}
+		span.RecordAttempt(attempt)
+
+		// Report the attempt to the circuit breaker of the connection, unless the request was cancelled
+		if c.circuitBreaker != nil {
+			c.reportCircuit(c.connectionBreaker(conn), c.connectionResult(res, err, dur, ctx.Err() != nil))
+		}
+
 		if err != nil {
 			// Record metrics, when enabled
 			if c.metrics != nil {
@@ -249,6 +560,13 @@ func (c *Client) Perform(req *http.Request) (*http.Response, error) {
 				c.metrics.Unlock()
 			}
 
+			// Don't report the connection when the request was cancelled by the caller
+			if ctx.Err() != nil {
+				attemptCancel()
+				cancel()
+				return nil, &contextError{err: ctx.Err(), lastErr: err}
+			}
+
 			// Report the connection as unsuccessful
 			c.Lock()
 			c.pool.OnFailure(conn)
@@ -261,10 +579,17 @@ func (c *Client) Perform(req *http.Request) (*http.Response, error) {
 
 			// Retry on network errors, but not on timeout errors, unless configured
 			if err, ok := err.(net.Error); ok {
-				if (!err.Timeout() || c.enableRetryOnTimeout) && !c.disableRetry {
+				if (!err.Timeout() || c.enableRetryOnTimeout) && !rc.disableRetry {
 					shouldRetry = true
 				}
 			}
+
+			// Retry when the attempt timeout is exceeded
+			if req.Context().Err() == context.DeadlineExceeded && !rc.disableRetry {
+				shouldRetry = true
+			}
+
+			lastErr = err
 		} else {
 			// Report the connection as succesfull
 			c.Lock()
@@ -279,29 +604,212 @@ func (c *Client) Perform(req *http.Request) (*http.Response, error) {
 		}
 
 		// Retry on configured response statuses
-		if res != nil && !c.disableRetry {
-			for _, code := range c.retryOnStatus {
+		if res != nil && !rc.disableRetry {
+			for _, code := range rc.retryOnStatus {
 				if res.StatusCode == code {
 					shouldRetry = true
 				}
 			}
 		}
 
+		// Retry on rejected requests, when enabled, respecting the Retry-After header
+		if res != nil && res.StatusCode == http.StatusTooManyRequests {
+			if c.metrics != nil {
+				c.metrics.Lock()
+				c.metrics.throttled++
+				c.metrics.Unlock()
+			}
+
+			if c.enableRetryOn429 && !rc.disableRetry {
+				shouldRetry = true
+				throttled = true
+				retryAfter = parseRetryAfter(res.Header.Get("Retry-After"), time.Now())
+			}
+		}
+
 		// Break if retry should not be performed
-		if !shouldRetry {
+		if !shouldRetry || i == rc.maxRetries {
 			break
 		}
 
-		// Delay the retry if a backoff function is configured
-		if c.retryBackoff != nil {
-			time.Sleep(c.retryBackoff(i))
+		// Discard the response which is going to be retried
+		if res != nil {
+			if res.Body != nil {
+				io.Copy(ioutil.Discard, res.Body) // errcheck exclude
+				res.Body.Close()
+			}
+			lastErr = fmt.Errorf("unexpected response status: %d", res.StatusCode)
+		}
+		attemptCancel()
+
+		// Delay the retry if requested by the server, or if a backoff function is configured
+		var backoff time.Duration
+		switch {
+		case retryAfter > 0:
+			backoff = retryAfter
+		case c.retryBackoff != nil:
+			backoff = c.retryBackoff(i)
+		case throttled:
+			backoff = defaultRetryBackoff(i)
+		}
+		if backoff > 0 {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				cancel()
+				return nil, &contextError{err: ctx.Err(), lastErr: lastErr}
+			case <-timer.C:
+			}
 		}
 	}
 
+	// Release the contexts when the response body is closed
+	if res != nil && res.Body != nil {
+		res.Body = &cancelReadCloser{ReadCloser: res.Body, cancel: func() { attemptCancel(); cancel() }}
+	} else {
+		attemptCancel()
+		cancel()
+	}
+
 	// TODO(karmi): Wrap error
 	return res, err
 }
 
+// defaultRetryBackoff returns an exponential backoff duration for attempt,
+// with a random jitter of up to half of the duration.
+//
+func defaultRetryBackoff(attempt int) time.Duration {
+	d := defaultRetryBackoffMax
+	if attempt < 16 {
+		if exp := defaultRetryBackoffMin << uint(attempt-1); exp < d {
+			d = exp
+		}
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// parseRetryAfter returns the duration from the value of the Retry-After header,
+// which can be either a number of seconds or a HTTP date.
+//
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// contextError wraps the context error together with the last transport error.
+//
+type contextError struct {
+	err     error
+	lastErr error
+}
+
+// Error returns the error as a string.
+//
+func (e *contextError) Error() string {
+	if e.lastErr == nil {
+		return e.err.Error()
+	}
+	return e.err.Error() + " (last error: " + e.lastErr.Error() + ")"
+}
+
+// Unwrap returns the context error.
+//
+func (e *contextError) Unwrap() error {
+	return e.err
+}
+
+// cancelReadCloser calls the cancel function when the reader is closed.
+//
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel func()
+}
+
+// Close closes the reader and calls the cancel function.
+//
+func (r *cancelReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+	r.cancel()
+	return err
+}
+
+// Close stops the periodic node discovery, the health checks and the scheduled resurrection of dead connections,
+// and waits until the in-flight requests are finished, or until ctx is done.
+//
+// Any request performed after Close returns ErrClosed. It is safe to call Close multiple times.
+//
+func (c *Client) Close(ctx context.Context) error {
+	c.Lock()
+	if c.closed {
+		c.Unlock()
+		return nil
+	}
+	c.closed = true
+	if c.discoverNodesTimer != nil {
+		c.discoverNodesTimer.Stop()
+	}
+	if c.healthCheckTimer != nil {
+		c.healthCheckTimer.Stop()
+	}
+	pool := c.pool
+	c.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		c.inflight.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	// Cancel the scheduled resurrection of dead connections
+	if lockable, ok := pool.(sync.Locker); ok {
+		lockable.Lock()
+		defer lockable.Unlock()
+	}
+	if pool, ok := pool.(connectionable); ok {
+		for _, conn := range pool.connections() {
+			conn.Lock()
+			conn.cancelResurrect()
+			conn.Unlock()
+		}
+	}
+
+	return err
+}
+
+// beginRequest registers an in-flight request, or returns ErrClosed when the client is closed.
+//
+func (c *Client) beginRequest() error {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.closed {
+		return ErrClosed
+	}
+	c.inflight.Add(1)
+	return nil
+}
+
 // URLs returns a list of transport URLs.
 //
 //
@@ -309,6 +817,17 @@ func (c *Client) URLs() []*url.URL {
 	return c.pool.URLs()
 }
 
+// setReqConnection sets the URL, the authorization and the headers of the request for the connection,
+// starting from the original URL and headers, so the request doesn't share them with the other attempts.
+//
+func (c *Client) setReqConnection(req *http.Request, conn *Connection, u url.URL, header http.Header) {
+	req.URL = &u
+	req.Header = cloneHeader(header)
+	c.setReqURL(conn.URL, req)
+	c.setReqAuth(conn.URL, req)
+	c.setReqHeaders(req)
+}
+
 func (c *Client) setReqURL(u *url.URL, req *http.Request) *http.Request {
 	req.URL.Scheme = u.Scheme
 	req.URL.Host = u.Host
@@ -355,6 +874,61 @@ func (c *Client) setReqUserAgent(req *http.Request) *http.Request {
 	return req
 }
 
+// setReqHeaders adds the default headers which are not set on the request,
+// and calls the header function, when configured.
+//
+func (c *Client) setReqHeaders(req *http.Request) *http.Request {
+	for k, vv := range c.header {
+		if _, ok := req.Header[k]; !ok {
+			req.Header[k] = append([]string(nil), vv...)
+		}
+	}
+
+	if c.headerFunc != nil {
+		c.headerFunc(req)
+	}
+
+	return req
+}
+
+// cloneHeader returns a deep copy of h.
+//
+func cloneHeader(h http.Header) http.Header {
+	h2 := make(http.Header, len(h))
+	for k, vv := range h {
+		h2[k] = append([]string(nil), vv...)
+	}
+	return h2
+}
+
+// compressReqBody replaces the request body with its gzip-compressed copy,
+// and sets the GetBody function, so the body can be re-read for retries.
+//
+func (c *Client) compressReqBody(req *http.Request) error {
+	var buf bytes.Buffer
+
+	zw := gzipWriterPool.Get().(*gzip.Writer)
+	defer gzipWriterPool.Put(zw)
+	zw.Reset(&buf)
+
+	if _, err := io.Copy(zw, req.Body); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	req.GetBody = func() (io.ReadCloser, error) {
+		r := buf
+		return ioutil.NopCloser(&r), nil
+	}
+	req.Body, _ = req.GetBody()
+	req.ContentLength = int64(buf.Len())
+	req.Header.Set("Content-Encoding", "gzip")
+
+	return nil
+}
+
 func (c *Client) logRoundTrip(
 	req *http.Request,
 	res *http.Response,
@@ -366,6 +940,9 @@ func (c *Client) logRoundTrip(
 	if res != nil {
 		dupRes = *res
 	}
+	if c.logger.RequestBodyEnabled() && req.Header.Get("Content-Encoding") == "gzip" {
+		req = decompressedRequest(req)
+	}
 	if c.logger.ResponseBodyEnabled() {
 		if res != nil && res.Body != nil && res.Body != http.NoBody {
 			b1, b2, _ := duplicateBody(res.Body)
@@ -376,6 +953,47 @@ func (c *Client) logRoundTrip(
 	c.logger.LogRoundTrip(req, &dupRes, err, start, dur) // errcheck exclude
 }
 
+// decompressedRequest returns a copy of the request with uncompressed body,
+// so the loggers can display it.
+//
+func decompressedRequest(req *http.Request) *http.Request {
+	if req.GetBody == nil {
+		return req
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return req
+	}
+	zr, err := gzip.NewReader(body)
+	if err != nil {
+		return req
+	}
+	defer zr.Close()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(zr); err != nil {
+		return req
+	}
+
+	dupReq := *req
+	dupReq.Header = make(http.Header, len(req.Header))
+	for k, vv := range req.Header {
+		if k == "Content-Encoding" {
+			continue
+		}
+		dupReq.Header[k] = vv
+	}
+	dupReq.ContentLength = int64(buf.Len())
+	dupReq.GetBody = func() (io.ReadCloser, error) {
+		r := buf
+		return ioutil.NopCloser(&r), nil
+	}
+	dupReq.Body, _ = dupReq.GetBody()
+
+	return &dupReq
+}
+
 func initUserAgent() string {
 	var b strings.Builder
 
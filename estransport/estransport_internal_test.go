@@ -7,6 +7,8 @@
 package estransport
 
 import (
+	"compress/gzip"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -15,6 +17,7 @@ import (
 	"net/url"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -338,6 +341,85 @@ func TestTransportPerform(t *testing.T) {
 		}
 	})
 
+	t.Run("Compresses request body", func(t *testing.T) {
+		var (
+			bodies   []string
+			encoding string
+			length   int64
+		)
+
+		u, _ := url.Parse("http://foo.bar")
+		tp := New(Config{
+			URLs:                []*url.URL{u, u},
+			CompressRequestBody: true,
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					encoding = req.Header.Get("Content-Encoding")
+					length = req.ContentLength
+
+					zr, err := gzip.NewReader(req.Body)
+					if err != nil {
+						return nil, err
+					}
+					body, err := ioutil.ReadAll(zr)
+					if err != nil {
+						return nil, err
+					}
+					bodies = append(bodies, string(body))
+
+					return &http.Response{Status: "MOCK", StatusCode: 502}, nil
+				},
+			}})
+
+		req, _ := http.NewRequest("POST", "/abc", strings.NewReader(strings.Repeat("FOOBAR", 100)))
+
+		if _, err := tp.Perform(req); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if encoding != "gzip" {
+			t.Errorf("Unexpected Content-Encoding header: %q", encoding)
+		}
+
+		if length <= 0 || length >= 600 {
+			t.Errorf("Unexpected ContentLength: %d", length)
+		}
+
+		if n := len(bodies); n != 3 {
+			t.Fatalf("expected 3 requests, got %d", n)
+		}
+		for i, body := range bodies {
+			if body != strings.Repeat("FOOBAR", 100) {
+				t.Fatalf("request %d body: unexpected value %q", i, body)
+			}
+		}
+	})
+
+	t.Run("Doesn't compress empty request body", func(t *testing.T) {
+		var encoding string
+
+		u, _ := url.Parse("http://foo.bar")
+		tp := New(Config{
+			URLs:                []*url.URL{u},
+			CompressRequestBody: true,
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					encoding = req.Header.Get("Content-Encoding")
+					return &http.Response{Status: "MOCK"}, nil
+				},
+			}})
+
+		req, _ := http.NewRequest("GET", "/abc", nil)
+
+		if _, err := tp.Perform(req); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if encoding != "" {
+			t.Errorf("Unexpected Content-Encoding header: %q", encoding)
+		}
+	})
+
 	t.Run("Error No URL", func(t *testing.T) {
 		tp := New(Config{
 			URLs: []*url.URL{},
@@ -652,6 +734,564 @@ func TestTransportPerformRetries(t *testing.T) {
 			t.Errorf("Unexpected duration, want=>%s, got=%s", expectedDuration, end)
 		}
 	})
+
+	t.Run("Stop retrying when the context is cancelled", func(t *testing.T) {
+		var i int
+
+		u, _ := url.Parse("http://foo.bar")
+		tp := New(Config{
+			URLs: []*url.URL{u, u, u},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					i++
+					return nil, &mockNetError{error: fmt.Errorf("Mock network error (%d)", i)}
+				},
+			},
+			RetryBackoff: func(i int) time.Duration { return time.Hour },
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		req, _ := http.NewRequest("GET", "/abc", nil)
+		res, err := tp.Perform(req.WithContext(ctx))
+
+		if err == nil {
+			t.Fatalf("Expected error, got: %v", err)
+		}
+
+		if res != nil {
+			t.Errorf("Unexpected response: %+v", res)
+		}
+
+		if i != 1 {
+			t.Errorf("Unexpected number of requests, want=%d, got=%d", 1, i)
+		}
+
+		if e, ok := err.(interface{ Unwrap() error }); !ok || e.Unwrap() != context.DeadlineExceeded {
+			t.Errorf("Expected the context error, got: %#v", err)
+		}
+
+		if !strings.Contains(err.Error(), "Mock network error (1)") {
+			t.Errorf("Expected the error to contain the last transport error, got: %s", err)
+		}
+	})
+
+	t.Run("Don't mark the connection as dead when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		u, _ := url.Parse("http://foo.bar")
+		tp := New(Config{
+			URLs: []*url.URL{u, u},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					cancel()
+					return nil, &mockNetError{error: req.Context().Err()}
+				},
+			},
+		})
+
+		req, _ := http.NewRequest("GET", "/abc", nil)
+		if _, err := tp.Perform(req.WithContext(ctx)); err == nil {
+			t.Fatalf("Expected error, got: %v", err)
+		}
+
+		pool := tp.pool.(*statusConnectionPool)
+		if len(pool.dead) != 0 {
+			t.Errorf("Unexpected dead connections: %v", pool.dead)
+		}
+	})
+
+	t.Run("Limit the total duration with RetryTimeout", func(t *testing.T) {
+		var i int
+
+		u, _ := url.Parse("http://foo.bar")
+		tp := New(Config{
+			URLs: []*url.URL{u, u, u},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					i++
+					return &http.Response{StatusCode: 502, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+				},
+			},
+			MaxRetries:   10,
+			RetryBackoff: func(i int) time.Duration { return 40 * time.Millisecond },
+			RetryTimeout: 100 * time.Millisecond,
+		})
+
+		req, _ := http.NewRequest("GET", "/abc", nil)
+
+		start := time.Now()
+		_, err := tp.Perform(req)
+		dur := time.Since(start)
+
+		if err == nil {
+			t.Fatalf("Expected error, got: %v", err)
+		}
+
+		if e, ok := err.(interface{ Unwrap() error }); !ok || e.Unwrap() != context.DeadlineExceeded {
+			t.Errorf("Expected the context error, got: %#v", err)
+		}
+
+		if i < 2 || i > 4 {
+			t.Errorf("Unexpected number of requests: %d", i)
+		}
+
+		if dur > time.Second {
+			t.Errorf("Unexpected duration: %s", dur)
+		}
+	})
+
+	t.Run("Retry the request when AttemptTimeout is exceeded", func(t *testing.T) {
+		var urls []string
+
+		u1, _ := url.Parse("http://foo1.bar")
+		u2, _ := url.Parse("http://foo2.bar")
+		tp := New(Config{
+			URLs: []*url.URL{u1, u2},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					urls = append(urls, req.URL.Host)
+					if req.URL.Host == "foo1.bar" {
+						<-req.Context().Done()
+						return nil, req.Context().Err()
+					}
+					return &http.Response{Status: "OK", StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader("{}"))}, nil
+				},
+			},
+			AttemptTimeout: 10 * time.Millisecond,
+		})
+
+		req, _ := http.NewRequest("GET", "/abc", nil)
+		res, err := tp.Perform(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode != 200 {
+			t.Errorf("Unexpected response: %+v", res)
+		}
+
+		if strings.Join(urls, ",") != "foo1.bar,foo2.bar" {
+			t.Errorf("Unexpected requests: %v", urls)
+		}
+
+		if _, err := ioutil.ReadAll(res.Body); err != nil {
+			t.Errorf("Unexpected error reading body: %s", err)
+		}
+	})
+}
+
+func TestTransportPerformTooManyRequests(t *testing.T) {
+	t.Run("Don't retry 429 by default", func(t *testing.T) {
+		var i int
+
+		u, _ := url.Parse("http://foo.bar")
+		tp := New(Config{
+			URLs: []*url.URL{u},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					i++
+					return &http.Response{StatusCode: 429, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+				},
+			},
+			EnableMetrics: true,
+		})
+
+		req, _ := http.NewRequest("GET", "/abc", nil)
+		res, err := tp.Perform(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if res.StatusCode != 429 {
+			t.Errorf("Unexpected response: %+v", res)
+		}
+
+		if i != 1 {
+			t.Errorf("Unexpected number of requests, want=%d, got=%d", 1, i)
+		}
+
+		m, _ := tp.Metrics()
+		if m.Throttled != 1 {
+			t.Errorf("Unexpected number of throttled requests, want=%d, got=%d", 1, m.Throttled)
+		}
+	})
+
+	t.Run("Retry 429 respecting Retry-After", func(t *testing.T) {
+		var (
+			i     int
+			times []time.Time
+		)
+
+		u, _ := url.Parse("http://foo.bar")
+		tp := New(Config{
+			URLs: []*url.URL{u},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					i++
+					times = append(times, time.Now())
+					switch i {
+					case 1:
+						return &http.Response{
+							StatusCode: 429,
+							Header:     http.Header{"Retry-After": []string{"1"}},
+							Body:       ioutil.NopCloser(strings.NewReader("")),
+						}, nil
+					case 2:
+						return &http.Response{StatusCode: 429, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+					}
+					return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+				},
+			},
+			RetryOnTooManyRequests: true,
+			RetryBackoff:           func(i int) time.Duration { return time.Millisecond },
+			EnableMetrics:          true,
+		})
+
+		req, _ := http.NewRequest("GET", "/abc", nil)
+		res, err := tp.Perform(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if res.StatusCode != 200 {
+			t.Errorf("Unexpected response: %+v", res)
+		}
+
+		if i != 3 {
+			t.Fatalf("Unexpected number of requests, want=%d, got=%d", 3, i)
+		}
+
+		if d := times[1].Sub(times[0]); d < time.Second {
+			t.Errorf("Expected the retry to be delayed by Retry-After, got: %s", d)
+		}
+
+		if d := times[2].Sub(times[1]); d > 500*time.Millisecond {
+			t.Errorf("Expected the retry to be delayed by RetryBackoff, got: %s", d)
+		}
+
+		m, _ := tp.Metrics()
+		if m.Throttled != 2 {
+			t.Errorf("Unexpected number of throttled requests, want=%d, got=%d", 2, m.Throttled)
+		}
+
+		if m.Failures != 0 {
+			t.Errorf("Unexpected number of failures: %d", m.Failures)
+		}
+	})
+
+	t.Run("Retry 429 with default backoff", func(t *testing.T) {
+		var i int
+
+		u, _ := url.Parse("http://foo.bar")
+		tp := New(Config{
+			URLs: []*url.URL{u},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					i++
+					return &http.Response{StatusCode: 429, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+				},
+			},
+			RetryOnTooManyRequests: true,
+			MaxRetries:             2,
+		})
+
+		req, _ := http.NewRequest("GET", "/abc", nil)
+
+		start := time.Now()
+		res, err := tp.Perform(req)
+		dur := time.Since(start)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if res.StatusCode != 429 {
+			t.Errorf("Unexpected response: %+v", res)
+		}
+
+		if i != 2 {
+			t.Errorf("Unexpected number of requests, want=%d, got=%d", 2, i)
+		}
+
+		if dur < defaultRetryBackoffMin/2 {
+			t.Errorf("Expected the retry to be delayed, got: %s", dur)
+		}
+	})
+}
+
+func TestDefaultRetryBackoff(t *testing.T) {
+	for attempt, max := range map[int]time.Duration{
+		1:   100 * time.Millisecond,
+		2:   200 * time.Millisecond,
+		5:   1600 * time.Millisecond,
+		10:  10 * time.Second,
+		100: 10 * time.Second,
+	} {
+		for i := 0; i < 100; i++ {
+			if d := defaultRetryBackoff(attempt); d < max/2 || d > max {
+				t.Errorf("Unexpected backoff for attempt %d: %s", attempt, d)
+			}
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for v, expected := range map[string]time.Duration{
+		"":                              0,
+		"5":                             5 * time.Second,
+		"-1":                            0,
+		"foo":                           0,
+		"Wed, 01 Jan 2020 12:00:30 GMT": 30 * time.Second,
+		"Wed, 01 Jan 2020 11:00:00 GMT": 0,
+	} {
+		if d := parseRetryAfter(v, now); d != expected {
+			t.Errorf("Unexpected duration for %q, want=%s, got=%s", v, expected, d)
+		}
+	}
+}
+
+func TestTransportClose(t *testing.T) {
+	t.Run("Perform after Close", func(t *testing.T) {
+		tp := New(Config{URLs: []*url.URL{{Scheme: "http", Host: "foo"}}, Transport: &mockTransp{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{Status: "MOCK"}, nil
+			},
+		}})
+
+		if err := tp.Close(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		req, _ := http.NewRequest("GET", "/", nil)
+		if _, err := tp.Perform(req); err != ErrClosed {
+			t.Errorf("Expected ErrClosed, got: %v", err)
+		}
+
+		if err := tp.DiscoverNodes(); err != ErrClosed {
+			t.Errorf("Expected ErrClosed, got: %v", err)
+		}
+
+		if err := tp.Close(context.Background()); err != nil {
+			t.Errorf("Unexpected error on second Close: %s", err)
+		}
+	})
+
+	t.Run("Wait for in-flight requests", func(t *testing.T) {
+		var (
+			started  = make(chan struct{})
+			release  = make(chan struct{})
+			finished = make(chan struct{})
+		)
+
+		tp := New(Config{URLs: []*url.URL{{Scheme: "http", Host: "foo"}}, Transport: &mockTransp{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				close(started)
+				<-release
+				return &http.Response{Status: "MOCK"}, nil
+			},
+		}})
+
+		go func() {
+			req, _ := http.NewRequest("GET", "/", nil)
+			if _, err := tp.Perform(req); err != nil {
+				t.Errorf("Unexpected error: %s", err)
+			}
+			close(finished)
+		}()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := tp.Close(ctx); err != context.DeadlineExceeded {
+			t.Errorf("Expected context.DeadlineExceeded, got: %v", err)
+		}
+
+		close(release)
+		<-finished
+	})
+
+	t.Run("Drain in-flight requests", func(t *testing.T) {
+		var (
+			started = make(chan struct{})
+			done    bool
+		)
+
+		tp := New(Config{URLs: []*url.URL{{Scheme: "http", Host: "foo"}}, Transport: &mockTransp{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				close(started)
+				time.Sleep(20 * time.Millisecond)
+				done = true
+				return &http.Response{Status: "MOCK"}, nil
+			},
+		}})
+
+		go func() {
+			req, _ := http.NewRequest("GET", "/", nil)
+			tp.Perform(req)
+		}()
+		<-started
+
+		if err := tp.Close(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if !done {
+			t.Errorf("Expected the in-flight request to be finished")
+		}
+	})
+
+	t.Run("Stop node discovery", func(t *testing.T) {
+		var (
+			mu sync.Mutex
+			n  int
+		)
+
+		tp := New(Config{
+			URLs: []*url.URL{{Scheme: "http", Host: "foo"}},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					mu.Lock()
+					n++
+					mu.Unlock()
+					return nil, fmt.Errorf("Mock error")
+				},
+			},
+			DiscoverNodesInterval: time.Millisecond,
+		})
+
+		time.Sleep(10 * time.Millisecond)
+		if err := tp.Close(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		mu.Lock()
+		before := n
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		after := n
+		mu.Unlock()
+
+		if before == 0 {
+			t.Errorf("Expected the discovery to be performed")
+		}
+
+		if after != before {
+			t.Errorf("Unexpected discovery after Close: before=%d, after=%d", before, after)
+		}
+	})
+
+	t.Run("Cancel resurrect timers", func(t *testing.T) {
+		tp := New(Config{URLs: []*url.URL{{Scheme: "http", Host: "foo1"}, {Scheme: "http", Host: "foo2"}}})
+
+		conn, _ := tp.pool.Next()
+		tp.pool.OnFailure(conn)
+
+		if conn.resurrectTimer == nil {
+			t.Fatalf("Expected the resurrect timer to be scheduled")
+		}
+
+		if err := tp.Close(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if conn.resurrectTimer != nil {
+			t.Errorf("Expected the resurrect timer to be cancelled")
+		}
+	})
+}
+
+func TestTransportHeaders(t *testing.T) {
+	t.Run("Default headers and header function", func(t *testing.T) {
+		var (
+			headers []http.Header
+			hosts   []string
+		)
+
+		u1, _ := url.Parse("http://foo1")
+		u2, _ := url.Parse("http://foo2")
+		tp := New(Config{
+			URLs:   []*url.URL{u1, u2},
+			APIKey: "Zm9vYmFy",
+			Header: http.Header{"x-found-cluster": []string{"abc"}, "X-Foo": []string{"default"}, "Authorization": []string{"Basic foo"}},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					headers = append(headers, req.Header)
+					if len(headers) == 1 {
+						return &http.Response{StatusCode: 502, Body: http.NoBody}, nil
+					}
+					return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+				},
+			},
+			HeaderFunc: func(req *http.Request) {
+				hosts = append(hosts, req.URL.Host)
+				req.Header.Add("X-Attempt", req.URL.Host)
+			},
+		})
+
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.Header.Set("X-Foo", "request")
+
+		if _, err := tp.Perform(req); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if len(headers) != 2 {
+			t.Fatalf("Unexpected number of requests: %d", len(headers))
+		}
+		for i, h := range headers {
+			if v := h.Get("X-Found-Cluster"); v != "abc" {
+				t.Errorf("Unexpected header X-Found-Cluster: %q", v)
+			}
+			if v := h.Get("X-Foo"); v != "request" {
+				t.Errorf("Expected the request header to take precedence, got: %q", v)
+			}
+			if v := h.Get("Authorization"); v != "APIKey Zm9vYmFy" {
+				t.Errorf("Expected the client header to take precedence, got: %q", v)
+			}
+			if v := h["X-Attempt"]; len(v) != 1 || v[0] != hosts[i] {
+				t.Errorf("Expected the header function to be called for every attempt, got: %v", v)
+			}
+		}
+
+		if v := req.Header.Get("X-Found-Cluster"); v != "" {
+			t.Errorf("Expected the original request to not be modified, got: %q", v)
+		}
+	})
+
+	t.Run("Header function overrides", func(t *testing.T) {
+		var header http.Header
+
+		u, _ := url.Parse("http://foo1")
+		tp := New(Config{
+			URLs:       []*url.URL{u},
+			Username:   "foo",
+			Password:   "bar",
+			HeaderFunc: func(req *http.Request) { req.Header.Set("Authorization", "Bearer token") },
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					header = req.Header
+					return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+				},
+			},
+		})
+
+		req, _ := http.NewRequest("GET", "/", nil)
+		if _, err := tp.Perform(req); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if v := header.Get("Authorization"); v != "Bearer token" {
+			t.Errorf("Unexpected header Authorization: %q", v)
+		}
+	})
 }
 
 func TestURLs(t *testing.T) {
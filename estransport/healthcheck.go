@@ -0,0 +1,149 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package estransport
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	defaultHealthCheckTimeout     = 5 * time.Second
+	defaultHealthCheckConcurrency = 4
+)
+
+// scheduleHealthCheck schedules the health check of dead connections after a duration.
+//
+func (c *Client) scheduleHealthCheck(d time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	c.healthCheckTimer = time.AfterFunc(d, func() {
+		c.checkDeadConnections()
+		c.scheduleHealthCheck(c.healthCheckInterval)
+	})
+}
+
+// checkDeadConnections probes the dead connections and resurrects the healthy ones.
+//
+func (c *Client) checkDeadConnections() {
+	var dead []*Connection
+
+	c.Lock()
+	pool := c.pool
+	c.Unlock()
+
+	for _, conn := range poolConnections(pool) {
+		conn.Lock()
+		if conn.IsDead {
+			dead = append(dead, conn)
+		}
+		conn.Unlock()
+	}
+
+	if len(dead) == 0 {
+		return
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, c.healthCheckConcurrency)
+	)
+
+	for _, conn := range dead {
+		if err := c.beginRequest(); err != nil {
+			break
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(conn *Connection) {
+			defer func() {
+				<-sem
+				wg.Done()
+				c.inflight.Done()
+			}()
+
+			healthy := c.probe(conn)
+
+			if c.metrics != nil {
+				c.metrics.Lock()
+				c.metrics.healthChecks++
+				if !healthy {
+					c.metrics.healthCheckFailures++
+				}
+				c.metrics.Unlock()
+			}
+
+			if debugLogger != nil {
+				debugLogger.Logf("Health check of %s; healthy=%v\n", conn.URL, healthy)
+			}
+
+			// Resurrect the connection in the current pool, unless the node discovery has removed it
+			if healthy {
+				c.Lock()
+				for _, cc := range poolConnections(c.pool) {
+					if cc == conn {
+						c.pool.OnSuccess(conn)
+						break
+					}
+				}
+				c.Unlock()
+			}
+		}(conn)
+	}
+
+	wg.Wait()
+}
+
+// poolConnections returns the connections of the pool, while holding the lock of the pool.
+//
+func poolConnections(pool ConnectionPool) []*Connection {
+	if lockable, ok := pool.(sync.Locker); ok {
+		lockable.Lock()
+		defer lockable.Unlock()
+	}
+	if pool, ok := pool.(connectionable); ok {
+		return pool.connections()
+	}
+	return nil
+}
+
+// probe sends a HEAD request to the connection and reports whether it has succeeded.
+//
+func (c *Client) probe(conn *Connection) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), c.healthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequest("HEAD", "/", nil)
+	if err != nil {
+		return false
+	}
+	req = req.WithContext(ctx)
+
+	c.setReqURL(conn.URL, req)
+	c.setReqAuth(conn.URL, req)
+	c.setReqUserAgent(req)
+	c.setReqHeaders(req)
+
+	res, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return false
+	}
+	if res.Body != nil {
+		io.Copy(ioutil.Discard, res.Body)
+		res.Body.Close()
+	}
+
+	return res.StatusCode >= 200 && res.StatusCode < 300
+}
@@ -0,0 +1,305 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// +build !integration
+
+package estransport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealthCheck(t *testing.T) {
+	newClient := func(tr http.RoundTripper, cfg Config) *Client {
+		u1, _ := url.Parse("http://foo1")
+		u2, _ := url.Parse("http://foo2")
+		cfg.URLs = []*url.URL{u1, u2}
+		cfg.Transport = tr
+		cfg.EnableMetrics = true
+		return New(cfg)
+	}
+
+	markAsDead := func(tp *Client, host string) *Connection {
+		for _, c := range tp.pool.(*statusConnectionPool).connections() {
+			if c.URL.Host == host {
+				tp.pool.OnFailure(c)
+				return c
+			}
+		}
+		t.Fatalf("Unknown host: %s", host)
+		return nil
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		tp := New(Config{})
+
+		if tp.healthCheckTimeout != defaultHealthCheckTimeout {
+			t.Errorf("Unexpected timeout: %s", tp.healthCheckTimeout)
+		}
+		if tp.healthCheckConcurrency != defaultHealthCheckConcurrency {
+			t.Errorf("Unexpected concurrency: %d", tp.healthCheckConcurrency)
+		}
+		if tp.healthCheckTimer != nil {
+			t.Errorf("Expected the health checks to be disabled")
+		}
+	})
+
+	t.Run("Resurrect after successful probe", func(t *testing.T) {
+		var healthy atomic.Value
+		healthy.Store(false)
+
+		tp := newClient(&mockTransp{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				if req.Method != "HEAD" || req.URL.Path != "/" {
+					t.Errorf("Unexpected request: %s %s", req.Method, req.URL)
+				}
+				if req.URL.Host == "foo1" && !healthy.Load().(bool) {
+					return nil, &mockNetError{error: fmt.Errorf("Mock network error")}
+				}
+				return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+			},
+		}, Config{HealthCheckInterval: time.Hour})
+		defer tp.Close(context.Background())
+
+		conn := markAsDead(tp, "foo1")
+		if conn.resurrectTimer != nil {
+			t.Errorf("Expected no resurrection to be scheduled with health checks")
+		}
+
+		tp.checkDeadConnections()
+
+		if !conn.IsDead {
+			t.Errorf("Expected the connection to be dead after a failed probe")
+		}
+		if n := len(tp.pool.(*statusConnectionPool).live); n != 1 {
+			t.Errorf("Expected 1 live connection, got: %d", n)
+		}
+
+		healthy.Store(true)
+		tp.checkDeadConnections()
+
+		if conn.IsDead {
+			t.Errorf("Expected the connection to be live after a successful probe")
+		}
+		if n := len(tp.pool.(*statusConnectionPool).live); n != 2 {
+			t.Errorf("Expected 2 live connections, got: %d", n)
+		}
+
+		m, _ := tp.Metrics()
+		if m.HealthChecks != 2 {
+			t.Errorf("Unexpected number of health checks: %d", m.HealthChecks)
+		}
+		if m.HealthCheckFailures != 1 {
+			t.Errorf("Unexpected number of health check failures: %d", m.HealthCheckFailures)
+		}
+		if !strings.Contains(m.String(), "HealthChecks:2 HealthCheckFailures:1") {
+			t.Errorf("Unexpected output: %s", m)
+		}
+	})
+
+	t.Run("Keep dead on error response", func(t *testing.T) {
+		tp := newClient(&mockTransp{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: 503, Body: http.NoBody}, nil
+			},
+		}, Config{HealthCheckInterval: time.Hour})
+		defer tp.Close(context.Background())
+
+		conn := markAsDead(tp, "foo2")
+		tp.checkDeadConnections()
+
+		if !conn.IsDead {
+			t.Errorf("Expected the connection to be dead")
+		}
+
+		m, _ := tp.Metrics()
+		if m.HealthCheckFailures != 1 {
+			t.Errorf("Unexpected number of health check failures: %d", m.HealthCheckFailures)
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		tp := newClient(&mockTransp{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				<-req.Context().Done()
+				return nil, req.Context().Err()
+			},
+		}, Config{HealthCheckInterval: time.Hour, HealthCheckTimeout: 10 * time.Millisecond})
+		defer tp.Close(context.Background())
+
+		conn := markAsDead(tp, "foo1")
+
+		start := time.Now()
+		tp.checkDeadConnections()
+
+		if d := time.Since(start); d > time.Second {
+			t.Errorf("Expected the probe to time out, took: %s", d)
+		}
+		if !conn.IsDead {
+			t.Errorf("Expected the connection to be dead")
+		}
+	})
+
+	t.Run("Concurrency", func(t *testing.T) {
+		var (
+			mu             sync.Mutex
+			active, maxAct int
+		)
+
+		tp := newClient(&mockTransp{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				mu.Lock()
+				active++
+				if active > maxAct {
+					maxAct = active
+				}
+				mu.Unlock()
+
+				time.Sleep(10 * time.Millisecond)
+
+				mu.Lock()
+				active--
+				mu.Unlock()
+				return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+			},
+		}, Config{HealthCheckInterval: time.Hour, HealthCheckConcurrency: 1})
+		defer tp.Close(context.Background())
+
+		markAsDead(tp, "foo1")
+		markAsDead(tp, "foo2")
+		tp.checkDeadConnections()
+
+		if maxAct != 1 {
+			t.Errorf("Expected at most 1 concurrent probe, got: %d", maxAct)
+		}
+		if n := len(tp.pool.(*statusConnectionPool).live); n != 2 {
+			t.Errorf("Expected 2 live connections, got: %d", n)
+		}
+	})
+
+	t.Run("Probe with concurrent failures", func(t *testing.T) {
+		tp := newClient(&mockTransp{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				if req.Method == "HEAD" {
+					return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+				}
+				return nil, &mockNetError{error: fmt.Errorf("Mock network error")}
+			},
+		}, Config{HealthCheckInterval: time.Hour, DisableRetry: true})
+		defer tp.Close(context.Background())
+
+		var (
+			wg      sync.WaitGroup
+			stop    = make(chan struct{})
+			stopped = make(chan struct{})
+		)
+
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 500; j++ {
+					req, _ := http.NewRequest("GET", "/", nil)
+					tp.Perform(req)
+				}
+			}()
+		}
+
+		go func() {
+			defer close(stopped)
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					tp.checkDeadConnections()
+				}
+			}
+		}()
+
+		wg.Wait()
+		close(stop)
+		<-stopped
+
+		if n := len(poolConnections(tp.pool)); n != 2 {
+			t.Errorf("Expected 2 connections, got: %d", n)
+		}
+	})
+
+	t.Run("Don't resurrect connection removed from the pool", func(t *testing.T) {
+		var tp *Client
+
+		tp = newClient(&mockTransp{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				// Replace the pool during the probe, as the node discovery does
+				tp.Lock()
+				pool, _ := NewConnectionPool(
+					[]*Connection{{URL: &url.URL{Scheme: "http", Host: "foo2"}}},
+					NewRoundRobinSelector(),
+				)
+				tp.pool = pool
+				tp.Unlock()
+				return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+			},
+		}, Config{HealthCheckInterval: time.Hour})
+		defer tp.Close(context.Background())
+
+		conn := markAsDead(tp, "foo1")
+		prev := tp.pool.(*statusConnectionPool)
+		tp.checkDeadConnections()
+
+		if !conn.IsDead {
+			t.Errorf("Expected the removed connection to not be resurrected")
+		}
+		if n := len(prev.live); n != 1 {
+			t.Errorf("Expected the previous pool to not be modified, got: %d live connections", n)
+		}
+		for _, c := range poolConnections(tp.pool) {
+			if c == conn {
+				t.Errorf("Expected the removed connection to not be added to the pool")
+			}
+		}
+	})
+
+	t.Run("Scheduled", func(t *testing.T) {
+		var probes int32
+
+		tp := newClient(&mockTransp{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&probes, 1)
+				return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+			},
+		}, Config{HealthCheckInterval: 10 * time.Millisecond})
+
+		tp.Lock()
+		conn := markAsDead(tp, "foo1")
+		tp.Unlock()
+
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) && atomic.LoadInt32(&probes) == 0 {
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		if err := tp.Close(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if atomic.LoadInt32(&probes) == 0 {
+			t.Fatalf("Expected the dead connection to be probed")
+		}
+		conn.Lock()
+		defer conn.Unlock()
+		if conn.IsDead {
+			t.Errorf("Expected the connection to be live")
+		}
+	})
+}
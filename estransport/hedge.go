@@ -0,0 +1,233 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package estransport
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var defaultHedgeDelay = 100 * time.Millisecond
+
+type hedgingKey struct{}
+
+// hedgedResult represents the result of a round trip to a connection.
+//
+type hedgedResult struct {
+	index int
+	res   *http.Response
+	err   error
+	conn  *Connection
+}
+
+// WithHedging returns a context which enables the hedged requests.
+//
+// When the request to a node doesn't return a response within the HedgeDelay,
+// a duplicate request is sent to another live node, and the response which
+// arrives first is returned, while the other request is cancelled.
+//
+// Only the read-only requests are hedged: the search, count and multi-get
+// requests, and the GET and HEAD requests for documents. The duplicate request
+// counts against the RateLimits, and it is sent only when the limits allow it
+// without waiting.
+//
+func WithHedging(ctx context.Context) context.Context {
+	return context.WithValue(ctx, hedgingKey{}, true)
+}
+
+// hedgeDelayFor returns the hedge delay for the request, or zero when the request shouldn't be hedged.
+//
+func (c *Client) hedgeDelayFor(req *http.Request) time.Duration {
+	if enabled, _ := req.Context().Value(hedgingKey{}).(bool); !enabled {
+		return 0
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0
+	}
+	if !isHedgeable(req) {
+		return 0
+	}
+	return c.hedgeDelay
+}
+
+// isHedgeable returns true for the idempotent requests to the read-only endpoints.
+//
+func isHedgeable(req *http.Request) bool {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	last := segments[len(segments)-1]
+
+	switch req.Method {
+	case "GET", "HEAD":
+		if len(segments) >= 3 && segments[len(segments)-2] == "_doc" {
+			return true
+		}
+		fallthrough
+	case "POST":
+		switch last {
+		case "_search", "_count", "_mget":
+			return true
+		}
+	}
+	return false
+}
+
+// hedgedRoundTrip performs the request, and sends a duplicate request to another connection
+// when no response arrives within the delay. It returns the first successful response,
+// and the connection which has returned it.
+//
+// The duplicate request is prepared for its connection from the original URL and headers.
+// The release function frees the concurrency limits of the request, once it is finished.
+//
+func (c *Client) hedgedRoundTrip(
+	req *http.Request,
+	conn *Connection,
+	delay time.Duration,
+	origURL url.URL,
+	header http.Header,
+	release func(),
+) (*http.Response, *Connection, error) {
+	var (
+		results = make(chan hedgedResult, 2)
+		cancels []context.CancelFunc
+	)
+
+	send := func(req *http.Request, conn *Connection, release func()) {
+		ctx, cancel := context.WithCancel(req.Context())
+		index := len(cancels)
+		cancels = append(cancels, func() { cancel(); release() })
+
+		go func() {
+			res, err := c.roundTrip(req.WithContext(ctx), conn)
+			results <- hedgedResult{index: index, res: res, err: err, conn: conn}
+		}()
+	}
+
+	send(req, conn, release)
+
+	timer := time.NewTimer(delay)
+	select {
+	case winner := <-results:
+		timer.Stop()
+		return c.hedgedResponse(winner, cancels[winner.index])
+	case <-timer.C:
+	}
+
+	if hedge, hedgeConn, release := c.hedgeRequest(req, conn, origURL, header); hedge != nil {
+		send(hedge, hedgeConn, release)
+
+		if c.metrics != nil {
+			c.metrics.Lock()
+			c.metrics.hedges++
+			c.metrics.Unlock()
+		}
+	}
+
+	winner := <-results
+	pending := len(cancels) - 1
+
+	// Wait for the other request when the first one has failed
+	if winner.err != nil && pending > 0 {
+		pending--
+		if r := <-results; r.err == nil {
+			c.discardHedged(winner, cancels[winner.index])
+			winner = r
+		} else {
+			c.discardHedged(r, cancels[r.index])
+		}
+	}
+
+	// Cancel the other request, and discard its response in the background
+	if pending > 0 {
+		loser := 1 - winner.index
+		cancels[loser]()
+		go func() {
+			c.discardHedged(<-results, cancels[loser])
+		}()
+	}
+
+	return c.hedgedResponse(winner, cancels[winner.index])
+}
+
+// hedgedResponse returns the result, releasing the context when the response body is closed.
+//
+func (c *Client) hedgedResponse(r hedgedResult, cancel context.CancelFunc) (*http.Response, *Connection, error) {
+	if r.res != nil && r.res.Body != nil {
+		r.res.Body = &cancelReadCloser{ReadCloser: r.res.Body, cancel: cancel}
+	} else {
+		cancel()
+	}
+	return r.res, r.conn, r.err
+}
+
+// hedgeRequest returns a copy of the request for another live connection, and the function
+// releasing its concurrency limits, or nil when no connection is available, or when the rate
+// and concurrency limits don't allow another request without waiting.
+//
+func (c *Client) hedgeRequest(
+	req *http.Request,
+	conn *Connection,
+	origURL url.URL,
+	header http.Header,
+) (*http.Request, *Connection, func()) {
+	release, ok := c.tryRateLimits(req)
+	if !ok {
+		if debugLogger != nil {
+			debugLogger.Logf("Not hedging request to %s: rate limited\n", conn.URL)
+		}
+		return nil, nil, nil
+	}
+
+	var body io.ReadCloser
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = req.GetBody(); err != nil {
+			release()
+			return nil, nil, nil
+		}
+	}
+
+	hedgeConn, err := c.nextConnection()
+	if err != nil || hedgeConn == conn {
+		if hedgeConn != nil && c.circuitBreaker != nil {
+			c.reportCircuit(c.connectionBreaker(hedgeConn), circuitIgnored)
+		}
+		if body != nil {
+			body.Close()
+		}
+		release()
+		return nil, nil, nil
+	}
+
+	hedge := new(http.Request)
+	*hedge = *req
+	if body != nil {
+		hedge.Body = body
+	}
+	c.setReqConnection(hedge, hedgeConn, origURL, header)
+
+	if debugLogger != nil {
+		debugLogger.Logf("Hedging request to %s with %s\n", conn.URL, hedgeConn.URL)
+	}
+
+	return hedge, hedgeConn, release
+}
+
+// discardHedged releases the resources of a request which has lost the race.
+//
+func (c *Client) discardHedged(r hedgedResult, cancel context.CancelFunc) {
+	cancel()
+	if r.res != nil && r.res.Body != nil {
+		io.Copy(ioutil.Discard, r.res.Body) // errcheck exclude
+		r.res.Body.Close()
+	}
+	if c.circuitBreaker != nil {
+		c.reportCircuit(c.connectionBreaker(r.conn), circuitIgnored)
+	}
+}
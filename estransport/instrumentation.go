@@ -0,0 +1,177 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package estransport
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/internal/endpoint"
+)
+
+// Instrumentation defines the interface for tracing the requests.
+//
+// Start is called once for every logical request, before the first attempt;
+// the returned context is passed to the underlying transport.
+//
+type Instrumentation interface {
+	Start(ctx context.Context, info RequestInfo) (context.Context, Span)
+}
+
+// Span represents a single logical request, which can consist of multiple attempts.
+//
+type Span interface {
+	// RecordAttempt is called after every attempt to perform the request.
+	RecordAttempt(AttemptInfo)
+	// End is called when the request is finished, with the status code of the final response,
+	// or with the error.
+	End(statusCode int, err error)
+}
+
+// RequestInfo represents the information about the request.
+//
+// Endpoint and Index are set for requests performed through the esapi package,
+// for example "search" or "indices.create".
+//
+type RequestInfo struct {
+	Endpoint string
+	Index    []string
+	Method   string
+	Path     string
+}
+
+// AttemptInfo represents the information about a single attempt to perform the request.
+//
+type AttemptInfo struct {
+	Attempt    int
+	URL        *url.URL
+	StatusCode int
+	Err        error
+	Duration   time.Duration
+}
+
+// newRequestInfo returns the information about req.
+//
+func newRequestInfo(req *http.Request) RequestInfo {
+	info := RequestInfo{Method: req.Method, Path: req.URL.Path}
+	if e, ok := endpoint.FromContext(req.Context()); ok {
+		info.Endpoint = e.Name
+		info.Index = e.Index
+	}
+	return info
+}
+
+// noopInstrumentation is the default instrumentation, which doesn't record anything.
+//
+type noopInstrumentation struct{}
+
+// noopSpan is the span returned by noopInstrumentation.
+//
+type noopSpan struct{}
+
+// Start returns ctx and a span which doesn't record anything.
+//
+func (noopInstrumentation) Start(ctx context.Context, info RequestInfo) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+// RecordAttempt does nothing.
+//
+func (noopSpan) RecordAttempt(AttemptInfo) {}
+
+// End does nothing.
+//
+func (noopSpan) End(int, error) {}
+
+// RecordingInstrumentation records the spans in memory.
+//
+// It is useful for testing the instrumentation of an application.
+//
+type RecordingInstrumentation struct {
+	sync.Mutex
+	spans []*recordingSpan
+}
+
+// RecordedSpan represents a span recorded by RecordingInstrumentation.
+//
+type RecordedSpan struct {
+	RequestInfo
+	Attempts   []AttemptInfo
+	StatusCode int
+	Err        error
+	Start      time.Time
+	End        time.Time
+}
+
+// recordingSpan records the span data.
+//
+type recordingSpan struct {
+	sync.Mutex
+	data RecordedSpan
+}
+
+// NewRecordingInstrumentation creates a new in-memory instrumentation recorder.
+//
+func NewRecordingInstrumentation() *RecordingInstrumentation {
+	return &RecordingInstrumentation{}
+}
+
+// Start records a new span.
+//
+func (r *RecordingInstrumentation) Start(ctx context.Context, info RequestInfo) (context.Context, Span) {
+	s := &recordingSpan{data: RecordedSpan{RequestInfo: info, Start: time.Now().UTC()}}
+
+	r.Lock()
+	r.spans = append(r.spans, s)
+	r.Unlock()
+
+	return ctx, s
+}
+
+// Spans returns a copy of the recorded spans.
+//
+func (r *RecordingInstrumentation) Spans() []RecordedSpan {
+	r.Lock()
+	defer r.Unlock()
+
+	spans := make([]RecordedSpan, 0, len(r.spans))
+	for _, s := range r.spans {
+		s.Lock()
+		span := s.data
+		span.Attempts = append([]AttemptInfo(nil), s.data.Attempts...)
+		spans = append(spans, span)
+		s.Unlock()
+	}
+	return spans
+}
+
+// Reset removes all recorded spans.
+//
+func (r *RecordingInstrumentation) Reset() {
+	r.Lock()
+	r.spans = nil
+	r.Unlock()
+}
+
+// RecordAttempt records the attempt.
+//
+func (s *recordingSpan) RecordAttempt(a AttemptInfo) {
+	s.Lock()
+	s.data.Attempts = append(s.data.Attempts, a)
+	s.Unlock()
+}
+
+// End records the end of the span.
+//
+func (s *recordingSpan) End(statusCode int, err error) {
+	s.Lock()
+	s.data.StatusCode = statusCode
+	s.data.Err = err
+	s.data.End = time.Now().UTC()
+	s.Unlock()
+}
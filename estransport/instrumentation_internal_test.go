@@ -0,0 +1,122 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// +build !integration
+
+package estransport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/internal/endpoint"
+)
+
+func TestTransportInstrumentation(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		tp := New(Config{})
+		if _, ok := tp.instrumentation.(noopInstrumentation); !ok {
+			t.Errorf("Unexpected instrumentation: %T", tp.instrumentation)
+		}
+	})
+
+	t.Run("Records spans and attempts", func(t *testing.T) {
+		var i int
+
+		u1, _ := url.Parse("http://foo1.bar")
+		u2, _ := url.Parse("http://foo2.bar")
+		rec := NewRecordingInstrumentation()
+		tp := New(Config{
+			URLs: []*url.URL{u1, u2},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					i++
+					if i == 1 {
+						return nil, &mockNetError{error: fmt.Errorf("Mock network error")}
+					}
+					return &http.Response{StatusCode: 200}, nil
+				},
+			},
+			Instrumentation: rec,
+		})
+
+		ctx := endpoint.WithInfo(context.Background(), endpoint.Info{Name: "search", Index: []string{"test"}})
+		req, _ := http.NewRequest("GET", "/test/_search", nil)
+		if _, err := tp.Perform(req.WithContext(ctx)); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		spans := rec.Spans()
+		if len(spans) != 1 {
+			t.Fatalf("Unexpected number of spans: %d", len(spans))
+		}
+		span := spans[0]
+
+		if span.Endpoint != "search" || strings.Join(span.Index, ",") != "test" {
+			t.Errorf("Unexpected span attributes: %+v", span.RequestInfo)
+		}
+
+		if span.Method != "GET" || span.Path != "/test/_search" {
+			t.Errorf("Unexpected span attributes: %+v", span.RequestInfo)
+		}
+
+		if span.StatusCode != 200 || span.Err != nil {
+			t.Errorf("Unexpected span result: status=%d, err=%v", span.StatusCode, span.Err)
+		}
+
+		if span.End.Before(span.Start) {
+			t.Errorf("Unexpected span times: start=%s, end=%s", span.Start, span.End)
+		}
+
+		if len(span.Attempts) != 2 {
+			t.Fatalf("Unexpected number of attempts: %d", len(span.Attempts))
+		}
+
+		if a := span.Attempts[0]; a.Attempt != 1 || a.URL.Host != "foo1.bar" || a.Err == nil || a.StatusCode != 0 {
+			t.Errorf("Unexpected attempt: %+v", a)
+		}
+
+		if a := span.Attempts[1]; a.Attempt != 2 || a.URL.Host != "foo2.bar" || a.Err != nil || a.StatusCode != 200 {
+			t.Errorf("Unexpected attempt: %+v", a)
+		}
+
+		rec.Reset()
+		if len(rec.Spans()) != 0 {
+			t.Errorf("Expected no spans after reset")
+		}
+	})
+
+	t.Run("Records error", func(t *testing.T) {
+		rec := NewRecordingInstrumentation()
+		tp := New(Config{
+			URLs: []*url.URL{{Scheme: "http", Host: "foo.bar"}},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					return nil, fmt.Errorf("Mock error")
+				},
+			},
+			Instrumentation: rec,
+		})
+
+		req, _ := http.NewRequest("GET", "/", nil)
+		tp.Perform(req)
+
+		spans := rec.Spans()
+		if len(spans) != 1 {
+			t.Fatalf("Unexpected number of spans: %d", len(spans))
+		}
+
+		if spans[0].Endpoint != "" {
+			t.Errorf("Unexpected endpoint: %q", spans[0].Endpoint)
+		}
+
+		if spans[0].Err == nil || spans[0].Err.Error() != "Mock error" {
+			t.Errorf("Unexpected error: %v", spans[0].Err)
+		}
+	})
+}
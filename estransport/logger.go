@@ -32,6 +32,13 @@ type Logger interface {
 	ResponseBodyEnabled() bool
 }
 
+// messageLogger defines the optional interface of a Logger for messages about the transport,
+// such as the changes of the circuit breaker state. It is implemented by the bundled loggers.
+//
+type messageLogger interface {
+	Logf(format string, a ...interface{}) error
+}
+
 // DebuggingLogger defines the interface for a debugging logger.
 //
 type DebuggingLogger interface {
@@ -115,6 +122,13 @@ func (l *TextLogger) RequestBodyEnabled() bool { return l.EnableRequestBody }
 // ResponseBodyEnabled returns true when the response body should be logged.
 func (l *TextLogger) ResponseBodyEnabled() bool { return l.EnableResponseBody }
 
+// Logf prints a message about the transport, such as a change of the circuit breaker state.
+//
+func (l *TextLogger) Logf(format string, a ...interface{}) error {
+	fmt.Fprintf(l.Output, "%s %s\n", time.Now().UTC().Format(time.RFC3339), fmt.Sprintf(format, a...))
+	return nil
+}
+
 // LogRoundTrip prints the information about request and response.
 //
 func (l *ColorLogger) LogRoundTrip(req *http.Request, res *http.Response, err error, start time.Time, dur time.Duration) error {
@@ -190,6 +204,13 @@ func (l *ColorLogger) RequestBodyEnabled() bool { return l.EnableRequestBody }
 // ResponseBodyEnabled returns true when the response body should be logged.
 func (l *ColorLogger) ResponseBodyEnabled() bool { return l.EnableResponseBody }
 
+// Logf prints a message about the transport, such as a change of the circuit breaker state.
+//
+func (l *ColorLogger) Logf(format string, a ...interface{}) error {
+	fmt.Fprintf(l.Output, "\x1b[2m%s\x1b[0m %s\n", time.Now().UTC().Format(time.RFC3339), fmt.Sprintf(format, a...))
+	return nil
+}
+
 // LogRoundTrip prints the information about request and response.
 //
 func (l *CurlLogger) LogRoundTrip(req *http.Request, res *http.Response, err error, start time.Time, dur time.Duration) error {
@@ -280,6 +301,13 @@ func (l *CurlLogger) RequestBodyEnabled() bool { return l.EnableRequestBody }
 // ResponseBodyEnabled returns true when the response body should be logged.
 func (l *CurlLogger) ResponseBodyEnabled() bool { return l.EnableResponseBody }
 
+// Logf prints a message about the transport, such as a change of the circuit breaker state.
+//
+func (l *CurlLogger) Logf(format string, a ...interface{}) error {
+	fmt.Fprintf(l.Output, "# %s %s\n\n", time.Now().UTC().Format(time.RFC3339), fmt.Sprintf(format, a...))
+	return nil
+}
+
 // LogRoundTrip prints the information about request and response.
 //
 func (l *JSONLogger) LogRoundTrip(req *http.Request, res *http.Response, err error, start time.Time, dur time.Duration) error {
@@ -390,6 +418,24 @@ func (l *JSONLogger) RequestBodyEnabled() bool { return l.EnableRequestBody }
 // ResponseBodyEnabled returns true when the response body should be logged.
 func (l *JSONLogger) ResponseBodyEnabled() bool { return l.EnableResponseBody }
 
+// Logf prints a message about the transport, such as a change of the circuit breaker state.
+//
+func (l *JSONLogger) Logf(format string, a ...interface{}) error {
+	msg, err := json.Marshal(fmt.Sprintf(format, a...))
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	b.WriteString(`{"@timestamp":"`)
+	b.WriteString(time.Now().UTC().Format(time.RFC3339))
+	b.WriteString(`","message":`)
+	b.Write(msg)
+	b.WriteString("}\n")
+	b.WriteTo(l.Output)
+	return nil
+}
+
 // Log prints the arguments to output in default format.
 //
 func (l *debuggingLogger) Log(a ...interface{}) error {
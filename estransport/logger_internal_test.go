@@ -59,7 +59,7 @@ func TestTransportLogger(t *testing.T) {
 				req, _ := http.NewRequest("GET", "/abc", nil)
 				_, err := tp.Perform(req)
 				if err != nil {
-					t.Fatalf("Unexpected error: %s", err)
+					t.Errorf("Unexpected error: %s", err)
 				}
 			}()
 		}
@@ -268,6 +268,37 @@ func TestTransportLogger(t *testing.T) {
 		}
 	})
 
+	t.Run("Curl with compressed request body", func(t *testing.T) {
+		var dst strings.Builder
+
+		tp := New(Config{
+			URLs:                []*url.URL{{Scheme: "http", Host: "foo"}},
+			Transport:           newRoundTripper(),
+			Logger:              &CurlLogger{Output: &dst, EnableRequestBody: true},
+			CompressRequestBody: true,
+		})
+
+		req, _ := http.NewRequest("POST", "/abc", strings.NewReader(`{"query":"42"}`))
+
+		if _, err := tp.Perform(req); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		output := dst.String()
+
+		if !strings.Contains(output, `"query": "42"`) {
+			t.Errorf("Expected the output to contain uncompressed body, got: %s", output)
+		}
+
+		if strings.Contains(output, "Content-Encoding") {
+			t.Errorf("Unexpected Content-Encoding header in output: %s", output)
+		}
+
+		if req.Header.Get("Content-Encoding") != "gzip" {
+			t.Errorf("Expected the request Content-Encoding header to be kept, got: %q", req.Header.Get("Content-Encoding"))
+		}
+	})
+
 	t.Run("JSON", func(t *testing.T) {
 		var dst strings.Builder
 
@@ -348,6 +379,25 @@ func TestTransportLogger(t *testing.T) {
 		}
 	})
 
+	t.Run("JSON message", func(t *testing.T) {
+		var dst strings.Builder
+
+		l := &JSONLogger{Output: &dst}
+		l.Logf("Circuit breaker for %s changed from %s to %s", "http://foo\x00\U0001F600", CircuitClosed, CircuitOpen)
+
+		var j map[string]interface{}
+		if err := json.Unmarshal([]byte(dst.String()), &j); err != nil {
+			t.Fatalf("Error decoding JSON: %s: %s", err, dst.String())
+		}
+
+		if j["message"] != "Circuit breaker for http://foo\x00\U0001F600 changed from closed to open" {
+			t.Errorf("Unexpected message: %q", j["message"])
+		}
+		if _, ok := j["@timestamp"]; !ok {
+			t.Errorf("Expected the timestamp in the output: %s", dst.String())
+		}
+	})
+
 	t.Run("Custom", func(t *testing.T) {
 		var dst strings.Builder
 
@@ -7,6 +7,8 @@ package estransport
 import (
 	"errors"
 	"fmt"
+	"io"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -30,11 +32,49 @@ type connectionable interface {
 type Metrics struct {
 	Requests  int         `json:"requests"`
 	Failures  int         `json:"failures"`
+	Throttled int         `json:"throttled"`
+	Retries   int         `json:"retries"`
 	Responses map[int]int `json:"responses"`
 
+	HealthChecks        int `json:"health_checks"`
+	HealthCheckFailures int `json:"health_check_failures"`
+
+	CircuitBreakerOpens      int                     `json:"circuit_breaker_opens"`
+	CircuitBreakerRejections int                     `json:"circuit_breaker_rejections"`
+	CircuitBreakers          map[string]CircuitState `json:"circuit_breakers,omitempty"`
+
+	Hedges int `json:"hedges"`
+
+	RateLimited   int           `json:"rate_limited"`
+	RateLimitWait time.Duration `json:"rate_limit_wait"`
+
+	BytesSent     int64 `json:"bytes_sent"`
+	BytesReceived int64 `json:"bytes_received"`
+
+	NodeLatency     map[string]Histogram `json:"node_latency,omitempty"`
+	EndpointLatency map[string]Histogram `json:"endpoint_latency,omitempty"`
+
 	Connections []fmt.Stringer `json:"connections"`
 }
 
+// Histogram represents the distribution of request durations.
+//
+// The buckets are cumulative: every bucket contains the number of requests
+// with duration lower than or equal to its upper bound.
+//
+type Histogram struct {
+	Count   int               `json:"count"`
+	Sum     time.Duration     `json:"sum"`
+	Buckets []HistogramBucket `json:"buckets"`
+}
+
+// HistogramBucket represents a single bucket of the histogram.
+//
+type HistogramBucket struct {
+	UpperBound time.Duration `json:"le"`
+	Count      int           `json:"count"`
+}
+
 // ConnectionMetric represents metric information for a connection.
 //
 type ConnectionMetric struct {
@@ -43,6 +83,9 @@ type ConnectionMetric struct {
 	IsDead    bool       `json:"dead,omitempty"`
 	DeadSince *time.Time `json:"dead_since,omitempty"`
 
+	InFlight int           `json:"in_flight,omitempty"`
+	Latency  time.Duration `json:"latency,omitempty"`
+
 	Meta struct {
 		ID    string   `json:"id"`
 		Name  string   `json:"name"`
@@ -57,11 +100,156 @@ type metrics struct {
 
 	requests  int
 	failures  int
+	throttled int
+	retries   int
 	responses map[int]int
 
+	healthChecks        int
+	healthCheckFailures int
+
+	circuitOpens      int
+	circuitRejections int
+	circuitStates     map[string]CircuitState
+
+	hedges int
+
+	rateLimited   int
+	rateLimitWait time.Duration
+
+	bytesSent     int64
+	bytesReceived int64
+
+	nodeLatency     map[string]*histogram
+	endpointLatency map[string]*histogram
+
 	connections []*Connection
 }
 
+// histogram represents the inner state of a histogram.
+//
+type histogram struct {
+	count  int
+	sum    time.Duration
+	counts []int
+}
+
+// latencyBuckets defines the upper bounds of the latency histogram buckets.
+//
+var latencyBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// newMetrics creates the inner state of metrics.
+//
+func newMetrics() *metrics {
+	return &metrics{
+		responses:       make(map[int]int),
+		circuitStates:   make(map[string]CircuitState),
+		nodeLatency:     make(map[string]*histogram),
+		endpointLatency: make(map[string]*histogram),
+	}
+}
+
+// observeLatency records the duration d in the histogram for key.
+//
+// The caller must hold the lock.
+//
+func (m *metrics) observeLatency(hh map[string]*histogram, key string, d time.Duration) {
+	h, ok := hh[key]
+	if !ok {
+		h = &histogram{counts: make([]int, len(latencyBuckets))}
+		hh[key] = h
+	}
+	h.count++
+	h.sum += d
+	for i, b := range latencyBuckets {
+		if d <= b {
+			h.counts[i]++
+		}
+	}
+}
+
+// export returns the histogram as Histogram.
+//
+func (h *histogram) export() Histogram {
+	hh := Histogram{Count: h.count, Sum: h.sum, Buckets: make([]HistogramBucket, len(latencyBuckets))}
+	for i, b := range latencyBuckets {
+		hh.Buckets[i] = HistogramBucket{UpperBound: b, Count: h.counts[i]}
+	}
+	return hh
+}
+
+// exportHistograms returns a copy of histograms.
+//
+func exportHistograms(hh map[string]*histogram) map[string]Histogram {
+	if len(hh) == 0 {
+		return nil
+	}
+	out := make(map[string]Histogram, len(hh))
+	for k, h := range hh {
+		out[k] = h.export()
+	}
+	return out
+}
+
+// sortedKeys returns the keys of histograms in sorted order.
+//
+func sortedKeys(hh map[string]Histogram) []string {
+	keys := make([]string, 0, len(hh))
+	for k := range hh {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// countingReadCloser records the number of bytes read from the response body.
+//
+type countingReadCloser struct {
+	io.ReadCloser
+	metrics *metrics
+	n       int64
+	done    bool
+}
+
+// Read reads from the underlying reader and counts the bytes.
+//
+func (r *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n += int64(n)
+	if err == io.EOF {
+		r.record()
+	}
+	return n, err
+}
+
+// Close closes the underlying reader and records the number of bytes read.
+//
+func (r *countingReadCloser) Close() error {
+	r.record()
+	return r.ReadCloser.Close()
+}
+
+func (r *countingReadCloser) record() {
+	if r.done {
+		return
+	}
+	r.done = true
+	r.metrics.Lock()
+	r.metrics.bytesReceived += r.n
+	r.metrics.Unlock()
+}
+
 // Metrics returns the transport metrics.
 //
 func (c *Client) Metrics() (Metrics, error) {
@@ -79,7 +267,33 @@ func (c *Client) Metrics() (Metrics, error) {
 	m := Metrics{
 		Requests:  c.metrics.requests,
 		Failures:  c.metrics.failures,
+		Throttled: c.metrics.throttled,
+		Retries:   c.metrics.retries,
 		Responses: c.metrics.responses,
+
+		HealthChecks:        c.metrics.healthChecks,
+		HealthCheckFailures: c.metrics.healthCheckFailures,
+
+		CircuitBreakerOpens:      c.metrics.circuitOpens,
+		CircuitBreakerRejections: c.metrics.circuitRejections,
+
+		Hedges: c.metrics.hedges,
+
+		RateLimited:   c.metrics.rateLimited,
+		RateLimitWait: c.metrics.rateLimitWait,
+
+		BytesSent:     c.metrics.bytesSent,
+		BytesReceived: c.metrics.bytesReceived,
+
+		NodeLatency:     exportHistograms(c.metrics.nodeLatency),
+		EndpointLatency: exportHistograms(c.metrics.endpointLatency),
+	}
+
+	if len(c.metrics.circuitStates) > 0 {
+		m.CircuitBreakers = make(map[string]CircuitState, len(c.metrics.circuitStates))
+		for k, v := range c.metrics.circuitStates {
+			m.CircuitBreakers[k] = v
+		}
 	}
 
 	if pool, ok := c.pool.(connectionable); ok {
@@ -90,6 +304,8 @@ func (c *Client) Metrics() (Metrics, error) {
 				URL:      c.URL.String(),
 				IsDead:   c.IsDead,
 				Failures: c.Failures,
+				InFlight: c.InFlight(),
+				Latency:  c.latency,
 			}
 
 			if !c.DeadSince.IsZero() {
@@ -131,6 +347,37 @@ func (m Metrics) String() string {
 	b.WriteString(" Failures:")
 	b.WriteString(strconv.Itoa(m.Failures))
 
+	if m.Throttled > 0 {
+		b.WriteString(" Throttled:")
+		b.WriteString(strconv.Itoa(m.Throttled))
+	}
+
+	if m.HealthChecks > 0 {
+		b.WriteString(" HealthChecks:")
+		b.WriteString(strconv.Itoa(m.HealthChecks))
+		b.WriteString(" HealthCheckFailures:")
+		b.WriteString(strconv.Itoa(m.HealthCheckFailures))
+	}
+
+	if m.CircuitBreakerOpens > 0 || m.CircuitBreakerRejections > 0 {
+		b.WriteString(" CircuitBreakerOpens:")
+		b.WriteString(strconv.Itoa(m.CircuitBreakerOpens))
+		b.WriteString(" CircuitBreakerRejections:")
+		b.WriteString(strconv.Itoa(m.CircuitBreakerRejections))
+	}
+
+	if m.Hedges > 0 {
+		b.WriteString(" Hedges:")
+		b.WriteString(strconv.Itoa(m.Hedges))
+	}
+
+	if m.RateLimited > 0 {
+		b.WriteString(" RateLimited:")
+		b.WriteString(strconv.Itoa(m.RateLimited))
+		b.WriteString(" RateLimitWait:")
+		b.WriteString(m.RateLimitWait.String())
+	}
+
 	if len(m.Responses) > 0 {
 		b.WriteString(" Responses: ")
 		b.WriteString("[")
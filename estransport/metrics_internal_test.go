@@ -7,12 +7,18 @@
 package estransport
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/internal/endpoint"
 )
 
 func TestMetrics(t *testing.T) {
@@ -58,6 +64,96 @@ func TestMetrics(t *testing.T) {
 		}
 	})
 
+	t.Run("Metrics() with retries, bytes and latency", func(t *testing.T) {
+		var i int
+
+		tp := New(
+			Config{
+				URLs: []*url.URL{
+					{Scheme: "http", Host: "foo1"},
+					{Scheme: "http", Host: "foo2"},
+				},
+				Transport: &mockTransp{
+					RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+						i++
+						if i == 1 {
+							return &http.Response{StatusCode: 502, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+						}
+						return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader("FOOBAR"))}, nil
+					},
+				},
+				EnableMetrics: true,
+			},
+		)
+
+		ctx := endpoint.WithInfo(context.Background(), endpoint.Info{Name: "search"})
+		req, _ := http.NewRequest("POST", "/_search", strings.NewReader("{}"))
+		res, err := tp.Perform(req.WithContext(ctx))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		m, _ := tp.Metrics()
+
+		if m.Retries != 1 {
+			t.Errorf("Unexpected number of retries: %d", m.Retries)
+		}
+
+		if m.BytesSent != 4 {
+			t.Errorf("Unexpected number of bytes sent: %d", m.BytesSent)
+		}
+
+		if m.BytesReceived != 6 {
+			t.Errorf("Unexpected number of bytes received: %d", m.BytesReceived)
+		}
+
+		if len(m.NodeLatency) != 2 || m.NodeLatency["http://foo1"].Count != 1 || m.NodeLatency["http://foo2"].Count != 1 {
+			t.Errorf("Unexpected node latency: %+v", m.NodeLatency)
+		}
+
+		h, ok := m.EndpointLatency["search"]
+		if !ok || h.Count != 1 {
+			t.Fatalf("Unexpected endpoint latency: %+v", m.EndpointLatency)
+		}
+
+		if len(h.Buckets) != len(latencyBuckets) || h.Buckets[len(h.Buckets)-1].Count != 1 {
+			t.Errorf("Unexpected buckets: %+v", h.Buckets)
+		}
+	})
+
+	t.Run("Metrics() with body of unknown length", func(t *testing.T) {
+		tp := New(
+			Config{
+				URLs: []*url.URL{{Scheme: "http", Host: "foo1"}},
+				Transport: &mockTransp{
+					RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+						return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+					},
+				},
+				EnableMetrics: true,
+			},
+		)
+
+		body := io.MultiReader(strings.NewReader(`{"query":`), strings.NewReader(`{"match_all":{}}}`))
+		req, _ := http.NewRequest("POST", "/_search", body)
+		if req.ContentLength != 0 {
+			t.Fatalf("Expected unknown content length, got: %d", req.ContentLength)
+		}
+		res, err := tp.Perform(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		res.Body.Close()
+
+		m, _ := tp.Metrics()
+
+		if m.BytesSent != 26 {
+			t.Errorf("Unexpected number of bytes sent: %d", m.BytesSent)
+		}
+	})
+
 	t.Run("Metrics() when not enabled", func(t *testing.T) {
 		tp := New(Config{})
 
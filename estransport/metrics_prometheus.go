@@ -0,0 +1,181 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package estransport
+
+import (
+	"bufio"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const prometheusNamespace = "elasticsearch_client"
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// NewPrometheusHandler returns a HTTP handler which exposes the metrics of m
+// in the Prometheus text exposition format.
+//
+// The metrics have to be enabled with the EnableMetrics option.
+//
+//     http.Handle("/metrics", estransport.NewPrometheusHandler(es))
+//
+func NewPrometheusHandler(m Measurable) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		metrics, err := m.Metrics()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+		bw := bufio.NewWriter(w)
+		writePrometheusMetrics(bw, metrics)
+		bw.Flush()
+	})
+}
+
+// writePrometheusMetrics writes the metrics into w in the Prometheus text exposition format.
+//
+func writePrometheusMetrics(w *bufio.Writer, m Metrics) {
+	writeCounter(w, "requests_total", "Total number of requests.", float64(m.Requests))
+	writeCounter(w, "failures_total", "Total number of failed attempts.", float64(m.Failures))
+	writeCounter(w, "retries_total", "Total number of retried attempts.", float64(m.Retries))
+	writeCounter(w, "throttled_total", "Total number of attempts rejected with 429 Too Many Requests.", float64(m.Throttled))
+	writeCounter(w, "health_checks_total", "Total number of health check probes of dead connections.", float64(m.HealthChecks))
+	writeCounter(w, "health_check_failures_total", "Total number of failed health check probes.", float64(m.HealthCheckFailures))
+	writeCounter(w, "circuit_breaker_opens_total", "Total number of circuit breaker transitions to the open state.", float64(m.CircuitBreakerOpens))
+	writeCounter(w, "circuit_breaker_rejections_total", "Total number of requests rejected by an open circuit breaker.", float64(m.CircuitBreakerRejections))
+	writeCounter(w, "hedged_requests_total", "Total number of hedged requests sent to another node.", float64(m.Hedges))
+	writeCounter(w, "rate_limited_total", "Total number of requests delayed by the rate limits.", float64(m.RateLimited))
+	writeCounter(w, "rate_limit_wait_seconds_total", "Total time spent waiting for the rate limits.", m.RateLimitWait.Seconds())
+	writeCounter(w, "sent_bytes_total", "Total number of bytes sent in request bodies.", float64(m.BytesSent))
+	writeCounter(w, "received_bytes_total", "Total number of bytes received in response bodies.", float64(m.BytesReceived))
+
+	if len(m.Responses) > 0 {
+		codes := make([]int, 0, len(m.Responses))
+		for code := range m.Responses {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+
+		writeHeader(w, "responses_total", "Total number of responses by status code.", "counter")
+		for _, code := range codes {
+			writeSample(w, "responses_total", []string{"status", strconv.Itoa(code)}, float64(m.Responses[code]))
+		}
+	}
+
+	if len(m.CircuitBreakers) > 0 {
+		names := make([]string, 0, len(m.CircuitBreakers))
+		for name := range m.CircuitBreakers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		writeHeader(w, "circuit_breaker_state", "State of the circuit breaker: 0 closed, 1 open, 2 half-open.", "gauge")
+		for _, name := range names {
+			writeSample(w, "circuit_breaker_state", []string{"breaker", name}, float64(m.CircuitBreakers[name]))
+		}
+	}
+
+	var (
+		alive, dead int
+		nodes       []ConnectionMetric
+	)
+	for _, c := range m.Connections {
+		if cm, ok := c.(ConnectionMetric); ok {
+			nodes = append(nodes, cm)
+			if cm.IsDead {
+				dead++
+			} else {
+				alive++
+			}
+		}
+	}
+
+	writeHeader(w, "connections", "Number of connections by state.", "gauge")
+	writeSample(w, "connections", []string{"state", "alive"}, float64(alive))
+	writeSample(w, "connections", []string{"state", "dead"}, float64(dead))
+
+	if len(nodes) > 0 {
+		writeHeader(w, "node_dead", "Whether the node is marked as dead.", "gauge")
+		for _, n := range nodes {
+			var v float64
+			if n.IsDead {
+				v = 1
+			}
+			writeSample(w, "node_dead", []string{"node", n.URL}, v)
+		}
+	}
+
+	writeHistograms(w, "node_request_duration_seconds", "Duration of request attempts by node.", "node", m.NodeLatency)
+	writeHistograms(w, "endpoint_request_duration_seconds", "Duration of requests by API endpoint, including retries.", "endpoint", m.EndpointLatency)
+}
+
+// writeHeader writes the HELP and TYPE lines for the metric.
+//
+func writeHeader(w *bufio.Writer, name, help, typ string) {
+	w.WriteString("# HELP ")
+	w.WriteString(prometheusNamespace + "_" + name)
+	w.WriteString(" ")
+	w.WriteString(help)
+	w.WriteString("\n# TYPE ")
+	w.WriteString(prometheusNamespace + "_" + name)
+	w.WriteString(" ")
+	w.WriteString(typ)
+	w.WriteString("\n")
+}
+
+// writeCounter writes a counter without labels.
+//
+func writeCounter(w *bufio.Writer, name, help string, v float64) {
+	writeHeader(w, name, help, "counter")
+	writeSample(w, name, nil, v)
+}
+
+// writeSample writes a single sample; labels is a list of label name and value pairs.
+//
+func writeSample(w *bufio.Writer, name string, labels []string, v float64) {
+	w.WriteString(prometheusNamespace + "_" + name)
+	if len(labels) > 0 {
+		w.WriteString("{")
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				w.WriteString(",")
+			}
+			w.WriteString(labels[i])
+			w.WriteString(`="`)
+			w.WriteString(labelValueReplacer.Replace(labels[i+1]))
+			w.WriteString(`"`)
+		}
+		w.WriteString("}")
+	}
+	w.WriteString(" ")
+	w.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+	w.WriteString("\n")
+}
+
+// writeHistograms writes the histograms, labelled with their keys.
+//
+func writeHistograms(w *bufio.Writer, name, help, label string, hh map[string]Histogram) {
+	if len(hh) == 0 {
+		return
+	}
+
+	writeHeader(w, name, help, "histogram")
+	for _, k := range sortedKeys(hh) {
+		h := hh[k]
+		for _, b := range h.Buckets {
+			le := strconv.FormatFloat(b.UpperBound.Seconds(), 'g', -1, 64)
+			writeSample(w, name+"_bucket", []string{label, k, "le", le}, float64(b.Count))
+		}
+		writeSample(w, name+"_bucket", []string{label, k, "le", "+Inf"}, float64(h.Count))
+		writeSample(w, name+"_sum", []string{label, k}, h.Sum.Seconds())
+		writeSample(w, name+"_count", []string{label, k}, float64(h.Count))
+	}
+}
+
@@ -0,0 +1,98 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// +build !integration
+
+package estransport
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/internal/endpoint"
+)
+
+func TestPrometheusHandler(t *testing.T) {
+	t.Run("Metrics", func(t *testing.T) {
+		tp := New(
+			Config{
+				URLs: []*url.URL{
+					{Scheme: "http", Host: "foo1"},
+					{Scheme: "http", Host: "foo2"},
+				},
+				Transport: &mockTransp{
+					RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+						if req.URL.Host == "foo1" {
+							return nil, &mockNetError{error: context.DeadlineExceeded}
+						}
+						return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader("OK"))}, nil
+					},
+				},
+				EnableMetrics: true,
+			},
+		)
+
+		ctx := endpoint.WithInfo(context.Background(), endpoint.Info{Name: "indices.create"})
+		req, _ := http.NewRequest("PUT", "/test", nil)
+		res, err := tp.Perform(req.WithContext(ctx))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		w := httptest.NewRecorder()
+		NewPrometheusHandler(tp).ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+		if w.Code != 200 {
+			t.Fatalf("Unexpected status: %d", w.Code)
+		}
+
+		if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+			t.Errorf("Unexpected content type: %s", ct)
+		}
+
+		output := w.Body.String()
+		for _, expected := range []string{
+			"# TYPE elasticsearch_client_requests_total counter\nelasticsearch_client_requests_total 1\n",
+			"elasticsearch_client_failures_total 1\n",
+			"elasticsearch_client_retries_total 1\n",
+			`elasticsearch_client_responses_total{status="200"} 1` + "\n",
+			`elasticsearch_client_connections{state="alive"} 1` + "\n",
+			`elasticsearch_client_connections{state="dead"} 1` + "\n",
+			`elasticsearch_client_node_dead{node="http://foo1"} 1` + "\n",
+			`elasticsearch_client_node_dead{node="http://foo2"} 0` + "\n",
+			"# TYPE elasticsearch_client_node_request_duration_seconds histogram\n",
+			`elasticsearch_client_node_request_duration_seconds_bucket{node="http://foo2",le="+Inf"} 1` + "\n",
+			`elasticsearch_client_node_request_duration_seconds_count{node="http://foo1"} 1` + "\n",
+			`elasticsearch_client_endpoint_request_duration_seconds_bucket{endpoint="indices.create",le="0.005"} 1` + "\n",
+			`elasticsearch_client_endpoint_request_duration_seconds_count{endpoint="indices.create"} 1` + "\n",
+			"elasticsearch_client_received_bytes_total 2\n",
+		} {
+			if !strings.Contains(output, expected) {
+				t.Errorf("Expected output to contain %q, got:\n%s", expected, output)
+			}
+		}
+	})
+
+	t.Run("Metrics not enabled", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		NewPrometheusHandler(New(Config{})).ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+		if w.Code != 500 {
+			t.Errorf("Unexpected status: %d", w.Code)
+		}
+	})
+
+	t.Run("Escape label values", func(t *testing.T) {
+		if v := labelValueReplacer.Replace("a\"b\\c\nd"); v != `a\"b\\c\nd` {
+			t.Errorf("Unexpected value: %s", v)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package estransport
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/internal/options"
+)
+
+// RequestOptions represents the per-request overrides of the transport configuration:
+// the maximum number of attempts, disabling the retries, the list of status codes for retry,
+// the headers set on the request, and the timeout of the request, including the retries.
+//
+// The zero values keep the configuration of the transport.
+//
+type RequestOptions = options.Request
+
+// WithRequestOptions returns a copy of ctx with the request options, which override
+// the transport configuration for the requests performed with the context.
+//
+// The options are merged with the options already stored in ctx.
+//
+//     ctx := estransport.WithRequestOptions(ctx, estransport.RequestOptions{DisableRetry: true})
+//     res, err := es.Update("test", "1", body, es.Update.WithContext(ctx))
+//
+func WithRequestOptions(ctx context.Context, o RequestOptions) context.Context {
+	return options.WithRequest(ctx, o)
+}
+
+// requestConfig represents the retry configuration for a single request.
+//
+type requestConfig struct {
+	maxRetries    int
+	disableRetry  bool
+	retryOnStatus []int
+	retryTimeout  time.Duration
+}
+
+// requestConfig returns the retry configuration for the request, overridden by the request options,
+// and sets the headers from the options on the request.
+//
+func (c *Client) requestConfig(req *http.Request) requestConfig {
+	rc := requestConfig{
+		maxRetries:    c.maxRetries,
+		disableRetry:  c.disableRetry,
+		retryOnStatus: c.retryOnStatus,
+		retryTimeout:  c.retryTimeout,
+	}
+
+	o, ok := options.FromContext(req.Context())
+	if !ok {
+		return rc
+	}
+
+	if o.MaxRetries > 0 {
+		rc.maxRetries = o.MaxRetries
+	}
+	if o.DisableRetry {
+		rc.disableRetry = true
+	}
+	if len(o.RetryOnStatus) > 0 {
+		rc.retryOnStatus = o.RetryOnStatus
+	}
+	if o.Timeout > 0 {
+		rc.retryTimeout = o.Timeout
+	}
+
+	if len(o.Header) > 0 {
+		h := make(http.Header, len(req.Header)+len(o.Header))
+		for k, vv := range req.Header {
+			h[k] = vv
+		}
+		for k, vv := range o.Header {
+			h[http.CanonicalHeaderKey(k)] = append([]string(nil), vv...)
+		}
+		req.Header = h
+	}
+
+	return rc
+}
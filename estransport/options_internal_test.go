@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// +build !integration
+
+package estransport
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTransportRequestOptions(t *testing.T) {
+	newClient := func(fn func(*http.Request) (*http.Response, error)) *Client {
+		u, _ := url.Parse("http://foo1")
+		return New(Config{URLs: []*url.URL{u}, Transport: &mockTransp{RoundTripFunc: fn}})
+	}
+
+	var tt = []struct {
+		name     string
+		opts     RequestOptions
+		status   int
+		expected int
+	}{
+		{"Default", RequestOptions{}, 502, defaultMaxRetries},
+		{"DisableRetry", RequestOptions{DisableRetry: true}, 502, 1},
+		{"MaxRetries", RequestOptions{MaxRetries: 5}, 502, 5},
+		{"RetryOnStatus", RequestOptions{RetryOnStatus: []int{500}}, 500, defaultMaxRetries},
+		{"RetryOnStatus excludes default", RequestOptions{RetryOnStatus: []int{500}}, 502, 1},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var numReqs int
+
+			tp := newClient(func(req *http.Request) (*http.Response, error) {
+				numReqs++
+				return &http.Response{StatusCode: tc.status, Body: http.NoBody}, nil
+			})
+
+			req, _ := http.NewRequest("POST", "/test/_update/1", strings.NewReader(`{}`))
+			ctx := WithRequestOptions(context.Background(), tc.opts)
+			if _, err := tp.Perform(req.WithContext(ctx)); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if numReqs != tc.expected {
+				t.Errorf("Unexpected number of requests, want=%d, got=%d", tc.expected, numReqs)
+			}
+		})
+	}
+
+	t.Run("Header", func(t *testing.T) {
+		var header http.Header
+
+		tp := newClient(func(req *http.Request) (*http.Response, error) {
+			header = req.Header
+			return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+		})
+
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.Header.Set("X-Foo", "foo")
+
+		ctx := WithRequestOptions(context.Background(), RequestOptions{Header: http.Header{"X-Foo": []string{"bar"}}})
+		ctx = WithRequestOptions(ctx, RequestOptions{Header: http.Header{"X-Bar": []string{"baz"}}})
+		if _, err := tp.Perform(req.WithContext(ctx)); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if v := strings.Join(header["X-Foo"], ","); v != "bar" {
+			t.Errorf("Unexpected header: %s", v)
+		}
+		if v := header.Get("X-Bar"); v != "baz" {
+			t.Errorf("Unexpected header: %s", v)
+		}
+		if v := strings.Join(req.Header["X-Foo"], ","); v != "foo" {
+			t.Errorf("Expected the original request to not be modified, got: %s", v)
+		}
+	})
+
+	t.Run("Header replaces the values", func(t *testing.T) {
+		var header http.Header
+
+		tp := newClient(func(req *http.Request) (*http.Response, error) {
+			header = req.Header
+			return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+		})
+
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.Header.Set("X-Opaque-Id", "abc")
+
+		ctx := WithRequestOptions(context.Background(), RequestOptions{Header: http.Header{"X-Opaque-Id": []string{"def"}}})
+		ctx = WithRequestOptions(ctx, RequestOptions{Header: http.Header{"x-opaque-id": []string{"ghi"}}})
+		if _, err := tp.Perform(req.WithContext(ctx)); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if v := strings.Join(header["X-Opaque-Id"], ","); v != "ghi" {
+			t.Errorf("Unexpected header: %s", v)
+		}
+		if _, ok := header["x-opaque-id"]; ok {
+			t.Errorf("Expected the header name to be canonicalized, got: %v", header)
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		tp := newClient(func(req *http.Request) (*http.Response, error) {
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-time.After(time.Second):
+				return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+			}
+		})
+
+		req, _ := http.NewRequest("GET", "/", nil)
+		ctx := WithRequestOptions(context.Background(), RequestOptions{Timeout: 10 * time.Millisecond})
+
+		start := time.Now()
+		_, err := tp.Perform(req.WithContext(ctx))
+		if err == nil || !strings.Contains(err.Error(), "deadline exceeded") {
+			t.Fatalf("Expected the deadline to be exceeded, got: %v", err)
+		}
+		if d := time.Since(start); d > 500*time.Millisecond {
+			t.Errorf("Expected the request to time out, took: %s", d)
+		}
+	})
+}
@@ -0,0 +1,329 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package estransport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// RateLimit represents the limits for the rate and concurrency of requests.
+//
+// The limits with a PathPattern apply only to the requests with a matching path,
+// eg. `/_bulk$`; the limits without a pattern apply to all requests. A request
+// has to satisfy all the matching limits before every attempt, including the retries.
+//
+// For the BytesPerSecond limit, a request body of unknown length, eg. encoded into JSON
+// on the fly, is buffered to measure its size.
+//
+type RateLimit struct {
+	PathPattern       *regexp.Regexp // Limit the requests with a matching path. Default: all requests.
+	RequestsPerSecond float64        // The maximum rate of requests. Default: unlimited.
+	BytesPerSecond    float64        // The maximum rate of request body bytes. Default: unlimited.
+	MaxInFlight       int            // The maximum number of concurrent requests. Default: unlimited.
+}
+
+// rateLimiter represents the inner state of a rate limit.
+//
+type rateLimiter struct {
+	pattern  *regexp.Regexp
+	requests *tokenBucket
+	bytes    *tokenBucket
+	inflight chan struct{}
+}
+
+// tokenBucket represents a token bucket which is refilled at a constant rate,
+// and holds up to one second worth of tokens.
+//
+type tokenBucket struct {
+	sync.Mutex
+
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter creates a limiter for the configuration.
+//
+func newRateLimiter(l RateLimit) *rateLimiter {
+	rl := rateLimiter{pattern: l.PathPattern}
+	if l.RequestsPerSecond > 0 {
+		rl.requests = newTokenBucket(l.RequestsPerSecond)
+	}
+	if l.BytesPerSecond > 0 {
+		rl.bytes = newTokenBucket(l.BytesPerSecond)
+	}
+	if l.MaxInFlight > 0 {
+		rl.inflight = make(chan struct{}, l.MaxInFlight)
+	}
+	return &rl
+}
+
+// newTokenBucket creates a full token bucket.
+//
+func newTokenBucket(rate float64) *tokenBucket {
+	burst := math.Max(rate, 1)
+	return &tokenBucket{rate: rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// reserve takes n tokens from the bucket, and returns the duration to wait for them.
+//
+func (b *tokenBucket) reserve(n float64, now time.Time) time.Duration {
+	b.Lock()
+	defer b.Unlock()
+
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	b.tokens -= n
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// take takes n tokens from the bucket only when they are available, and reports whether it has taken them.
+//
+func (b *tokenBucket) take(n float64, now time.Time) bool {
+	b.Lock()
+	defer b.Unlock()
+
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	if b.tokens < n {
+		return false
+	}
+	b.tokens -= n
+	return true
+}
+
+// cancel returns n tokens to the bucket.
+//
+func (b *tokenBucket) cancel(n float64) {
+	b.Lock()
+	b.tokens = math.Min(b.burst, b.tokens+n)
+	b.Unlock()
+}
+
+// wait takes n tokens from the bucket, waiting for them when necessary, and reports whether it has waited.
+//
+func (b *tokenBucket) wait(ctx context.Context, n float64) (bool, error) {
+	d := b.reserve(n, time.Now())
+	if d == 0 {
+		return false, nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true, nil
+	case <-ctx.Done():
+		b.cancel(n)
+		return true, ctx.Err()
+	}
+}
+
+// acquire waits for a free slot in the semaphore, and reports whether it has waited.
+//
+func (rl *rateLimiter) acquire(ctx context.Context) (bool, error) {
+	select {
+	case rl.inflight <- struct{}{}:
+		return false, nil
+	default:
+	}
+
+	select {
+	case rl.inflight <- struct{}{}:
+		return true, nil
+	case <-ctx.Done():
+		return true, ctx.Err()
+	}
+}
+
+// waitRateLimits waits for the limits matching the request, and returns a function which releases
+// the concurrency slots. The time spent waiting is recorded in the metrics.
+//
+// When a limit can't be satisfied because the context is done, the tokens taken
+// from the other limits are returned.
+//
+func (c *Client) waitRateLimits(ctx context.Context, req *http.Request) (func(), error) {
+	var (
+		start    = time.Now()
+		delayed  bool
+		refunds  []func()
+		acquired []*rateLimiter
+		once     sync.Once
+	)
+
+	release := func() {
+		once.Do(func() {
+			for _, rl := range acquired {
+				<-rl.inflight
+			}
+		})
+	}
+
+	wait := func(rl *rateLimiter) error {
+		if rl.requests != nil {
+			waited, err := rl.requests.wait(ctx, 1)
+			delayed = delayed || waited
+			if err != nil {
+				return err
+			}
+			refunds = append(refunds, func() { rl.requests.cancel(1) })
+		}
+		if rl.bytes != nil {
+			size, err := requestBodySize(req)
+			if err != nil {
+				return err
+			}
+			if n := float64(size); n > 0 {
+				waited, err := rl.bytes.wait(ctx, n)
+				delayed = delayed || waited
+				if err != nil {
+					return err
+				}
+				refunds = append(refunds, func() { rl.bytes.cancel(n) })
+			}
+		}
+		if rl.inflight != nil {
+			waited, err := rl.acquire(ctx)
+			delayed = delayed || waited
+			if err != nil {
+				return err
+			}
+			acquired = append(acquired, rl)
+		}
+		return nil
+	}
+
+	var err error
+	for _, rl := range c.rateLimiters {
+		if rl.pattern != nil && !rl.pattern.MatchString(req.URL.Path) {
+			continue
+		}
+		if err = wait(rl); err != nil {
+			for _, refund := range refunds {
+				refund()
+			}
+			release()
+			break
+		}
+	}
+
+	if delayed && c.metrics != nil {
+		c.metrics.Lock()
+		c.metrics.rateLimited++
+		c.metrics.rateLimitWait += time.Since(start)
+		c.metrics.Unlock()
+	}
+
+	if err != nil {
+		if ctx.Err() == nil {
+			return nil, fmt.Errorf("cannot read request body: %s", err)
+		}
+		return nil, &contextError{err: err}
+	}
+	return release, nil
+}
+
+// requestBodySize returns the size of the request body. When the size is unknown,
+// eg. for a body encoded on the fly, the body is buffered to measure it,
+// and the request is updated with the buffered body and its size.
+//
+func requestBodySize(req *http.Request) (int64, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return 0, nil
+	}
+	if req.ContentLength > 0 {
+		return req.ContentLength, nil
+	}
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(req.Body); err != nil {
+		return 0, err
+	}
+	req.Body.Close()
+
+	b := buf.Bytes()
+	req.ContentLength = int64(len(b))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(b)), nil
+	}
+	req.Body, _ = req.GetBody()
+
+	return req.ContentLength, nil
+}
+
+// tryRateLimits takes the tokens and the concurrency slots for the request, when all the matching limits
+// allow it without waiting, and returns a function which releases the concurrency slots.
+//
+// It is used for the hedged requests, which are skipped rather than delayed by the limits.
+//
+func (c *Client) tryRateLimits(req *http.Request) (func(), bool) {
+	var (
+		now      = time.Now()
+		refunds  []func()
+		acquired []*rateLimiter
+		once     sync.Once
+	)
+
+	release := func() {
+		once.Do(func() {
+			for _, rl := range acquired {
+				<-rl.inflight
+			}
+		})
+	}
+
+	for _, rl := range c.rateLimiters {
+		rl := rl
+		if rl.pattern != nil && !rl.pattern.MatchString(req.URL.Path) {
+			continue
+		}
+
+		ok := true
+		if rl.requests != nil {
+			if ok = rl.requests.take(1, now); ok {
+				refunds = append(refunds, func() { rl.requests.cancel(1) })
+			}
+		}
+		if n := float64(req.ContentLength); ok && rl.bytes != nil && n > 0 {
+			if ok = rl.bytes.take(n, now); ok {
+				refunds = append(refunds, func() { rl.bytes.cancel(n) })
+			}
+		}
+		if ok && rl.inflight != nil {
+			select {
+			case rl.inflight <- struct{}{}:
+				acquired = append(acquired, rl)
+			default:
+				ok = false
+			}
+		}
+
+		if !ok {
+			for _, refund := range refunds {
+				refund()
+			}
+			release()
+			return nil, false
+		}
+	}
+
+	return release, true
+}
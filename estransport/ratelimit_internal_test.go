@@ -0,0 +1,300 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// +build !integration
+
+package estransport
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	t.Run("Reserve", func(t *testing.T) {
+		now := time.Now()
+		b := &tokenBucket{rate: 10, burst: 10, tokens: 10, last: now}
+
+		if d := b.reserve(10, now); d != 0 {
+			t.Errorf("Expected no wait for the burst, got: %s", d)
+		}
+		if d := b.reserve(1, now); d != 100*time.Millisecond {
+			t.Errorf("Unexpected wait: %s", d)
+		}
+		if d := b.reserve(1, now.Add(100*time.Millisecond)); d != 100*time.Millisecond {
+			t.Errorf("Unexpected wait: %s", d)
+		}
+
+		b.cancel(2)
+		if d := b.reserve(1, now.Add(100*time.Millisecond)); d != 0 {
+			t.Errorf("Expected no wait after cancel, got: %s", d)
+		}
+
+		if d := b.reserve(1, now.Add(time.Hour)); d != 0 {
+			t.Errorf("Expected no wait after refill, got: %s", d)
+		}
+		if b.tokens != 9 {
+			t.Errorf("Expected the bucket to be capped at the burst, got: %v", b.tokens)
+		}
+	})
+
+	t.Run("Wait with context", func(t *testing.T) {
+		b := newTokenBucket(1)
+		b.reserve(1, time.Now())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		waited, err := b.wait(ctx, 1)
+		if !waited || err != context.DeadlineExceeded {
+			t.Errorf("Unexpected result: waited=%v, err=%v", waited, err)
+		}
+		if b.tokens < -0.1 {
+			t.Errorf("Expected the tokens to be returned, got: %v", b.tokens)
+		}
+	})
+}
+
+func TestTransportRateLimits(t *testing.T) {
+	newClient := func(limits ...RateLimit) *Client {
+		u, _ := url.Parse("http://foo1")
+		return New(Config{
+			URLs:          []*url.URL{u},
+			RateLimits:    limits,
+			EnableMetrics: true,
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+				},
+			},
+		})
+	}
+
+	t.Run("Requests per second", func(t *testing.T) {
+		tp := newClient(RateLimit{RequestsPerSecond: 20})
+
+		start := time.Now()
+		for i := 0; i < 22; i++ {
+			req, _ := http.NewRequest("GET", "/", nil)
+			if _, err := tp.Perform(req); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+
+		if d := time.Since(start); d < 75*time.Millisecond {
+			t.Errorf("Expected the requests to be delayed, took: %s", d)
+		}
+
+		m, _ := tp.Metrics()
+		if m.RateLimited < 1 || m.RateLimitWait <= 0 {
+			t.Errorf("Expected the wait to be recorded, got: %d, %s", m.RateLimited, m.RateLimitWait)
+		}
+		if !strings.Contains(m.String(), "RateLimited:") {
+			t.Errorf("Unexpected output: %s", m)
+		}
+	})
+
+	t.Run("Bytes per second", func(t *testing.T) {
+		tp := newClient(RateLimit{BytesPerSecond: 1000})
+		body := strings.Repeat("x", 600)
+
+		start := time.Now()
+		for i := 0; i < 2; i++ {
+			req, _ := http.NewRequest("POST", "/_bulk", strings.NewReader(body))
+			if _, err := tp.Perform(req); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+
+		if d := time.Since(start); d < 150*time.Millisecond {
+			t.Errorf("Expected the second request to be delayed, took: %s", d)
+		}
+	})
+
+	t.Run("Bytes per second with unknown body length", func(t *testing.T) {
+		var sizes []int
+
+		u, _ := url.Parse("http://foo1")
+		tp := New(Config{
+			URLs:       []*url.URL{u},
+			RateLimits: []RateLimit{{BytesPerSecond: 1000}},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					b, _ := ioutil.ReadAll(req.Body)
+					sizes = append(sizes, len(b))
+					return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+				},
+			},
+		})
+
+		start := time.Now()
+		for i := 0; i < 2; i++ {
+			body := ioutil.NopCloser(io.MultiReader(strings.NewReader(strings.Repeat("x", 600))))
+			req, _ := http.NewRequest("POST", "/_bulk", body)
+			if req.ContentLength > 0 {
+				t.Fatalf("Expected unknown body length, got: %d", req.ContentLength)
+			}
+			if _, err := tp.Perform(req); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+
+		if d := time.Since(start); d < 150*time.Millisecond {
+			t.Errorf("Expected the second request to be delayed, took: %s", d)
+		}
+		if len(sizes) != 2 || sizes[0] != 600 || sizes[1] != 600 {
+			t.Errorf("Unexpected body sizes: %v", sizes)
+		}
+	})
+
+	t.Run("Return the tokens when a limit can't be satisfied", func(t *testing.T) {
+		tp := newClient(RateLimit{RequestsPerSecond: 10}, RateLimit{MaxInFlight: 1})
+
+		req, _ := http.NewRequest("GET", "/", nil)
+		res, err := tp.Perform(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		defer res.Body.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		req, _ = http.NewRequest("GET", "/", nil)
+		if _, err := tp.Perform(req.WithContext(ctx)); err == nil {
+			t.Fatalf("Expected the deadline to be exceeded")
+		}
+
+		b := tp.rateLimiters[0].requests
+		b.Lock()
+		tokens := b.tokens
+		b.Unlock()
+		if tokens < 8.9 {
+			t.Errorf("Expected the request token to be returned, got: %v", tokens)
+		}
+	})
+
+	t.Run("Apply the limits to the retries", func(t *testing.T) {
+		var attempts int
+
+		u, _ := url.Parse("http://foo1")
+		tp := New(Config{
+			URLs:          []*url.URL{u},
+			MaxRetries:    3,
+			RateLimits:    []RateLimit{{RequestsPerSecond: 10}, {MaxInFlight: 1}},
+			EnableMetrics: true,
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					attempts++
+					return &http.Response{StatusCode: 502, Body: http.NoBody}, nil
+				},
+			},
+		})
+
+		req, _ := http.NewRequest("GET", "/", nil)
+		res, err := tp.Perform(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if attempts != 3 {
+			t.Errorf("Unexpected number of attempts: %d", attempts)
+		}
+
+		b := tp.rateLimiters[0].requests
+		b.Lock()
+		tokens := b.tokens
+		b.Unlock()
+		if tokens > 7.5 {
+			t.Errorf("Expected a token to be taken for every attempt, got: %v", tokens)
+		}
+
+		if n := len(tp.rateLimiters[1].inflight); n != 1 {
+			t.Errorf("Expected 1 request in flight, got: %d", n)
+		}
+		res.Body.Close()
+		if n := len(tp.rateLimiters[1].inflight); n != 0 {
+			t.Errorf("Expected no request in flight, got: %d", n)
+		}
+	})
+
+	t.Run("Delay the retries over the limit", func(t *testing.T) {
+		var attempts int
+
+		u, _ := url.Parse("http://foo1")
+		tp := New(Config{
+			URLs:          []*url.URL{u},
+			MaxRetries:    3,
+			RateLimits:    []RateLimit{{RequestsPerSecond: 1}},
+			EnableMetrics: true,
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					attempts++
+					return &http.Response{StatusCode: 502, Body: http.NoBody}, nil
+				},
+			},
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		req, _ := http.NewRequest("GET", "/", nil)
+		if _, err := tp.Perform(req.WithContext(ctx)); err == nil || !strings.Contains(err.Error(), "deadline exceeded") {
+			t.Errorf("Expected the deadline to be exceeded, got: %v", err)
+		}
+
+		if attempts != 1 {
+			t.Errorf("Expected the retry to wait for the limit, got: %d attempts", attempts)
+		}
+
+		m, _ := tp.Metrics()
+		if m.RateLimited != 1 {
+			t.Errorf("Expected the wait to be recorded, got: %d", m.RateLimited)
+		}
+	})
+
+	t.Run("Max in flight per path", func(t *testing.T) {
+		tp := newClient(RateLimit{PathPattern: regexp.MustCompile(`/_bulk$`), MaxInFlight: 1})
+
+		req, _ := http.NewRequest("POST", "/_bulk", nil)
+		res, err := tp.Perform(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		req, _ = http.NewRequest("POST", "/test/_bulk", nil)
+		if _, err := tp.Perform(req.WithContext(ctx)); err == nil || !strings.Contains(err.Error(), "deadline exceeded") {
+			t.Errorf("Expected the deadline to be exceeded, got: %v", err)
+		}
+
+		req, _ = http.NewRequest("GET", "/_search", nil)
+		if _, err := tp.Perform(req); err != nil {
+			t.Errorf("Expected the search request to not be limited, got: %s", err)
+		}
+
+		res.Body.Close()
+		res.Body.Close()
+
+		req, _ = http.NewRequest("POST", "/_bulk", nil)
+		res, err = tp.Perform(req)
+		if err != nil {
+			t.Fatalf("Expected the slot to be released, got: %s", err)
+		}
+		res.Body.Close()
+
+		if n := len(tp.rateLimiters[0].inflight); n != 0 {
+			t.Errorf("Expected no request in flight, got: %d", n)
+		}
+	})
+}
@@ -0,0 +1,248 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package estransport
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// latencyDecay defines the weight of the most recent response time in the moving average.
+//
+var latencyDecay = 0.3
+
+type randomSelector struct{}
+
+type weightedSelector struct{}
+
+type leastOutstandingSelector struct {
+	sync.Mutex
+
+	curr int // Index of the connection to start the scan from
+}
+
+type powerOfTwoSelector struct{}
+
+// NewRoundRobinSelector returns a selector which returns the connections in a round-robin fashion.
+//
+// This is the default selector.
+//
+func NewRoundRobinSelector() Selector {
+	return &roundRobinSelector{curr: -1}
+}
+
+// NewRandomSelector returns a selector which returns a random connection.
+//
+func NewRandomSelector() Selector {
+	return &randomSelector{}
+}
+
+// NewWeightedSelector returns a selector which returns a random connection,
+// with the probability proportional to the Weight of the connection.
+//
+func NewWeightedSelector() Selector {
+	return &weightedSelector{}
+}
+
+// NewLeastOutstandingSelector returns a selector which returns the connection
+// with the lowest number of outstanding requests. Ties are resolved in a round-robin fashion.
+//
+func NewLeastOutstandingSelector() Selector {
+	return &leastOutstandingSelector{curr: -1}
+}
+
+// NewPowerOfTwoSelector returns a selector which picks two random connections,
+// and returns the one with the lower load, which is the moving average of the response time
+// multiplied by the number of outstanding requests.
+//
+// The connections without any recorded response time are preferred, so they can be measured.
+//
+func NewPowerOfTwoSelector() Selector {
+	return &powerOfTwoSelector{}
+}
+
+// Select returns a random connection.
+//
+func (s *randomSelector) Select(conns []*Connection) (*Connection, error) {
+	return conns[rand.Intn(len(conns))], nil
+}
+
+// Select returns a random connection, weighted by the connection Weight.
+//
+func (s *weightedSelector) Select(conns []*Connection) (*Connection, error) {
+	var total int
+	for _, c := range conns {
+		total += c.weight()
+	}
+
+	n := rand.Intn(total)
+	for _, c := range conns {
+		n -= c.weight()
+		if n < 0 {
+			return c, nil
+		}
+	}
+	return conns[len(conns)-1], nil
+}
+
+// Select returns the connection with the lowest number of outstanding requests.
+//
+func (s *leastOutstandingSelector) Select(conns []*Connection) (*Connection, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	best := -1
+	for i := range conns {
+		idx := (s.curr + 1 + i) % len(conns)
+		if best < 0 || conns[idx].InFlight() < conns[best].InFlight() {
+			best = idx
+		}
+	}
+
+	s.curr = best
+	return conns[best], nil
+}
+
+// Select returns the less loaded connection of two random connections.
+//
+func (s *powerOfTwoSelector) Select(conns []*Connection) (*Connection, error) {
+	if len(conns) == 1 {
+		return conns[0], nil
+	}
+
+	i := rand.Intn(len(conns))
+	j := rand.Intn(len(conns) - 1)
+	if j >= i {
+		j++
+	}
+
+	if conns[j].load() < conns[i].load() {
+		return conns[j], nil
+	}
+	return conns[i], nil
+}
+
+// InFlight returns the number of outstanding requests to the connection.
+//
+func (c *Connection) InFlight() int {
+	return int(atomic.LoadInt32(&c.inflight))
+}
+
+// Latency returns the moving average of the response time of the connection,
+// or zero when no response has been recorded yet.
+//
+func (c *Connection) Latency() time.Duration {
+	c.Lock()
+	defer c.Unlock()
+	return c.latency
+}
+
+// AttributeWeight returns a function for the ConnectionWeight option, which reads the weight
+// of the connection from the node attribute, eg. configured as "node.attr.weight: 3".
+//
+// The connections without the attribute, or with an invalid value, have the weight of 1.
+//
+func AttributeWeight(name string) func(*Connection) int {
+	return func(c *Connection) int {
+		switch v := c.Attributes[name].(type) {
+		case string:
+			if w, err := strconv.Atoi(v); err == nil {
+				return w
+			}
+		case float64:
+			return int(v)
+		}
+		return 1
+	}
+}
+
+// setConnectionWeight sets the connection weight with the configured function.
+//
+func (c *Client) setConnectionWeight(conn *Connection) {
+	if c.weight != nil {
+		conn.Weight = c.weight(conn)
+	}
+}
+
+// roundTrip executes the request with the connection, which is recorded as outstanding
+// until the response body is closed.
+//
+func (c *Client) roundTrip(req *http.Request, conn *Connection) (*http.Response, error) {
+	start := time.Now()
+	conn.startRequest()
+	res, err := c.transport.RoundTrip(req)
+	conn.observeLatency(time.Since(start), err)
+
+	if res != nil && res.Body != nil {
+		res.Body = &inflightReadCloser{ReadCloser: res.Body, conn: conn}
+	} else {
+		conn.finishRequest()
+	}
+	return res, err
+}
+
+// startRequest records an outstanding request to the connection.
+//
+func (c *Connection) startRequest() {
+	atomic.AddInt32(&c.inflight, 1)
+}
+
+// finishRequest records the end of an outstanding request to the connection.
+//
+func (c *Connection) finishRequest() {
+	atomic.AddInt32(&c.inflight, -1)
+}
+
+// observeLatency records the response time of a successful request.
+//
+func (c *Connection) observeLatency(d time.Duration, err error) {
+	if err != nil {
+		return
+	}
+
+	c.Lock()
+	if c.latency == 0 {
+		c.latency = d
+	} else {
+		c.latency = time.Duration(latencyDecay*float64(d) + (1-latencyDecay)*float64(c.latency))
+	}
+	c.Unlock()
+}
+
+// load returns the moving average of the response time weighted by the number of outstanding requests.
+//
+func (c *Connection) load() float64 {
+	return float64(c.Latency()) * float64(c.InFlight()+1)
+}
+
+// weight returns the connection weight, treating the zero and negative values as 1.
+//
+func (c *Connection) weight() int {
+	if c.Weight < 1 {
+		return 1
+	}
+	return c.Weight
+}
+
+// inflightReadCloser records the end of the request to the connection when the response body is closed.
+//
+type inflightReadCloser struct {
+	io.ReadCloser
+	conn *Connection
+	once sync.Once
+}
+
+// Close closes the reader and records the end of the request.
+//
+func (r *inflightReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.conn.finishRequest)
+	return err
+}
@@ -0,0 +1,226 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// +build !integration
+
+package estransport
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func newTestConnections(hosts ...string) []*Connection {
+	var conns []*Connection
+	for _, h := range hosts {
+		conns = append(conns, &Connection{URL: &url.URL{Scheme: "http", Host: h}})
+	}
+	return conns
+}
+
+func TestSelectors(t *testing.T) {
+	t.Run("Round robin", func(t *testing.T) {
+		conns := newTestConnections("foo1", "foo2")
+		s := NewRoundRobinSelector()
+
+		for i, expected := range []string{"foo1", "foo2", "foo1"} {
+			c, _ := s.Select(conns)
+			if c.URL.Host != expected {
+				t.Errorf("%d: Unexpected connection, want=%s, got=%s", i, expected, c.URL.Host)
+			}
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		conns := newTestConnections("foo1", "foo2", "foo3")
+		s := NewRandomSelector()
+
+		seen := make(map[string]int)
+		for i := 0; i < 300; i++ {
+			c, err := s.Select(conns)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			seen[c.URL.Host]++
+		}
+
+		if len(seen) != 3 {
+			t.Errorf("Expected all connections to be selected, got: %v", seen)
+		}
+	})
+
+	t.Run("Weighted", func(t *testing.T) {
+		conns := newTestConnections("foo1", "foo2", "foo3")
+		conns[0].Weight = 9
+		conns[2].Weight = -1
+		s := NewWeightedSelector()
+
+		seen := make(map[string]int)
+		for i := 0; i < 1100; i++ {
+			c, _ := s.Select(conns)
+			seen[c.URL.Host]++
+		}
+
+		if seen["foo2"] == 0 || seen["foo3"] == 0 {
+			t.Errorf("Expected all connections to be selected, got: %v", seen)
+		}
+		if seen["foo1"] < 2*(seen["foo2"]+seen["foo3"]) {
+			t.Errorf("Expected the heavier connection to be preferred, got: %v", seen)
+		}
+	})
+
+	t.Run("Least outstanding", func(t *testing.T) {
+		conns := newTestConnections("foo1", "foo2", "foo3")
+		s := NewLeastOutstandingSelector()
+
+		for i, expected := range []string{"foo1", "foo2", "foo3", "foo1"} {
+			c, _ := s.Select(conns)
+			if c.URL.Host != expected {
+				t.Errorf("%d: Unexpected connection on ties, want=%s, got=%s", i, expected, c.URL.Host)
+			}
+		}
+
+		conns[0].startRequest()
+		conns[1].startRequest()
+		conns[1].startRequest()
+
+		for i := 0; i < 3; i++ {
+			c, _ := s.Select(conns)
+			if c.URL.Host != "foo3" {
+				t.Errorf("Unexpected connection, want=foo3, got=%s", c.URL.Host)
+			}
+		}
+
+		conns[2].startRequest()
+		conns[2].startRequest()
+
+		c, _ := s.Select(conns)
+		if c.URL.Host != "foo1" {
+			t.Errorf("Unexpected connection, want=foo1, got=%s", c.URL.Host)
+		}
+	})
+
+	t.Run("Power of two", func(t *testing.T) {
+		conns := newTestConnections("foo1", "foo2")
+		conns[0].startRequest()
+		conns[0].observeLatency(10*time.Millisecond, nil)
+		conns[0].finishRequest()
+		conns[1].startRequest()
+		conns[1].observeLatency(100*time.Millisecond, nil)
+		conns[1].finishRequest()
+		s := NewPowerOfTwoSelector()
+
+		for i := 0; i < 10; i++ {
+			c, _ := s.Select(conns)
+			if c.URL.Host != "foo1" {
+				t.Errorf("Unexpected connection, want=foo1, got=%s", c.URL.Host)
+			}
+		}
+
+		for i := 0; i < 20; i++ {
+			conns[0].startRequest()
+		}
+
+		c, _ := s.Select(conns)
+		if c.URL.Host != "foo2" {
+			t.Errorf("Expected the loaded connection to be avoided, got=%s", c.URL.Host)
+		}
+
+		c, _ = s.Select(conns[:1])
+		if c != conns[0] {
+			t.Errorf("Unexpected connection: %s", c.URL)
+		}
+	})
+
+	t.Run("Power of two prefers unmeasured connections", func(t *testing.T) {
+		conns := newTestConnections("foo1", "foo2")
+		conns[0].startRequest()
+		conns[0].observeLatency(time.Millisecond, nil)
+		conns[0].finishRequest()
+		s := NewPowerOfTwoSelector()
+
+		c, _ := s.Select(conns)
+		if c.URL.Host != "foo2" {
+			t.Errorf("Unexpected connection, want=foo2, got=%s", c.URL.Host)
+		}
+	})
+}
+
+func TestConnectionStats(t *testing.T) {
+	t.Run("Latency", func(t *testing.T) {
+		c := &Connection{URL: &url.URL{Scheme: "http", Host: "foo1"}}
+
+		if c.Latency() != 0 {
+			t.Errorf("Unexpected latency: %s", c.Latency())
+		}
+
+		c.startRequest()
+		if c.InFlight() != 1 {
+			t.Errorf("Unexpected number of outstanding requests: %d", c.InFlight())
+		}
+		c.observeLatency(100*time.Millisecond, nil)
+		c.finishRequest()
+		if c.InFlight() != 0 {
+			t.Errorf("Unexpected number of outstanding requests: %d", c.InFlight())
+		}
+		if c.Latency() != 100*time.Millisecond {
+			t.Errorf("Unexpected latency: %s", c.Latency())
+		}
+
+		c.startRequest()
+		c.observeLatency(200*time.Millisecond, nil)
+		c.finishRequest()
+		if c.Latency() != 130*time.Millisecond {
+			t.Errorf("Unexpected latency: %s", c.Latency())
+		}
+
+		c.startRequest()
+		c.observeLatency(time.Hour, errors.New("Mock error"))
+		c.finishRequest()
+		if c.Latency() != 130*time.Millisecond {
+			t.Errorf("Expected failed request to not be recorded, got: %s", c.Latency())
+		}
+	})
+
+	t.Run("Perform", func(t *testing.T) {
+		u, _ := url.Parse("http://foo1")
+		var conn *Connection
+
+		tp := New(Config{
+			URLs: []*url.URL{u},
+			Transport: &mockTransp{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					if n := conn.InFlight(); n != 1 {
+						t.Errorf("Unexpected number of outstanding requests: %d", n)
+					}
+					time.Sleep(time.Millisecond)
+					return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+				},
+			},
+		})
+		conn, _ = tp.pool.Next()
+
+		req, _ := http.NewRequest("GET", "/", nil)
+		res, err := tp.Perform(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if n := conn.InFlight(); n != 1 {
+			t.Errorf("Expected the request to be outstanding until the body is closed, got: %d", n)
+		}
+		res.Body.Close()
+		res.Body.Close()
+
+		if n := conn.InFlight(); n != 0 {
+			t.Errorf("Unexpected number of outstanding requests: %d", n)
+		}
+		if conn.Latency() == 0 {
+			t.Errorf("Expected the latency to be recorded")
+		}
+	})
+}
@@ -0,0 +1,81 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// +build integration
+
+package esutil_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esutil"
+)
+
+func TestBulkIndexerIntegration(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		var (
+			indexName = "test-bulk-integration"
+			numItems  = 100000
+			body      = `{"body":"Lorem ipsum dolor sit amet, consectetur adipiscing elit. Maecenas consequat."}`
+		)
+
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{})
+
+		es.Indices.Delete([]string{indexName}, es.Indices.Delete.WithIgnoreUnavailable(true))
+		es.Indices.Create(
+			indexName,
+			es.Indices.Create.WithBody(strings.NewReader(`{"settings": {"number_of_shards": 1, "number_of_replicas": 0, "refresh_interval":"5s"}}`)),
+			es.Indices.Create.WithWaitForActiveShards("1"))
+
+		bi, _ := esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
+			Index:  indexName,
+			Client: es,
+		})
+
+		start := time.Now().UTC()
+
+		for i := 1; i <= numItems; i++ {
+			err := bi.Add(context.Background(), esutil.BulkIndexerItem{
+				Action:     "index",
+				DocumentID: strconv.Itoa(i),
+				Body:       strings.NewReader(body),
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+
+		if err := bi.Close(context.Background()); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+
+		stats := bi.Stats()
+
+		if stats.NumAdded != uint64(numItems) {
+			t.Errorf("Unexpected NumAdded: want=%d, got=%d", numItems, stats.NumAdded)
+		}
+
+		if stats.NumIndexed != uint64(numItems) {
+			t.Errorf("Unexpected NumIndexed: want=%d, got=%d", numItems, stats.NumIndexed)
+		}
+
+		if stats.NumFailed != 0 {
+			t.Errorf("Unexpected NumFailed: want=0, got=%d", stats.NumFailed)
+		}
+
+		fmt.Printf("  Added %d documents to indexer. Succeeded: %d. Failed: %d. Requests: %d. Duration: %s (%.0f docs/sec)\n",
+			stats.NumAdded,
+			stats.NumFlushed,
+			stats.NumFailed,
+			stats.NumRequests,
+			time.Since(start).Truncate(time.Millisecond),
+			1000.0/float64(time.Since(start)/time.Millisecond)*float64(stats.NumFlushed))
+	})
+}
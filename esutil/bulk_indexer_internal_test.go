@@ -0,0 +1,513 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// +build !integration
+
+package esutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+var defaultRoundTripFunc = func(*http.Request) (*http.Response, error) {
+	return &http.Response{Body: ioutil.NopCloser(strings.NewReader(`{}`))}, nil
+}
+
+type mockTransport struct {
+	RoundTripFunc func(*http.Request) (*http.Response, error)
+}
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.RoundTripFunc == nil {
+		return defaultRoundTripFunc(req)
+	}
+	return t.RoundTripFunc(req)
+}
+
+func TestBulkIndexer(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		var (
+			wg sync.WaitGroup
+
+			countReqs int
+			testfile  string
+			numItems  = 6
+		)
+
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: &mockTransport{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				countReqs++
+				switch countReqs {
+				case 1:
+					testfile = "testdata/bulk_response_1a.json"
+				case 2:
+					testfile = "testdata/bulk_response_1b.json"
+				case 3:
+					testfile = "testdata/bulk_response_1c.json"
+				}
+				bodyContent, _ := ioutil.ReadFile(testfile)
+				return &http.Response{Body: ioutil.NopCloser(bytes.NewBuffer(bodyContent))}, nil
+			},
+		}})
+
+		cfg := BulkIndexerConfig{
+			NumWorkers:    1,
+			FlushBytes:    75,
+			FlushInterval: time.Hour, // Disable auto-flushing, because response doesn't match number of items
+			Client:        es,
+		}
+		if testing.Verbose() {
+			cfg.DebugLogger = &debugLogger{}
+		}
+
+		bi, _ := NewBulkIndexer(cfg)
+
+		for i := 1; i <= numItems; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				err := bi.Add(context.Background(), BulkIndexerItem{
+					Action:     "foo",
+					DocumentID: strconv.Itoa(i),
+					Body:       strings.NewReader(fmt.Sprintf(`{"title":"foo-%d"}`, i)),
+				})
+				if err != nil {
+					t.Errorf("Unexpected error: %s", err)
+					return
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		if err := bi.Close(context.Background()); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+
+		stats := bi.Stats()
+
+		// added = numitems
+		if stats.NumAdded != uint64(numItems) {
+			t.Errorf("Unexpected NumAdded: want=%d, got=%d", numItems, stats.NumAdded)
+		}
+
+		// flushed = numitems - 1x conflict + 1x not_found
+		if stats.NumFlushed != uint64(numItems-2) {
+			t.Errorf("Unexpected NumFlushed: want=%d, got=%d", numItems-2, stats.NumFlushed)
+		}
+
+		// failed = 1x conflict + 1x not_found
+		if stats.NumFailed != 2 {
+			t.Errorf("Unexpected NumFailed: want=%d, got=%d", 2, stats.NumFailed)
+		}
+
+		// indexed = 1x
+		if stats.NumIndexed != 1 {
+			t.Errorf("Unexpected NumIndexed: want=%d, got=%d", 1, stats.NumIndexed)
+		}
+
+		// created = 1x
+		if stats.NumCreated != 1 {
+			t.Errorf("Unexpected NumCreated: want=%d, got=%d", 1, stats.NumCreated)
+		}
+
+		// deleted = 1x
+		if stats.NumDeleted != 1 {
+			t.Errorf("Unexpected NumDeleted: want=%d, got=%d", 1, stats.NumDeleted)
+		}
+
+		// updated = 1x
+		if stats.NumUpdated != 1 {
+			t.Errorf("Unexpected NumUpdated: want=%d, got=%d", 1, stats.NumUpdated)
+		}
+
+		// 3 items * 40 bytes, 2 workers, 1 request per worker
+		if stats.NumRequests != 3 {
+			t.Errorf("Unexpected NumRequests: want=%d, got=%d", 3, stats.NumRequests)
+		}
+	})
+
+	t.Run("Add() Timeout", func(t *testing.T) {
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: &mockTransport{}})
+		bi, _ := NewBulkIndexer(BulkIndexerConfig{NumWorkers: 1, Client: es})
+		ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+		defer cancel()
+		time.Sleep(100 * time.Millisecond)
+
+		var errs []error
+		for i := 0; i < 10; i++ {
+			errs = append(errs, bi.Add(ctx, BulkIndexerItem{Action: "delete", DocumentID: "timeout"}))
+		}
+		if err := bi.Close(context.Background()); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+
+		var gotError bool
+		for _, err := range errs {
+			if err != nil && err.Error() == "context deadline exceeded" {
+				gotError = true
+			}
+		}
+		if !gotError {
+			t.Errorf("Expected timeout error, but none in: %q", errs)
+		}
+	})
+
+	t.Run("Close() Cancel", func(t *testing.T) {
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: &mockTransport{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				time.Sleep(100 * time.Millisecond)
+				return defaultRoundTripFunc(req)
+			},
+		}})
+		bi, _ := NewBulkIndexer(BulkIndexerConfig{
+			NumWorkers: 1,
+			FlushBytes: 1,
+			Client:     es,
+		})
+
+		for i := 0; i < 3; i++ {
+			bi.Add(context.Background(), BulkIndexerItem{
+				Action: "index",
+				Body:   strings.NewReader(`{"title":"foo"}`),
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := bi.Close(ctx); err == nil {
+			t.Errorf("Expected context deadline error, but got: %v", err)
+		}
+	})
+
+	t.Run("Add() Cancel", func(t *testing.T) {
+		var (
+			started = make(chan struct{}, 1)
+			release = make(chan struct{})
+		)
+
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: &mockTransport{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				select {
+				case started <- struct{}{}:
+				default:
+				}
+				<-release
+				return defaultRoundTripFunc(req)
+			},
+		}})
+		bi, _ := NewBulkIndexer(BulkIndexerConfig{
+			NumWorkers: 1,
+			FlushBytes: 1,
+			Client:     es,
+		})
+
+		// The first item blocks the worker in the transport, the second one fills the queue
+		bi.Add(context.Background(), BulkIndexerItem{Action: "index", Body: strings.NewReader(`{"title":"foo"}`)})
+		<-started
+		bi.Add(context.Background(), BulkIndexerItem{Action: "index", Body: strings.NewReader(`{"title":"bar"}`)})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := bi.Add(ctx, BulkIndexerItem{Action: "index", Body: strings.NewReader(`{"title":"baz"}`)}); err == nil {
+			t.Errorf("Expected context canceled error, but got: %v", err)
+		}
+
+		close(release)
+		if err := bi.Close(context.Background()); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+
+		if stats := bi.Stats(); stats.NumAdded != 2 {
+			t.Errorf("Unexpected NumAdded: want=%d, got=%d", 2, stats.NumAdded)
+		}
+	})
+
+	t.Run("Indexer Callback", func(t *testing.T) {
+		esCfg := elasticsearch.Config{
+			Transport: &mockTransport{
+				RoundTripFunc: func(*http.Request) (*http.Response, error) {
+					return nil, fmt.Errorf("Mock transport error")
+				},
+			},
+		}
+		if testing.Verbose() {
+			esCfg.Logger = &estransportLogger{}
+		}
+
+		es, _ := elasticsearch.NewClient(esCfg)
+
+		var indexerError error
+		biCfg := BulkIndexerConfig{
+			NumWorkers: 1,
+			Client:     es,
+			OnError:    func(ctx context.Context, err error) { indexerError = err },
+		}
+		if testing.Verbose() {
+			biCfg.DebugLogger = &debugLogger{}
+		}
+
+		bi, _ := NewBulkIndexer(biCfg)
+
+		if err := bi.Add(context.Background(), BulkIndexerItem{
+			Action: "foo",
+			Body:   strings.NewReader(`{"title":"foo"}`),
+		}); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		bi.Close(context.Background())
+
+		if indexerError == nil {
+			t.Errorf("Expected indexerError to not be nil")
+		}
+	})
+
+	t.Run("Item Callbacks", func(t *testing.T) {
+		var (
+			countSuccessful uint64
+			countFailed     uint64
+			failedIDs       []string
+			numItems        = 4
+			numFailed       = 2
+			bodyContent, _  = ioutil.ReadFile("testdata/bulk_response_2.json")
+			mu              sync.Mutex
+		)
+
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: &mockTransport{
+			RoundTripFunc: func(*http.Request) (*http.Response, error) {
+				return &http.Response{Body: ioutil.NopCloser(bytes.NewBuffer(bodyContent))}, nil
+			},
+		}})
+
+		cfg := BulkIndexerConfig{NumWorkers: 1, Client: es}
+		if testing.Verbose() {
+			cfg.DebugLogger = &debugLogger{}
+		}
+
+		bi, _ := NewBulkIndexer(cfg)
+
+		successFunc := func(ctx context.Context, item BulkIndexerItem, res BulkIndexerResponseItem) {
+			atomic.AddUint64(&countSuccessful, 1)
+		}
+		failureFunc := func(ctx context.Context, item BulkIndexerItem, res BulkIndexerResponseItem, err error) {
+			if err != nil {
+				t.Errorf("Unexpected error: %s", err)
+			}
+			atomic.AddUint64(&countFailed, 1)
+			mu.Lock()
+			failedIDs = append(failedIDs, item.DocumentID)
+			mu.Unlock()
+		}
+
+		for i := 1; i <= numItems; i++ {
+			if err := bi.Add(context.Background(), BulkIndexerItem{
+				Action:     "index",
+				DocumentID: strconv.Itoa(i),
+				Body:       strings.NewReader(fmt.Sprintf(`{"title":"foo-%d"}`, i)),
+				OnSuccess:  successFunc,
+				OnFailure:  failureFunc,
+			}); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+		bi.Close(context.Background())
+
+		stats := bi.Stats()
+
+		if stats.NumAdded != uint64(numItems) {
+			t.Errorf("Unexpected NumAdded: %d", stats.NumAdded)
+		}
+
+		if stats.NumFailed != uint64(numFailed) {
+			t.Errorf("Unexpected NumFailed: %d", stats.NumFailed)
+		}
+
+		if countSuccessful != uint64(numItems-numFailed) {
+			t.Errorf("Unexpected countSuccessful: %d", countSuccessful)
+		}
+
+		if countFailed != uint64(numFailed) {
+			t.Errorf("Unexpected countFailed: %d", countFailed)
+		}
+
+		if !strings.Contains(strings.Join(failedIDs, ","), "2") || !strings.Contains(strings.Join(failedIDs, ","), "3") {
+			t.Errorf("Unexpected failed items: %v", failedIDs)
+		}
+	})
+
+	t.Run("Item Callback on invalid item", func(t *testing.T) {
+		var failure error
+
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: &mockTransport{}})
+		bi, _ := NewBulkIndexer(BulkIndexerConfig{NumWorkers: 1, Client: es})
+
+		bi.Add(context.Background(), BulkIndexerItem{
+			DocumentID: "1",
+			Body:       strings.NewReader(`{"title":"foo"}`),
+			OnFailure: func(ctx context.Context, item BulkIndexerItem, res BulkIndexerResponseItem, err error) {
+				failure = err
+			},
+		})
+		bi.Close(context.Background())
+
+		if failure == nil || failure.Error() != "missing action" {
+			t.Errorf("Expected error for item without action, got: %v", failure)
+		}
+
+		if stats := bi.Stats(); stats.NumFailed != 1 || stats.NumRequests != 0 {
+			t.Errorf("Unexpected stats: %+v", stats)
+		}
+	})
+
+	t.Run("Flush on number of items", func(t *testing.T) {
+		var (
+			bodies    []string
+			numItems  = 5
+			flushSize = 2
+		)
+
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: &mockTransport{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				body, _ := ioutil.ReadAll(req.Body)
+				bodies = append(bodies, string(body))
+
+				var items []string
+				for i := 0; i < strings.Count(string(body), "\n")/2; i++ {
+					items = append(items, `{"index":{"status":201}}`)
+				}
+				res := `{"items":[` + strings.Join(items, ",") + `]}`
+				return &http.Response{Body: ioutil.NopCloser(strings.NewReader(res))}, nil
+			},
+		}})
+
+		bi, _ := NewBulkIndexer(BulkIndexerConfig{
+			NumWorkers: 1,
+			FlushItems: flushSize,
+			Client:     es,
+		})
+
+		for i := 1; i <= numItems; i++ {
+			bi.Add(context.Background(), BulkIndexerItem{
+				Action: "index",
+				Body:   strings.NewReader(`{"title":"foo"}`),
+			})
+		}
+		bi.Close(context.Background())
+
+		if len(bodies) != 3 {
+			t.Fatalf("Unexpected number of requests, want=3, got=%d", len(bodies))
+		}
+
+		if stats := bi.Stats(); stats.NumIndexed != uint64(numItems) {
+			t.Errorf("Unexpected NumIndexed: %d", stats.NumIndexed)
+		}
+	})
+
+	t.Run("Flush on interval", func(t *testing.T) {
+		var countReqs uint64
+
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: &mockTransport{
+			RoundTripFunc: func(*http.Request) (*http.Response, error) {
+				atomic.AddUint64(&countReqs, 1)
+				res := `{"items":[{"index":{"status":201}}]}`
+				return &http.Response{Body: ioutil.NopCloser(strings.NewReader(res))}, nil
+			},
+		}})
+
+		bi, _ := NewBulkIndexer(BulkIndexerConfig{
+			NumWorkers:    1,
+			FlushInterval: 50 * time.Millisecond,
+			Client:        es,
+		})
+
+		bi.Add(context.Background(), BulkIndexerItem{
+			Action: "index",
+			Body:   strings.NewReader(`{"title":"foo"}`),
+		})
+
+		time.Sleep(150 * time.Millisecond)
+
+		if n := atomic.LoadUint64(&countReqs); n != 1 {
+			t.Errorf("Unexpected number of requests, want=1, got=%d", n)
+		}
+
+		bi.Close(context.Background())
+	})
+
+	t.Run("Metadata", func(t *testing.T) {
+		bi := &bulkIndexer{config: BulkIndexerConfig{}}
+		w := &worker{bi: bi, buf: bytes.NewBuffer(make([]byte, 0, 5e+6))}
+
+		tt := []struct {
+			name string
+			item BulkIndexerItem
+			want string
+		}{
+			{
+				"without _index and _id",
+				BulkIndexerItem{Action: "index"},
+				`{"index":{}}` + "\n",
+			},
+			{
+				"with _id",
+				BulkIndexerItem{Action: "index", DocumentID: "42"},
+				`{"index":{"_id":"42"}}` + "\n",
+			},
+			{
+				"with _index",
+				BulkIndexerItem{Action: "index", Index: "test"},
+				`{"index":{"_index":"test"}}` + "\n",
+			},
+			{
+				"with _index and _id",
+				BulkIndexerItem{Action: "index", DocumentID: "42", Index: "test"},
+				`{"index":{"_id":"42","_index":"test"}}` + "\n",
+			},
+			{
+				"with escaped _id",
+				BulkIndexerItem{Action: "delete", DocumentID: `a"b`},
+				`{"delete":{"_id":"a\"b"}}` + "\n",
+			},
+		}
+
+		for _, tc := range tt {
+			t.Run(tc.name, func(t *testing.T) {
+				w.buf.Reset()
+				if err := w.writeMeta(tc.item); err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				if w.buf.String() != tc.want {
+					t.Errorf("Unexpected output:\nwant: %s\ngot:  %s", tc.want, w.buf.String())
+				}
+			})
+		}
+	})
+}
+
+type debugLogger struct{}
+
+func (l *debugLogger) Printf(tmpl string, args ...interface{}) {
+	fmt.Printf(tmpl, args...)
+}
+
+type estransportLogger struct{}
+
+func (l *estransportLogger) LogRoundTrip(req *http.Request, res *http.Response, err error, start time.Time, dur time.Duration) error {
+	fmt.Printf("%s %s -> error=%v\n", req.Method, req.URL, err)
+	return nil
+}
+func (l *estransportLogger) RequestBodyEnabled() bool  { return false }
+func (l *estransportLogger) ResponseBodyEnabled() bool { return false }
@@ -1,5 +1,15 @@
 /*
 Package esutil provides helper utilities to the Go client for Elasticsearch.
 
+The NewJSONReader function encodes a value into JSON and returns it as an io.Reader,
+suitable for passing as a request body.
+
+The BulkIndexer type wraps the Bulk API: it buffers the added items, flushes them
+in parallel workers when the size, number of items or time threshold is reached,
+and reports the result for each item via the OnSuccess and OnFailure callbacks.
+
+The SearchIterator type pages through large result sets, either with the Scroll API,
+or with the "search_after" parameter and an optional point in time; it releases
+the server-side resources when closed, exhausted, or when the context is cancelled.
 */
 package esutil
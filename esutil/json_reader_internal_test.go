@@ -8,19 +8,12 @@ package esutil
 
 import (
 	"bytes"
-	"errors"
 	"io"
 	"io/ioutil"
 	"strings"
 	"testing"
 )
 
-type errReader struct{}
-
-func (errReader) Read(p []byte) (int, error)         { return 1, errors.New("MOCK ERROR") }
-func (errReader) Write(p []byte) (int, error)        { return 0, errors.New("MOCK ERROR") }
-func (errReader) WriteTo(w io.Writer) (int64, error) { return 0, errors.New("MOCK ERROR") }
-
 type Foo struct {
 	Bar string
 }
@@ -50,19 +43,10 @@ func TestJSONReader(t *testing.T) {
 
 	t.Run("WriteTo", func(t *testing.T) {
 		b := bytes.NewBuffer([]byte{})
-		r := JSONReader{val: map[string]string{"foo": "bar"}}
-		r.WriteTo(b)
+		r := NewJSONReader(map[string]string{"foo": "bar"})
+		r.(io.WriterTo).WriteTo(b)
 		if b.String() != `{"foo":"bar"}`+"\n" {
 			t.Fatalf("Unexpected output: %s", b.String())
 		}
 	})
-
-	t.Run("Read error", func(t *testing.T) {
-		b := []byte{}
-		r := JSONReader{val: map[string]string{"foo": "bar"}, buf: errReader{}}
-		_, err := r.Read(b)
-		if err == nil {
-			t.Fatalf("Expected error, got: %#v", err)
-		}
-	})
 }
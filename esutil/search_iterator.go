@@ -0,0 +1,386 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package esutil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+var (
+	defaultSearchIteratorSize      = 1000
+	defaultSearchIteratorKeepAlive = time.Minute
+)
+
+// SearchIteratorMode defines the paging strategy of the SearchIterator.
+//
+type SearchIteratorMode int
+
+const (
+	// ScrollMode pages through the results with the Scroll API.
+	ScrollMode SearchIteratorMode = iota
+	// SearchAfterMode pages through the results with the "search_after" parameter,
+	// optionally within a point in time.
+	SearchAfterMode
+)
+
+// SearchIteratorConfig represents configuration of the search iterator.
+//
+type SearchIteratorConfig struct {
+	Mode      SearchIteratorMode // The paging strategy. Defaults to ScrollMode.
+	Size      int                // The number of hits per page. Defaults to 1000.
+	KeepAlive time.Duration      // The lifetime of the scroll context or point in time. Defaults to 1m.
+
+	// Sort is the sort definition for the search_after mode. Defaults to "_doc",
+	// or to "_shard_doc" with PointInTime.
+	Sort []interface{}
+	// PointInTime opens a point in time for the search_after mode,
+	// to keep the view of the index consistent across pages.
+	PointInTime bool
+}
+
+// SearchHit represents a single hit in the search results.
+//
+// The numeric sort values are decoded as json.Number, to keep the precision of long values.
+//
+type SearchHit struct {
+	Index  string          `json:"_index"`
+	ID     string          `json:"_id"`
+	Score  *float64        `json:"_score"`
+	Source json.RawMessage `json:"_source"`
+	Sort   []interface{}   `json:"sort,omitempty"`
+}
+
+// SearchIterator pages through the results of a search request.
+//
+// Call Next() to get the next page of hits, or NextHit() to get a single hit;
+// both return io.EOF when the results are exhausted.
+//
+// The iterator must be closed with Close() to release the scroll context
+// or point in time; it is released automatically when the results are exhausted,
+// or when the context passed to Next() is cancelled.
+//
+type SearchIterator struct {
+	client *elasticsearch.Client
+	index  []string
+	query  interface{}
+	config SearchIteratorConfig
+
+	scrollID    string
+	pitID       string
+	searchAfter []interface{}
+
+	total   int
+	hits    []SearchHit
+	started bool
+	done    bool
+	closed  bool
+}
+
+type searchIteratorResponse struct {
+	ScrollID string `json:"_scroll_id"`
+	PitID    string `json:"pit_id"`
+	Hits     struct {
+		Total struct {
+			Value int `json:"value"`
+		} `json:"total"`
+		Hits []SearchHit `json:"hits"`
+	} `json:"hits"`
+}
+
+// NewSearchIterator creates a new iterator over the results of query in index.
+//
+// The query is encoded into JSON as the "query" part of the request body;
+// a nil query matches all documents.
+//
+func NewSearchIterator(client *elasticsearch.Client, index []string, query interface{}, cfg SearchIteratorConfig) (*SearchIterator, error) {
+	if client == nil {
+		return nil, errors.New("cannot create search iterator: missing client")
+	}
+
+	if cfg.Size == 0 {
+		cfg.Size = defaultSearchIteratorSize
+	}
+
+	if cfg.KeepAlive == 0 {
+		cfg.KeepAlive = defaultSearchIteratorKeepAlive
+	}
+
+	if cfg.Mode == SearchAfterMode && len(cfg.Sort) == 0 {
+		if cfg.PointInTime {
+			cfg.Sort = []interface{}{"_shard_doc"}
+		} else {
+			cfg.Sort = []interface{}{"_doc"}
+		}
+	}
+
+	if query == nil {
+		query = map[string]interface{}{"match_all": map[string]interface{}{}}
+	}
+
+	return &SearchIterator{client: client, index: index, query: query, config: cfg}, nil
+}
+
+// Total returns the total number of hits reported by Elasticsearch.
+//
+// It returns 0 before the first call to Next() or NextHit().
+//
+func (it *SearchIterator) Total() int {
+	return it.total
+}
+
+// Next returns the next page of hits, or io.EOF when the results are exhausted.
+//
+func (it *SearchIterator) Next(ctx context.Context) ([]SearchHit, error) {
+	if len(it.hits) > 0 {
+		hits := it.hits
+		it.hits = nil
+		return hits, nil
+	}
+
+	return it.fetch(ctx)
+}
+
+// NextHit returns the next hit, or io.EOF when the results are exhausted.
+//
+func (it *SearchIterator) NextHit(ctx context.Context) (*SearchHit, error) {
+	if len(it.hits) == 0 {
+		hits, err := it.fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		it.hits = hits
+	}
+
+	hit := it.hits[0]
+	it.hits = it.hits[1:]
+	return &hit, nil
+}
+
+// Close releases the scroll context or the point in time.
+//
+// It is safe to call Close multiple times.
+//
+func (it *SearchIterator) Close(ctx context.Context) error {
+	if it.closed {
+		return nil
+	}
+	it.closed = true
+	it.done = true
+	it.hits = nil
+
+	if it.scrollID != "" {
+		scrollID := it.scrollID
+		it.scrollID = ""
+
+		res, err := esapi.ClearScrollRequest{
+			Body: NewJSONReader(map[string]interface{}{"scroll_id": []string{scrollID}}),
+		}.Do(ctx, it.client)
+		if err != nil {
+			return fmt.Errorf("search iterator: cannot clear scroll: %s", err)
+		}
+		return it.discardResponse(res)
+	}
+
+	if it.pitID != "" {
+		pitID := it.pitID
+		it.pitID = ""
+
+		req, _ := http.NewRequest("DELETE", "/_pit", NewJSONReader(map[string]interface{}{"id": pitID}))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := it.client.Perform(req.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("search iterator: cannot close point in time: %s", err)
+		}
+		return it.discardResponse(&esapi.Response{StatusCode: res.StatusCode, Header: res.Header, Body: res.Body})
+	}
+
+	return nil
+}
+
+// fetch requests the next page of hits from Elasticsearch.
+//
+func (it *SearchIterator) fetch(ctx context.Context) ([]SearchHit, error) {
+	if it.done {
+		return nil, io.EOF
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := ctx.Err(); err != nil {
+		it.Close(context.Background())
+		return nil, err
+	}
+
+	var (
+		res *esapi.Response
+		err error
+	)
+
+	switch it.config.Mode {
+	case ScrollMode:
+		res, err = it.fetchScroll(ctx)
+	case SearchAfterMode:
+		res, err = it.fetchSearchAfter(ctx)
+	default:
+		return nil, fmt.Errorf("search iterator: unknown mode %d", it.config.Mode)
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			it.Close(context.Background())
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("search iterator: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("search iterator: %s", res.String())
+	}
+
+	// Decode the numbers as json.Number, so the sort values are passed to search_after unchanged
+	var r searchIteratorResponse
+	dec := json.NewDecoder(res.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&r); err != nil {
+		return nil, fmt.Errorf("search iterator: error parsing response body: %s", err)
+	}
+
+	it.started = true
+	it.total = r.Hits.Total.Value
+	if r.ScrollID != "" {
+		it.scrollID = r.ScrollID
+	}
+	if r.PitID != "" {
+		it.pitID = r.PitID
+	}
+
+	hits := r.Hits.Hits
+	if len(hits) > 0 {
+		it.searchAfter = hits[len(hits)-1].Sort
+	}
+
+	// Release the resources as soon as the results are exhausted
+	if len(hits) < it.config.Size {
+		it.Close(ctx) // errcheck exclude: the resources expire after KeepAlive
+	}
+
+	if len(hits) == 0 {
+		return nil, io.EOF
+	}
+
+	return hits, nil
+}
+
+func (it *SearchIterator) fetchScroll(ctx context.Context) (*esapi.Response, error) {
+	if !it.started {
+		return esapi.SearchRequest{
+			Index:  it.index,
+			Body:   NewJSONReader(map[string]interface{}{"query": it.query, "size": it.config.Size}),
+			Scroll: it.config.KeepAlive,
+		}.Do(ctx, it.client)
+	}
+
+	return esapi.ScrollRequest{
+		Body: NewJSONReader(map[string]interface{}{
+			"scroll":    formatKeepAlive(it.config.KeepAlive),
+			"scroll_id": it.scrollID,
+		}),
+	}.Do(ctx, it.client)
+}
+
+func (it *SearchIterator) fetchSearchAfter(ctx context.Context) (*esapi.Response, error) {
+	body := map[string]interface{}{
+		"query": it.query,
+		"size":  it.config.Size,
+		"sort":  it.config.Sort,
+	}
+
+	if len(it.searchAfter) > 0 {
+		body["search_after"] = it.searchAfter
+	}
+
+	if !it.config.PointInTime {
+		return esapi.SearchRequest{Index: it.index, Body: NewJSONReader(body)}.Do(ctx, it.client)
+	}
+
+	if it.pitID == "" {
+		if err := it.openPointInTime(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	// The index is defined by the point in time, and must not be passed in the path
+	body["pit"] = map[string]interface{}{
+		"id":         it.pitID,
+		"keep_alive": formatKeepAlive(it.config.KeepAlive),
+	}
+
+	return esapi.SearchRequest{Body: NewJSONReader(body)}.Do(ctx, it.client)
+}
+
+func (it *SearchIterator) openPointInTime(ctx context.Context) error {
+	var b strings.Builder
+	b.WriteString("/")
+	b.WriteString(strings.Join(it.index, ","))
+	b.WriteString("/_pit?keep_alive=")
+	b.WriteString(formatKeepAlive(it.config.KeepAlive))
+
+	req, err := http.NewRequest("POST", b.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := it.client.Perform(req.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("cannot open point in time: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(res.Body)
+		return fmt.Errorf("cannot open point in time: %s: %s", res.Status, body)
+	}
+
+	var r struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
+		return fmt.Errorf("cannot open point in time: error parsing response body: %s", err)
+	}
+	it.pitID = r.ID
+
+	return nil
+}
+
+func (it *SearchIterator) discardResponse(res *esapi.Response) error {
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("search iterator: %s", res.String())
+	}
+	io.Copy(ioutil.Discard, res.Body)
+	return nil
+}
+
+// formatKeepAlive converts duration to a string in the format
+// accepted by Elasticsearch.
+//
+func formatKeepAlive(d time.Duration) string {
+	return strconv.FormatInt(int64(d/time.Millisecond), 10) + "ms"
+}
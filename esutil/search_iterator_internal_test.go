@@ -0,0 +1,270 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// +build !integration
+
+package esutil
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+func TestSearchIterator(t *testing.T) {
+	newResponse := func(body string) (*http.Response, error) {
+		return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(body))}, nil
+	}
+
+	t.Run("Scroll", func(t *testing.T) {
+		var requests []string
+
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: &mockTransport{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				requests = append(requests, req.Method+" "+req.URL.Path)
+				switch {
+				case req.URL.Path == "/test/_search":
+					if req.URL.Query().Get("scroll") != "60000ms" {
+						t.Errorf("Unexpected scroll parameter: %q", req.URL.Query().Get("scroll"))
+					}
+					return newResponse(`{"_scroll_id":"s1","hits":{"total":{"value":3},"hits":[{"_id":"1"},{"_id":"2"}]}}`)
+				case req.Method == "DELETE" && req.URL.Path == "/_search/scroll":
+					body, _ := ioutil.ReadAll(req.Body)
+					if !strings.Contains(string(body), `"s2"`) {
+						t.Errorf("Unexpected body: %s", body)
+					}
+					return newResponse(`{"succeeded":true,"num_freed":1}`)
+				case req.URL.Path == "/_search/scroll":
+					body, _ := ioutil.ReadAll(req.Body)
+					if !strings.Contains(string(body), `"scroll_id":"s1"`) {
+						t.Errorf("Unexpected body: %s", body)
+					}
+					return newResponse(`{"_scroll_id":"s2","hits":{"total":{"value":3},"hits":[{"_id":"3"}]}}`)
+				}
+				t.Fatalf("Unexpected request: %s %s", req.Method, req.URL)
+				return nil, nil
+			},
+		}})
+
+		it, err := NewSearchIterator(es, []string{"test"}, nil, SearchIteratorConfig{Size: 2})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		defer it.Close(context.Background())
+
+		var ids []string
+		for {
+			hits, err := it.Next(context.Background())
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			for _, hit := range hits {
+				ids = append(ids, hit.ID)
+			}
+		}
+
+		if strings.Join(ids, ",") != "1,2,3" {
+			t.Errorf("Unexpected hits: %v", ids)
+		}
+
+		if it.Total() != 3 {
+			t.Errorf("Unexpected total: %d", it.Total())
+		}
+
+		expected := "GET /test/_search,GET /_search/scroll,DELETE /_search/scroll"
+		if strings.Join(requests, ",") != expected {
+			t.Errorf("Unexpected requests:\nwant: %s\ngot:  %s", expected, strings.Join(requests, ","))
+		}
+	})
+
+	t.Run("Scroll cleared on context cancellation", func(t *testing.T) {
+		var cleared bool
+
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: &mockTransport{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				if req.Method == "DELETE" {
+					cleared = true
+					return newResponse(`{}`)
+				}
+				return newResponse(`{"_scroll_id":"s1","hits":{"hits":[{"_id":"1"},{"_id":"2"}]}}`)
+			},
+		}})
+
+		it, _ := NewSearchIterator(es, []string{"test"}, nil, SearchIteratorConfig{Size: 2})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		if _, err := it.NextHit(ctx); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, err := it.NextHit(ctx); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		cancel()
+
+		if _, err := it.NextHit(ctx); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got: %v", err)
+		}
+
+		if !cleared {
+			t.Errorf("Expected the scroll to be cleared")
+		}
+
+		if _, err := it.NextHit(context.Background()); err != io.EOF {
+			t.Errorf("Expected io.EOF after close, got: %v", err)
+		}
+	})
+
+	t.Run("Search after with point in time", func(t *testing.T) {
+		var (
+			requests []string
+			bodies   []map[string]interface{}
+		)
+
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: &mockTransport{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				requests = append(requests, req.Method+" "+req.URL.Path)
+				switch {
+				case req.URL.Path == "/test/_pit":
+					return newResponse(`{"id":"p1"}`)
+				case req.URL.Path == "/_search":
+					var body map[string]interface{}
+					json.NewDecoder(req.Body).Decode(&body)
+					bodies = append(bodies, body)
+					if len(bodies) == 1 {
+						return newResponse(`{"pit_id":"p2","hits":{"hits":[{"_id":"1","sort":[1]},{"_id":"2","sort":[2]}]}}`)
+					}
+					return newResponse(`{"pit_id":"p2","hits":{"hits":[]}}`)
+				case req.URL.Path == "/_pit":
+					body, _ := ioutil.ReadAll(req.Body)
+					if !strings.Contains(string(body), `"p2"`) {
+						t.Errorf("Unexpected body: %s", body)
+					}
+					return newResponse(`{"succeeded":true}`)
+				}
+				t.Fatalf("Unexpected request: %s %s", req.Method, req.URL)
+				return nil, nil
+			},
+		}})
+
+		it, _ := NewSearchIterator(
+			es,
+			[]string{"test"},
+			map[string]interface{}{"term": map[string]interface{}{"tag": "foo"}},
+			SearchIteratorConfig{Mode: SearchAfterMode, Size: 2, PointInTime: true},
+		)
+		defer it.Close(context.Background())
+
+		var ids []string
+		for {
+			hit, err := it.NextHit(context.Background())
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			ids = append(ids, hit.ID)
+		}
+
+		if strings.Join(ids, ",") != "1,2" {
+			t.Errorf("Unexpected hits: %v", ids)
+		}
+
+		expected := "POST /test/_pit,GET /_search,GET /_search,DELETE /_pit"
+		if strings.Join(requests, ",") != expected {
+			t.Errorf("Unexpected requests:\nwant: %s\ngot:  %s", expected, strings.Join(requests, ","))
+		}
+
+		if len(bodies) != 2 {
+			t.Fatalf("Unexpected number of search requests: %d", len(bodies))
+		}
+
+		if _, ok := bodies[0]["search_after"]; ok {
+			t.Errorf("Unexpected search_after in first request: %v", bodies[0])
+		}
+
+		if sa, ok := bodies[1]["search_after"].([]interface{}); !ok || len(sa) != 1 || sa[0] != float64(2) {
+			t.Errorf("Unexpected search_after in second request: %v", bodies[1]["search_after"])
+		}
+
+		if pit, ok := bodies[1]["pit"].(map[string]interface{}); !ok || pit["id"] != "p2" {
+			t.Errorf("Unexpected pit in second request: %v", bodies[1]["pit"])
+		}
+
+		if sort, ok := bodies[0]["sort"].([]interface{}); !ok || sort[0] != "_shard_doc" {
+			t.Errorf("Unexpected sort: %v", bodies[0]["sort"])
+		}
+	})
+
+	t.Run("Search after with long sort values", func(t *testing.T) {
+		var bodies []string
+
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: &mockTransport{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				body, _ := ioutil.ReadAll(req.Body)
+				bodies = append(bodies, string(body))
+				if len(bodies) == 1 {
+					return newResponse(`{"hits":{"hits":[{"_id":"1","sort":[9007199254740993,"a"]}]}}`)
+				}
+				return newResponse(`{"hits":{"hits":[]}}`)
+			},
+		}})
+
+		it, _ := NewSearchIterator(es, []string{"test"}, nil, SearchIteratorConfig{
+			Mode: SearchAfterMode,
+			Size: 1,
+			Sort: []interface{}{map[string]interface{}{"timestamp": "asc"}, "id"},
+		})
+		defer it.Close(context.Background())
+
+		hits, err := it.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if v, ok := hits[0].Sort[0].(json.Number); !ok || v.String() != "9007199254740993" {
+			t.Errorf("Unexpected sort value: %#v", hits[0].Sort[0])
+		}
+
+		if _, err := it.Next(context.Background()); err != io.EOF {
+			t.Fatalf("Expected io.EOF, got: %v", err)
+		}
+
+		if len(bodies) != 2 || !strings.Contains(bodies[1], `"search_after":[9007199254740993,"a"]`) {
+			t.Errorf("Unexpected search_after in second request: %v", bodies)
+		}
+	})
+
+	t.Run("Error response", func(t *testing.T) {
+		es, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: &mockTransport{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: 404,
+					Body:       ioutil.NopCloser(strings.NewReader(`{"error":{"type":"index_not_found_exception"}}`)),
+				}, nil
+			},
+		}})
+
+		it, _ := NewSearchIterator(es, []string{"missing"}, nil, SearchIteratorConfig{})
+
+		_, err := it.Next(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "index_not_found_exception") {
+			t.Errorf("Expected error, got: %v", err)
+		}
+	})
+
+	t.Run("Missing client", func(t *testing.T) {
+		if _, err := NewSearchIterator(nil, nil, nil, SearchIteratorConfig{}); err == nil {
+			t.Errorf("Expected error for missing client")
+		}
+	})
+}
@@ -0,0 +1,234 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package gensource
+
+// BodyStruct represents a typed request body.
+//
+// The JSON specification doesn't describe the request bodies, so the structs
+// for the endpoints with well-known bodies are defined in bodyStructs.
+//
+type BodyStruct struct {
+	Name        string
+	Description string
+	Fields      []BodyField
+}
+
+// BodyField represents a field of a typed request body.
+//
+type BodyField struct {
+	Name string
+	Type string
+	JSON string
+}
+
+// bodyStructs contains the typed request bodies for the endpoints;
+// the first struct is passed to the WithBodyStruct method.
+//
+// The types shared by multiple endpoints, such as Script or IndexSettings,
+// are defined in the esapi/esapi.body.go file.
+//
+var bodyStructs = map[string][]BodyStruct{
+	"cluster.reroute": {
+		{
+			Name:        "ClusterRerouteBody",
+			Description: "represents the body of the Cluster Reroute API request.",
+			Fields: []BodyField{
+				{"Commands", "[]ClusterRerouteCommand", "commands,omitempty"},
+			},
+		},
+		{
+			Name:        "ClusterRerouteCommand",
+			Description: "represents a single reroute command; set exactly one of the fields.",
+			Fields: []BodyField{
+				{"Move", "*ClusterRerouteMove", "move,omitempty"},
+				{"Cancel", "*ClusterRerouteCancel", "cancel,omitempty"},
+				{"AllocateReplica", "*ClusterRerouteAllocate", "allocate_replica,omitempty"},
+				{"AllocateStalePrimary", "*ClusterRerouteAllocate", "allocate_stale_primary,omitempty"},
+				{"AllocateEmptyPrimary", "*ClusterRerouteAllocate", "allocate_empty_primary,omitempty"},
+			},
+		},
+		{
+			Name:        "ClusterRerouteMove",
+			Description: "moves a started shard from one node to another node.",
+			Fields: []BodyField{
+				{"Index", "string", "index"},
+				{"Shard", "int", "shard"},
+				{"FromNode", "string", "from_node"},
+				{"ToNode", "string", "to_node"},
+			},
+		},
+		{
+			Name:        "ClusterRerouteCancel",
+			Description: "cancels the allocation of a shard, or its recovery.",
+			Fields: []BodyField{
+				{"Index", "string", "index"},
+				{"Shard", "int", "shard"},
+				{"Node", "string", "node"},
+				{"AllowPrimary", "bool", "allow_primary,omitempty"},
+			},
+		},
+		{
+			Name:        "ClusterRerouteAllocate",
+			Description: "allocates an unassigned shard to a node.",
+			Fields: []BodyField{
+				{"Index", "string", "index"},
+				{"Shard", "int", "shard"},
+				{"Node", "string", "node"},
+				{"AcceptDataLoss", "bool", "accept_data_loss,omitempty"},
+			},
+		},
+	},
+
+	"indices.create": {
+		{
+			Name:        "IndicesCreateBody",
+			Description: "represents the body of the Indices Create API request.",
+			Fields: []BodyField{
+				{"Settings", "*IndexSettings", "settings,omitempty"},
+				{"Mappings", "*TypeMapping", "mappings,omitempty"},
+				{"Aliases", "map[string]IndexAlias", "aliases,omitempty"},
+			},
+		},
+	},
+
+	"indices.put_mapping": {
+		{
+			Name:        "IndicesPutMappingBody",
+			Description: "represents the body of the Indices Put Mapping API request.",
+			Fields: []BodyField{
+				{"Dynamic", "interface{}", "dynamic,omitempty"},
+				{"Properties", "map[string]MappingProperty", "properties,omitempty"},
+			},
+		},
+	},
+
+	"indices.put_settings": {
+		{
+			Name:        "IndicesPutSettingsBody",
+			Description: "represents the body of the Indices Put Settings API request.",
+			Fields: []BodyField{
+				{"Index", "IndexSettings", "index"},
+			},
+		},
+	},
+
+	"indices.update_aliases": {
+		{
+			Name:        "IndicesUpdateAliasesBody",
+			Description: "represents the body of the Indices Update Aliases API request.",
+			Fields: []BodyField{
+				{"Actions", "[]IndicesAliasAction", "actions"},
+			},
+		},
+		{
+			Name:        "IndicesAliasAction",
+			Description: "represents a single alias action; set exactly one of the fields.",
+			Fields: []BodyField{
+				{"Add", "*IndicesAliasActionParams", "add,omitempty"},
+				{"Remove", "*IndicesAliasActionParams", "remove,omitempty"},
+				{"RemoveIndex", "*IndicesAliasActionParams", "remove_index,omitempty"},
+			},
+		},
+		{
+			Name:        "IndicesAliasActionParams",
+			Description: "represents the parameters of an alias action.",
+			Fields: []BodyField{
+				{"Index", "string", "index,omitempty"},
+				{"Indices", "[]string", "indices,omitempty"},
+				{"Alias", "string", "alias,omitempty"},
+				{"Aliases", "[]string", "aliases,omitempty"},
+				{"Filter", "interface{}", "filter,omitempty"},
+				{"Routing", "string", "routing,omitempty"},
+				{"IndexRouting", "string", "index_routing,omitempty"},
+				{"SearchRouting", "string", "search_routing,omitempty"},
+				{"IsWriteIndex", "*bool", "is_write_index,omitempty"},
+				{"MustExist", "*bool", "must_exist,omitempty"},
+			},
+		},
+	},
+
+	"reindex": {
+		{
+			Name:        "ReindexBody",
+			Description: "represents the body of the Reindex API request.",
+			Fields: []BodyField{
+				{"Conflicts", "string", "conflicts,omitempty"},
+				{"MaxDocs", "int", "max_docs,omitempty"},
+				{"Source", "ReindexSource", "source"},
+				{"Dest", "ReindexDest", "dest"},
+				{"Script", "*Script", "script,omitempty"},
+			},
+		},
+		{
+			Name:        "ReindexSource",
+			Description: "represents the source of the documents to reindex.",
+			Fields: []BodyField{
+				{"Index", "[]string", "index"},
+				{"Query", "interface{}", "query,omitempty"},
+				{"Size", "int", "size,omitempty"},
+				{"Sort", "interface{}", "sort,omitempty"},
+				{"SourceFields", "[]string", "_source,omitempty"},
+				{"Remote", "*ReindexRemote", "remote,omitempty"},
+			},
+		},
+		{
+			Name:        "ReindexRemote",
+			Description: "represents a remote cluster to reindex from.",
+			Fields: []BodyField{
+				{"Host", "string", "host"},
+				{"Username", "string", "username,omitempty"},
+				{"Password", "string", "password,omitempty"},
+				{"Headers", "map[string]string", "headers,omitempty"},
+				{"SocketTimeout", "string", "socket_timeout,omitempty"},
+				{"ConnectTimeout", "string", "connect_timeout,omitempty"},
+			},
+		},
+		{
+			Name:        "ReindexDest",
+			Description: "represents the destination of the reindexed documents.",
+			Fields: []BodyField{
+				{"Index", "string", "index"},
+				{"OpType", "string", "op_type,omitempty"},
+				{"Pipeline", "string", "pipeline,omitempty"},
+				{"VersionType", "string", "version_type,omitempty"},
+				{"Routing", "string", "routing,omitempty"},
+			},
+		},
+	},
+
+	"snapshot.create": {
+		{
+			Name:        "SnapshotCreateBody",
+			Description: "represents the body of the Snapshot Create API request.",
+			Fields: []BodyField{
+				{"Indices", "[]string", "indices,omitempty"},
+				{"IgnoreUnavailable", "bool", "ignore_unavailable,omitempty"},
+				{"IncludeGlobalState", "*bool", "include_global_state,omitempty"},
+				{"Partial", "bool", "partial,omitempty"},
+				{"FeatureStates", "[]string", "feature_states,omitempty"},
+				{"Metadata", "map[string]interface{}", "metadata,omitempty"},
+			},
+		},
+	},
+
+	"snapshot.restore": {
+		{
+			Name:        "SnapshotRestoreBody",
+			Description: "represents the body of the Snapshot Restore API request.",
+			Fields: []BodyField{
+				{"Indices", "[]string", "indices,omitempty"},
+				{"IgnoreUnavailable", "bool", "ignore_unavailable,omitempty"},
+				{"IncludeGlobalState", "*bool", "include_global_state,omitempty"},
+				{"IncludeAliases", "*bool", "include_aliases,omitempty"},
+				{"Partial", "bool", "partial,omitempty"},
+				{"RenamePattern", "string", "rename_pattern,omitempty"},
+				{"RenameReplacement", "string", "rename_replacement,omitempty"},
+				{"IndexSettings", "map[string]interface{}", "index_settings,omitempty"},
+				{"IgnoreIndexSettings", "[]string", "ignore_index_settings,omitempty"},
+				{"FeatureStates", "[]string", "feature_states,omitempty"},
+			},
+		},
+	},
+}
@@ -31,6 +31,7 @@ func (g *Generator) Output() (io.Reader, error) {
 	g.genConstructor()
 	g.genMethodDefinition()
 	g.genRequestStruct()
+	g.genBodyStructs()
 	g.w("\n")
 	g.genDoMethod()
 	g.genWithOptions()
@@ -243,9 +244,21 @@ type ` + g.Endpoint.MethodWithNamespace() + `Request struct {`)
 
 	g.w("\n\n\tHeader\thttp.Header\n")
 
+	g.w("\n\topts *RequestOptions\n")
+
 	g.w("\n\tctx context.Context\n}\n")
 }
 
+func (g *Generator) genBodyStructs() {
+	for _, b := range bodyStructs[g.Endpoint.Name] {
+		g.w("\n// " + b.Name + " " + b.Description + "\n//\ntype " + b.Name + " struct {")
+		for _, f := range b.Fields {
+			g.w("\n\t" + f.Name + "\t" + f.Type + "\t`json:\"" + f.JSON + "\"`")
+		}
+		g.w("\n}\n")
+	}
+}
+
 func (g *Generator) genWithOptions() {
 	// Generate WithContext first
 	g.w(`
@@ -352,6 +365,19 @@ func (f ` + g.Endpoint.MethodWithNamespace() + `) WithBody(v io.Reader) func(*`
 		}
 	}
 
+	// Generate WithBodyStruct method
+	if bb, ok := bodyStructs[g.Endpoint.Name]; ok && len(bb) > 0 {
+		g.w(`
+// WithBodyStruct sets the request body to v encoded into JSON.
+//
+func (f ` + g.Endpoint.MethodWithNamespace() + `) WithBodyStruct(v *` + bb[0].Name + `) func(*` + g.Endpoint.MethodWithNamespace() + `Request) {
+	return func(r *` + g.Endpoint.MethodWithNamespace() + `Request) {
+		r.Body = newJSONReader(v)
+	}
+}
+`)
+	}
+
 	// Generate With... methods for parts
 	for _, pName := range g.Endpoint.URL.PartNamesSorted {
 		if p, ok := g.Endpoint.URL.AllParts[pName]; ok {
@@ -448,6 +474,17 @@ func (f ` + g.Endpoint.MethodWithNamespace() + `) WithOpaqueID(s string) func(*`
 		r.Header.Set("X-Opaque-Id", s)
 	}
 }
+`)
+
+	// Generate methods for the per-request options
+	g.w(`
+// WithRequestOptions overrides the transport configuration for the request.
+//
+func (f ` + g.Endpoint.MethodWithNamespace() + `) WithRequestOptions(o RequestOptions) func(*` + g.Endpoint.MethodWithNamespace() + `Request) {
+	return func(r *` + g.Endpoint.MethodWithNamespace() + `Request) {
+		r.opts = mergeRequestOptions(r.opts, o)
+	}
+}
 `)
 }
 
@@ -763,9 +800,19 @@ func (r ` + g.Endpoint.MethodWithNamespace() + `Request) Do(ctx context.Context,
 		}
 	}` + "\n\n")
 
-	g.w(`if ctx != nil {
-		req = req.WithContext(ctx)
-	}` + "\n\n")
+	// Pass the endpoint name and index to the transport instrumentation
+	var endpointArgs string
+	if p, ok := g.Endpoint.URL.AllParts["index"]; ok {
+		switch p.Type {
+		case "list":
+			endpointArgs = `, r.Index...`
+		case "string":
+			endpointArgs = `, r.Index`
+		default:
+			panic(fmt.Sprintf("FAIL: %q: unexpected type %q for URL part %q\n", g.Endpoint.Name, p.Type, p.Name))
+		}
+	}
+	g.w(`req = req.WithContext(withRequestOptions(withEndpoint(ctx, "` + g.Endpoint.Name + `"` + endpointArgs + `), r.opts))` + "\n\n")
 
 	g.w(`
 	res, err := transport.Perform(req)
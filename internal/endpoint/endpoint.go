@@ -0,0 +1,32 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// Package endpoint passes the information about the API endpoint
+// from the esapi package to the transport in the request context.
+//
+package endpoint
+
+import "context"
+
+type contextKey struct{}
+
+// Info represents the information about the API endpoint.
+//
+type Info struct {
+	Name  string
+	Index []string
+}
+
+// WithInfo returns a copy of ctx with the endpoint information.
+//
+func WithInfo(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, contextKey{}, info)
+}
+
+// FromContext returns the endpoint information stored in ctx, if any.
+//
+func FromContext(ctx context.Context) (Info, bool) {
+	info, ok := ctx.Value(contextKey{}).(Info)
+	return info, ok
+}
@@ -0,0 +1,30 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// +build !integration
+
+package jsonreader
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+type errReader struct{}
+
+func (errReader) Read(p []byte) (int, error)         { return 1, errors.New("MOCK ERROR") }
+func (errReader) Write(p []byte) (int, error)        { return 0, errors.New("MOCK ERROR") }
+func (errReader) WriteTo(w io.Writer) (int64, error) { return 0, errors.New("MOCK ERROR") }
+
+func TestReader(t *testing.T) {
+	t.Run("Read error", func(t *testing.T) {
+		b := []byte{}
+		r := Reader{val: map[string]string{"foo": "bar"}, buf: errReader{}}
+		_, err := r.Read(b)
+		if err == nil {
+			t.Fatalf("Expected error, got: %#v", err)
+		}
+	})
+}
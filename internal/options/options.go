@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more agreements.
+// Elasticsearch B.V. licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+// Package options passes the per-request options
+// from the esapi package to the transport in the request context.
+//
+package options
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type contextKey struct{}
+
+// Request represents the per-request overrides of the transport configuration.
+//
+// The zero values keep the configuration of the transport.
+//
+type Request struct {
+	MaxRetries    int           // Override the maximum number of attempts.
+	DisableRetry  bool          // Disable the retries.
+	RetryOnStatus []int         // Override the list of status codes for retry.
+	Header        http.Header   // Set the headers on the request, replacing the existing values.
+	Timeout       time.Duration // Limit the duration of the request, including the retries.
+}
+
+// WithRequest returns a copy of ctx with the request options,
+// merged with the options already stored in ctx.
+//
+func WithRequest(ctx context.Context, o Request) context.Context {
+	if prev, ok := FromContext(ctx); ok {
+		o = prev.Merge(o)
+	}
+	return context.WithValue(ctx, contextKey{}, o)
+}
+
+// FromContext returns the request options stored in ctx, if any.
+//
+func FromContext(ctx context.Context) (Request, bool) {
+	o, ok := ctx.Value(contextKey{}).(Request)
+	return o, ok
+}
+
+// Merge returns a copy of o overridden by the non-zero values of other.
+// The headers of other replace the headers of o with the same name.
+//
+func (o Request) Merge(other Request) Request {
+	if other.MaxRetries != 0 {
+		o.MaxRetries = other.MaxRetries
+	}
+	if other.DisableRetry {
+		o.DisableRetry = true
+	}
+	if len(other.RetryOnStatus) > 0 {
+		o.RetryOnStatus = other.RetryOnStatus
+	}
+	if other.Timeout != 0 {
+		o.Timeout = other.Timeout
+	}
+	if len(other.Header) > 0 {
+		h := make(http.Header, len(o.Header)+len(other.Header))
+		for k, vv := range o.Header {
+			h[http.CanonicalHeaderKey(k)] = append([]string(nil), vv...)
+		}
+		for k, vv := range other.Header {
+			h[http.CanonicalHeaderKey(k)] = append([]string(nil), vv...)
+		}
+		o.Header = h
+	}
+	return o
+}